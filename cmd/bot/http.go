@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"languager/internal/metrics"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+// trackedPoller reports whether the wrapped poller is currently running
+type trackedPoller struct {
+	tele.Poller
+	running atomic.Bool
+}
+
+// Poll runs the wrapped poller and tracks its state
+func (p *trackedPoller) Poll(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	p.running.Store(true)
+	defer p.running.Store(false)
+	p.Poller.Poll(b, dest, stop)
+}
+
+// Running reports whether updates are being received
+func (p *trackedPoller) Running() bool {
+	return p.running.Load()
+}
+
+// Failed delivers the error the wrapped poller stopped with on its own.
+// Pollers that only stop when asked never deliver anything.
+func (p *trackedPoller) Failed() <-chan error {
+	if f, ok := p.Poller.(interface{ Failed() <-chan error }); ok {
+		return f.Failed()
+	}
+	return nil
+}
+
+// newHTTPServer creates the health, readiness and metrics server
+func newHTTPServer(addr string, db *sql.DB, poller *trackedPoller) *http.Server {
+	mux := http.NewServeMux()
+
+	// Liveness: the process is up and serving HTTP
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok\n"))
+	})
+
+	// Readiness: the bot can actually do its job
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+		defer cancel()
+
+		if err := db.PingContext(ctx); err != nil {
+			http.Error(w, "database: "+err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if !poller.Running() {
+			http.Error(w, "bot poller is not running", http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ready\n"))
+	})
+
+	mux.Handle("/metrics", metrics.Handler())
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
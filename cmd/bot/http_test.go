@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"languager/internal/metrics"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+// blockingPoller polls until stopped
+type blockingPoller struct{}
+
+func (blockingPoller) Poll(_ *tele.Bot, _ chan tele.Update, stop chan struct{}) {
+	<-stop
+}
+
+func serve(t *testing.T, server *http.Server, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestHTTPServer_Healthz(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	server := newHTTPServer(":0", db, &trackedPoller{Poller: blockingPoller{}})
+
+	rec := serve(t, server, "/healthz")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHTTPServer_Readyz(t *testing.T) {
+	tests := []struct {
+		name          string
+		pingError     error
+		pollerRunning bool
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			name:          "ready",
+			pollerRunning: true,
+			expectedCode:  http.StatusOK,
+			expectedBody:  "ready",
+		},
+		{
+			name:          "database down",
+			pingError:     fmt.Errorf("connection refused"),
+			pollerRunning: true,
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedBody:  "database",
+		},
+		{
+			name:          "poller stopped",
+			pollerRunning: false,
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedBody:  "poller",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectPing().WillReturnError(tt.pingError)
+
+			poller := &trackedPoller{Poller: blockingPoller{}}
+			stop := make(chan struct{})
+			if tt.pollerRunning {
+				go poller.Poll(nil, nil, stop)
+				require.Eventually(t, poller.Running, time.Second, 5*time.Millisecond)
+			}
+			defer close(stop)
+
+			server := newHTTPServer(":0", db, poller)
+
+			rec := serve(t, server, "/readyz")
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestHTTPServer_Metrics(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	metrics.Updates.WithLabelValues("text").Inc()
+	metrics.CallbackDuration.WithLabelValues("view_days").Observe(0.01)
+
+	server := newHTTPServer(":0", db, &trackedPoller{Poller: blockingPoller{}})
+
+	rec := serve(t, server, "/metrics")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `languager_updates_total{type="text"}`)
+	assert.Contains(t, rec.Body.String(), "# TYPE languager_callback_duration_seconds histogram")
+}
+
+func TestTrackedPoller(t *testing.T) {
+	poller := &trackedPoller{Poller: blockingPoller{}}
+	assert.False(t, poller.Running())
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		poller.Poll(nil, nil, stop)
+		close(done)
+	}()
+
+	require.Eventually(t, poller.Running, time.Second, 5*time.Millisecond)
+
+	close(stop)
+	<-done
+	assert.False(t, poller.Running())
+}
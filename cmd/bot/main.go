@@ -3,16 +3,23 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"languager/internal/config"
+	"languager/internal/dictionary"
+	"languager/internal/domain"
 	"languager/internal/handler"
+	"languager/internal/repository"
+	"languager/internal/repository/cached"
 	"languager/internal/repository/postgres"
 	"languager/internal/service"
+	"languager/internal/shutdown"
 
 	"github.com/golang-migrate/migrate/v4"
 	postgresdb "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -31,20 +38,31 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// run returns only after the shutdown sequence, so states are flushed
+	// and the database is closed before a failure exits the process
+	if err := run(logger); err != nil {
+		logger.Fatal("Bot stopped with an error", zap.Error(err))
+	}
+	logger.Info("Bot stopped gracefully")
+}
+
+// run starts the bot and serves updates until a shutdown signal
+// or a failure of update delivery
+func run(logger *zap.Logger) error {
 	logger.Info("Starting Languager Bot")
 
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		logger.Fatal("Failed to load config", zap.Error(err))
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	logger.Info("Configuration loaded successfully")
+	logger.Info("Configuration loaded successfully", zap.Int("admins", len(cfg.AdminIDs)))
 
 	// Connect to database with retries
 	db, err := connectDatabase(cfg.DSN(), logger)
 	if err != nil {
-		logger.Fatal("Failed to connect to database", zap.Error(err))
+		return err
 	}
 	defer db.Close()
 
@@ -52,42 +70,176 @@ func main() {
 
 	// Run migrations
 	if err := runMigrations(db, logger); err != nil {
-		logger.Fatal("Failed to run migrations", zap.Error(err))
+		return err
 	}
 
 	logger.Info("Database migrations completed")
 
 	// Initialize repositories
-	userRepo := postgres.NewUserRepo(db)
+	var userRepo repository.UserRepository = postgres.NewUserRepo(db)
+	if cfg.UserCache.TTL > 0 {
+		userRepo = cached.NewUserRepo(userRepo, cfg.UserCache.Size, cfg.UserCache.TTL)
+		logger.Info("User and settings cache enabled",
+			zap.Int("size", cfg.UserCache.Size),
+			zap.Duration("ttl", cfg.UserCache.TTL),
+		)
+	}
 	wordRepo := postgres.NewWordRepo(db)
+	stateRepo := postgres.NewStateRepo(db)
+	reminderRepo := postgres.NewReminderRepo(db)
+	streakRepo := postgres.NewStreakRepo(db)
+	statsRepo := postgres.NewStatsRepo(db)
+	reportRepo := postgres.NewReportRepo(db)
+	var settingsRepo repository.SettingsRepository = postgres.NewSettingsRepo(db)
+	if cfg.UserCache.TTL > 0 {
+		// Settings are read several times per update
+		settingsRepo = cached.NewSettingsRepo(settingsRepo, cfg.UserCache.Size, cfg.UserCache.TTL)
+	}
+	deckRepo := postgres.NewDeckRepo(db)
+	groupRepo := postgres.NewGroupRepo(db)
+	achievementRepo := postgres.NewAchievementRepo(db)
+	auditRepo := postgres.NewAuditRepo(db)
 
 	// Initialize services
+	settingsService := service.NewSettingsService(settingsRepo, domain.Settings{
+		PageSize:    cfg.Defaults.PageSize,
+		HistoryDays: cfg.Defaults.HistoryDays,
+		HideDays:    cfg.Defaults.HideDays,
+		Location:    cfg.Location,
+		Direction:   domain.DefaultReviewDirection,
+		Language:    domain.LanguageAuto,
+		Pair:        domain.DefaultLanguagePair,
+	})
+	// Tracks in-flight handlers, background jobs and async event subscribers
+	coordinator := shutdown.NewCoordinator()
+
+	// Services publish word and review events, subscribers react to them
+	events := service.NewEventBus(coordinator, logger)
+
+	auditService := service.NewAuditService(auditRepo, cfg.AdminIDs)
 	authService := service.NewAuthService(userRepo, cfg.BotPassword)
-	wordService := service.NewWordService(wordRepo)
-	statsService := service.NewStatsService(wordRepo, logger)
+	wordService := service.NewWordService(wordRepo, events)
+	cleanupService := service.NewCleanupService(wordRepo, auditService, cfg.Defaults.HistoryDays, logger)
+	stateService := service.NewStateService(stateRepo)
+	reminderService := service.NewReminderService(reminderRepo, wordRepo, settingsService, settingsService, logger)
+	streakService := service.NewStreakService(streakRepo, settingsService, events)
+	statsService := service.NewStatsService(statsRepo, settingsService)
+	reportService := service.NewReportService(reportRepo, streakRepo, settingsService, logger)
+
+	var dict dictionary.Dictionary
+	if cfg.DictionaryDir != "" {
+		tsv, err := dictionary.LoadDir(cfg.DictionaryDir)
+		if err != nil {
+			return fmt.Errorf("failed to load dictionaries: %w", err)
+		}
+		dict = tsv
+		logger.Info("Dictionaries loaded", zap.String("dir", cfg.DictionaryDir))
+	}
+	dictionaryService := service.NewDictionaryService(dict)
+	searchService := service.NewSearchService(wordRepo)
+	deckService := service.NewDeckService(deckRepo)
+	groupService := service.NewGroupService(groupRepo)
+	achievementService := service.NewAchievementService(achievementRepo)
+
+	service.SubscribeMetrics(events)
+	service.Subscribe(events, "decks", service.Async, deckService.OnWordSaved)
+	service.Subscribe(events, "audit", service.Async, auditService.OnWordHidden)
+
+	// Restore dialog states saved on the previous shutdown
+	if n, err := stateService.Load(context.Background()); err != nil {
+		logger.Warn("Failed to restore user states", zap.Error(err))
+	} else {
+		logger.Info("User states restored", zap.Int("count", n))
+	}
 
 	// Initialize Telegram bot
+	poller := &trackedPoller{Poller: newPoller(cfg, logger)}
 	bot, err := tele.NewBot(tele.Settings{
 		Token:  cfg.BotToken,
-		Poller: &tele.LongPoller{Timeout: 10 * time.Second},
+		Poller: poller,
 	})
 	if err != nil {
-		logger.Fatal("Failed to create bot", zap.Error(err))
+		return fmt.Errorf("failed to create bot: %w", err)
+	}
+
+	if err := registerPoller(bot, cfg); err != nil {
+		return fmt.Errorf("failed to register update delivery: %w", err)
 	}
 
-	logger.Info("Telegram bot initialized")
+	logger.Info("Telegram bot initialized", zap.String("mode", cfg.BotMode))
+
+	// Background jobs are stopped as soon as shutdown starts
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	// Handlers get until the shutdown deadline, then their queries are aborted
+	requestsCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
 
 	// Initialize handler
-	h := handler.NewHandler(bot, authService, wordService, logger)
-	h.RegisterHandlers()
+	h := handler.NewHandler(bot, handler.Services{
+		Auth:        authService,
+		Word:        wordService,
+		State:       stateService,
+		Reminder:    reminderService,
+		Streak:      streakService,
+		Stats:       statsService,
+		Report:      reportService,
+		Settings:    settingsService,
+		Dictionary:  dictionaryService,
+		Search:      searchService,
+		Deck:        deckService,
+		Group:       groupService,
+		Achievement: achievementService,
+		Audit:       auditService,
+	}, cfg.RequestTimeout, logger)
+	h.RegisterHandlers(requestsCtx, coordinator)
+
+	// Achievements are announced by the handler, so they are subscribed once it exists
+	service.SubscribeAchievements(events, achievementService, h)
 
 	logger.Info("Handlers registered")
 
-	// Start cleanup job in background
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Start background jobs
+	coordinator.Go("cleanup", func() {
+		runPeriodic(jobsCtx, "cleanup", 24*time.Hour, logger, func(ctx context.Context) error {
+			return errors.Join(
+				cleanupService.CleanupOldData(ctx),
+				reminderService.PruneDeliveries(ctx),
+				reportService.PruneDeliveries(ctx),
+			)
+		})
+	})
+	coordinator.Go("reminders", func() {
+		runPeriodic(jobsCtx, "reminders", time.Minute, logger, func(ctx context.Context) error {
+			sent, err := reminderService.SendDue(ctx, time.Now(), h)
+			if sent > 0 {
+				logger.Info("Reminders sent", zap.Int("count", sent))
+			}
+			return err
+		})
+	})
+	coordinator.Go("weekly_reports", func() {
+		runPeriodic(jobsCtx, "weekly_reports", 15*time.Minute, logger, func(ctx context.Context) error {
+			sent, err := reportService.SendDue(ctx, time.Now(), h)
+			if sent > 0 {
+				logger.Info("Weekly reports sent", zap.Int("count", sent))
+			}
+			return err
+		})
+	})
 
-	go runCleanupJob(ctx, statsService, logger)
+	// Start health and metrics server
+	var httpServer *http.Server
+	if cfg.HTTPAddr != "" {
+		httpServer = newHTTPServer(cfg.HTTPAddr, db, poller)
+		go func() {
+			logger.Info("HTTP server listening", zap.String("addr", cfg.HTTPAddr))
+			if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("HTTP server failed", zap.Error(err))
+			}
+		}()
+	}
 
 	// Start bot in background
 	go func() {
@@ -99,15 +251,65 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
-	<-sigChan
-
-	logger.Info("Shutdown signal received, stopping bot...")
+	// Updates that can't be received, e.g. the webhook port is taken, stop the bot too
+	var deliveryErr error
+	select {
+	case <-sigChan:
+		logger.Info("Shutdown signal received, stopping bot...",
+			zap.Duration("timeout", cfg.ShutdownTimeout),
+		)
+	case deliveryErr = <-poller.Failed():
+		logger.Error("Update delivery failed, stopping bot...",
+			zap.Error(deliveryErr),
+			zap.Duration("timeout", cfg.ShutdownTimeout),
+		)
+	}
 
-	// Graceful shutdown
+	// Stop receiving updates; handlers that are already running keep going
 	bot.Stop()
-	cancel()
+	if err := unregisterPoller(bot, cfg); err != nil {
+		logger.Warn("Failed to unregister update delivery", zap.Error(err))
+	}
+	cancelJobs()
 
-	logger.Info("Bot stopped gracefully")
+	// Wait for in-flight handlers and background jobs
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	report := coordinator.Wait(waitCtx)
+	waitCancel()
+
+	// Abort whatever didn't make it in time
+	cancelRequests()
+
+	if report.Clean() {
+		logger.Info("All in-flight work finished", zap.Duration("elapsed", report.Elapsed))
+	} else {
+		logger.Warn("Shutdown deadline exceeded, abandoning in-flight work",
+			zap.Duration("elapsed", report.Elapsed),
+			zap.Strings("handlers", report.AbandonedHandlers),
+			zap.Strings("jobs", report.AbandonedJobs),
+		)
+	}
+
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer flushCancel()
+
+	// Persist dialog states so users can continue after restart
+	if n, err := stateService.Flush(flushCtx); err != nil {
+		logger.Error("Failed to flush user states", zap.Error(err))
+	} else {
+		logger.Info("User states flushed", zap.Int("count", n))
+	}
+
+	if httpServer != nil {
+		if err := httpServer.Shutdown(flushCtx); err != nil {
+			logger.Warn("Failed to shut down HTTP server", zap.Error(err))
+		}
+	}
+
+	if deliveryErr != nil {
+		return fmt.Errorf("update delivery failed: %w", deliveryErr)
+	}
+	return nil
 }
 
 // connectDatabase connects to PostgreSQL with retries
@@ -181,28 +383,28 @@ func runMigrations(db *sql.DB, logger *zap.Logger) error {
 	return nil
 }
 
-// runCleanupJob runs periodic cleanup of old data
-func runCleanupJob(ctx context.Context, statsService *service.StatsService, logger *zap.Logger) {
-	// Run cleanup once at startup
-	if err := statsService.CleanupOldData(); err != nil {
-		logger.Error("Failed to run initial cleanup", zap.Error(err))
+// runPeriodic runs job at startup and then every interval until ctx is cancelled
+func runPeriodic(ctx context.Context, name string, interval time.Duration, logger *zap.Logger, job func(context.Context) error) {
+	logger = logger.With(zap.String("job", name))
+
+	run := func() {
+		if err := job(ctx); err != nil && ctx.Err() == nil {
+			logger.Error("Background job failed", zap.Error(err))
+		}
 	}
 
-	// Then run every 24 hours
-	ticker := time.NewTicker(24 * time.Hour)
+	run()
+
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Cleanup job stopped")
+			logger.Info("Background job stopped")
 			return
 		case <-ticker.C:
-			logger.Info("Running scheduled cleanup")
-			if err := statsService.CleanupOldData(); err != nil {
-				logger.Error("Failed to run scheduled cleanup", zap.Error(err))
-			}
+			run()
 		}
 	}
 }
-
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"languager/internal/config"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+// newPoller creates the update poller selected by BOT_MODE
+func newPoller(cfg *config.Config, logger *zap.Logger) tele.Poller {
+	if cfg.BotMode == config.BotModeWebhook {
+		return newWebhookPoller(cfg.Webhook, logger)
+	}
+	return &tele.LongPoller{Timeout: 10 * time.Second}
+}
+
+// registerPoller tells Telegram how updates should be delivered
+func registerPoller(bot *tele.Bot, cfg *config.Config) error {
+	if cfg.BotMode != config.BotModeWebhook {
+		// getUpdates doesn't work while a webhook is set
+		if err := bot.RemoveWebhook(); err != nil {
+			return fmt.Errorf("failed to remove webhook: %w", err)
+		}
+		return nil
+	}
+
+	hook := &tele.Webhook{
+		SecretToken: cfg.Webhook.SecretToken,
+		Endpoint: &tele.WebhookEndpoint{
+			PublicURL: cfg.Webhook.PublicURL,
+			// Uploaded so that Telegram trusts self-signed certificates
+			Cert: cfg.Webhook.TLSCert,
+		},
+	}
+	if err := bot.SetWebhook(hook); err != nil {
+		return fmt.Errorf("failed to set webhook: %w", err)
+	}
+	return nil
+}
+
+// unregisterPoller removes the webhook so Telegram stops sending updates
+func unregisterPoller(bot *tele.Bot, cfg *config.Config) error {
+	if cfg.BotMode != config.BotModeWebhook {
+		return nil
+	}
+	if err := bot.RemoveWebhook(); err != nil {
+		return fmt.Errorf("failed to remove webhook: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"languager/internal/config"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+const secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// webhookPoller receives updates from Telegram over HTTP(S).
+// Registration with Telegram is done separately by registerPoller,
+// so a failed setWebhook call stops the bot at startup.
+type webhookPoller struct {
+	cfg    config.WebhookConfig
+	logger *zap.Logger
+	failed chan error
+}
+
+// newWebhookPoller creates a webhook poller
+func newWebhookPoller(cfg config.WebhookConfig, logger *zap.Logger) *webhookPoller {
+	return &webhookPoller{cfg: cfg, logger: logger, failed: make(chan error, 1)}
+}
+
+// Failed delivers the error the webhook server stopped with, e.g. a port already in use
+func (p *webhookPoller) Failed() <-chan error {
+	return p.failed
+}
+
+// Poll serves webhook requests until the bot is stopped
+func (p *webhookPoller) Poll(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	server := &http.Server{
+		Addr:              p.cfg.Listen,
+		Handler:           p.handler(dest),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-stop
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			p.logger.Warn("Failed to shut down webhook server", zap.Error(err))
+		}
+	}()
+
+	p.logger.Info("Webhook server listening",
+		zap.String("listen", p.cfg.Listen),
+		zap.Bool("tls", p.cfg.TLSCert != ""),
+	)
+
+	var err error
+	if p.cfg.TLSCert != "" {
+		err = server.ListenAndServeTLS(p.cfg.TLSCert, p.cfg.TLSKey)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		p.logger.Error("Webhook server failed", zap.Error(err))
+		p.failed <- fmt.Errorf("webhook server: %w", err)
+	}
+}
+
+// handler validates incoming requests and forwards updates to the bot
+func (p *webhookPoller) handler(dest chan<- tele.Update) http.Handler {
+	path := webhookPath(p.cfg.PublicURL)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		token := r.Header.Get(secretTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(p.cfg.SecretToken)) != 1 {
+			p.logger.Warn("Webhook request with invalid secret token",
+				zap.String("remote_addr", r.RemoteAddr),
+			)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var update tele.Update
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			p.logger.Warn("Failed to decode webhook update", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case dest <- update:
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+			// Telegram will retry the update later
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+}
+
+// webhookPath returns the path part of the public URL that Telegram posts to
+func webhookPath(publicURL string) string {
+	u, err := url.Parse(publicURL)
+	if err != nil || u.Path == "" {
+		return "/"
+	}
+	return u.Path
+}
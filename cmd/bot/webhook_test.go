@@ -0,0 +1,294 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"languager/internal/config"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+// fakeTelegramAPI records Bot API calls and answers them successfully
+type fakeTelegramAPI struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	calls map[string]map[string]interface{}
+}
+
+func newFakeTelegramAPI(t *testing.T) *fakeTelegramAPI {
+	api := &fakeTelegramAPI{calls: make(map[string]map[string]interface{})}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		params := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+
+		api.mu.Lock()
+		api.calls[method] = params
+		api.mu.Unlock()
+
+		io.WriteString(w, `{"ok":true,"result":true}`)
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+func (a *fakeTelegramAPI) call(method string) (map[string]interface{}, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	params, ok := a.calls[method]
+	return params, ok
+}
+
+func freeAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func webhookTestConfig(t *testing.T) *config.Config {
+	return &config.Config{
+		BotMode: config.BotModeWebhook,
+		Webhook: config.WebhookConfig{
+			Listen:      freeAddr(t),
+			PublicURL:   "https://bot.example.com/telegram",
+			SecretToken: "s3cret",
+		},
+	}
+}
+
+func newTestBot(t *testing.T, api *fakeTelegramAPI, poller tele.Poller) *tele.Bot {
+	bot, err := tele.NewBot(tele.Settings{
+		URL:     api.URL,
+		Token:   "test-token",
+		Poller:  poller,
+		Offline: true,
+	})
+	require.NoError(t, err)
+	return bot
+}
+
+// postUpdate posts an update to the local webhook server, retrying until it is up
+func postUpdate(t *testing.T, addr, path, secret, body string) int {
+	var lastErr error
+	for i := 0; i < 50; i++ {
+		req, err := http.NewRequest(http.MethodPost, "http://"+addr+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if secret != "" {
+			req.Header.Set(secretTokenHeader, secret)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			lastErr = err
+			time.Sleep(20 * time.Millisecond)
+			continue
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	t.Fatalf("webhook server did not start: %v", lastErr)
+	return 0
+}
+
+const sampleTextUpdate = `{
+	"update_id": 1001,
+	"message": {
+		"message_id": 7,
+		"date": 1734000000,
+		"from": {"id": 123, "is_bot": false, "first_name": "Test"},
+		"chat": {"id": 123, "type": "private"},
+		"text": "hello"
+	}
+}`
+
+const sampleCallbackUpdate = `{
+	"update_id": 1002,
+	"callback_query": {
+		"id": "cb1",
+		"from": {"id": 123, "is_bot": false, "first_name": "Test"},
+		"data": "random_pair"
+	}
+}`
+
+func TestWebhookPoller_DeliversUpdates(t *testing.T) {
+	api := newFakeTelegramAPI(t)
+	cfg := webhookTestConfig(t)
+	bot := newTestBot(t, api, newWebhookPoller(cfg.Webhook, testutil.NewTestLogger()))
+
+	received := make(chan tele.Update, 2)
+	bot.Handle(tele.OnText, func(c tele.Context) error {
+		received <- c.Update()
+		return nil
+	})
+	bot.Handle(tele.OnCallback, func(c tele.Context) error {
+		received <- c.Update()
+		return nil
+	})
+
+	go bot.Start()
+	defer bot.Stop()
+
+	status := postUpdate(t, cfg.Webhook.Listen, "/telegram", "s3cret", sampleTextUpdate)
+	assert.Equal(t, http.StatusOK, status)
+
+	select {
+	case u := <-received:
+		assert.Equal(t, 1001, u.ID)
+		assert.Equal(t, "hello", u.Message.Text)
+		assert.Equal(t, int64(123), u.Message.Sender.ID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("text update was not delivered to the handler")
+	}
+
+	status = postUpdate(t, cfg.Webhook.Listen, "/telegram", "s3cret", sampleCallbackUpdate)
+	assert.Equal(t, http.StatusOK, status)
+
+	select {
+	case u := <-received:
+		assert.Equal(t, 1002, u.ID)
+		assert.Equal(t, "random_pair", u.Callback.Data)
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback update was not delivered to the handler")
+	}
+}
+
+func TestWebhookPoller_ReportsServerFailure(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer busy.Close()
+
+	cfg := webhookTestConfig(t)
+	cfg.Webhook.Listen = busy.Addr().String()
+	poller := &trackedPoller{Poller: newWebhookPoller(cfg.Webhook, testutil.NewTestLogger())}
+
+	stopped := make(chan struct{})
+	go func() {
+		poller.Poll(nil, make(chan tele.Update), make(chan struct{}))
+		close(stopped)
+	}()
+
+	select {
+	case err := <-poller.Failed():
+		assert.ErrorContains(t, err, "address already in use")
+	case <-time.After(2 * time.Second):
+		t.Fatal("server failure was not reported")
+	}
+	<-stopped
+	assert.False(t, poller.Running())
+}
+
+func TestWebhookPoller_RejectsInvalidRequests(t *testing.T) {
+	api := newFakeTelegramAPI(t)
+	cfg := webhookTestConfig(t)
+	bot := newTestBot(t, api, newWebhookPoller(cfg.Webhook, testutil.NewTestLogger()))
+
+	received := make(chan tele.Update, 1)
+	bot.Handle(tele.OnText, func(c tele.Context) error {
+		received <- c.Update()
+		return nil
+	})
+
+	go bot.Start()
+	defer bot.Stop()
+
+	tests := []struct {
+		name     string
+		path     string
+		secret   string
+		body     string
+		expected int
+	}{
+		{
+			name:     "missing secret",
+			path:     "/telegram",
+			body:     sampleTextUpdate,
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "wrong secret",
+			path:     "/telegram",
+			secret:   "guess",
+			body:     sampleTextUpdate,
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "wrong path",
+			path:     "/other",
+			secret:   "s3cret",
+			body:     sampleTextUpdate,
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "malformed body",
+			path:     "/telegram",
+			secret:   "s3cret",
+			body:     "{not json",
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := postUpdate(t, cfg.Webhook.Listen, tt.path, tt.secret, tt.body)
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+
+	select {
+	case u := <-received:
+		t.Fatalf("unexpected update delivered: %d", u.ID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRegisterPoller_Webhook(t *testing.T) {
+	api := newFakeTelegramAPI(t)
+	cfg := webhookTestConfig(t)
+	bot := newTestBot(t, api, newWebhookPoller(cfg.Webhook, testutil.NewTestLogger()))
+
+	require.NoError(t, registerPoller(bot, cfg))
+
+	params, ok := api.call("setWebhook")
+	require.True(t, ok, "setWebhook was not called")
+	assert.Equal(t, "https://bot.example.com/telegram", params["url"])
+	assert.Equal(t, "s3cret", params["secret_token"])
+
+	require.NoError(t, unregisterPoller(bot, cfg))
+
+	_, ok = api.call("deleteWebhook")
+	assert.True(t, ok, "deleteWebhook was not called")
+}
+
+func TestRegisterPoller_Polling(t *testing.T) {
+	api := newFakeTelegramAPI(t)
+	cfg := &config.Config{BotMode: config.BotModePolling}
+	bot := newTestBot(t, api, newPoller(cfg, testutil.NewTestLogger()))
+
+	require.NoError(t, registerPoller(bot, cfg))
+
+	_, ok := api.call("deleteWebhook")
+	assert.True(t, ok, "webhook must be removed before long polling")
+	_, ok = api.call("setWebhook")
+	assert.False(t, ok)
+}
+
+func TestWebhookPath(t *testing.T) {
+	assert.Equal(t, "/telegram", webhookPath("https://bot.example.com/telegram"))
+	assert.Equal(t, "/", webhookPath("https://bot.example.com"))
+	assert.Equal(t, "/", webhookPath("://bad"))
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_GetSet(t *testing.T) {
+	c := NewLRU[int64, string](10, time.Minute)
+
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+
+	c.Set(1, "one")
+	value, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", value)
+
+	c.Set(1, "uno")
+	value, ok = c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "uno", value)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestLRU_Expiry(t *testing.T) {
+	now := time.Date(2024, 12, 12, 10, 0, 0, 0, time.UTC)
+	c := NewLRU[int64, string](10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set(1, "one")
+
+	now = now.Add(59 * time.Second)
+	_, ok := c.Get(1)
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = c.Get(1)
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[int64, string](2, time.Minute)
+
+	c.Set(1, "one")
+	c.Set(2, "two")
+
+	// Touch 1 so that 2 becomes the oldest entry
+	c.Get(1)
+	c.Set(3, "three")
+
+	_, ok := c.Get(2)
+	assert.False(t, ok)
+	_, ok = c.Get(1)
+	assert.True(t, ok)
+	_, ok = c.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLRU_Delete(t *testing.T) {
+	c := NewLRU[int64, string](10, time.Minute)
+
+	c.Set(1, "one")
+	c.Delete(1)
+	c.Delete(2)
+
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
@@ -0,0 +1,36 @@
+package chart
+
+import (
+	"image"
+	"strconv"
+)
+
+// niceStep returns the smallest of 1, 2, 5, 10, 20, 50... not less than x
+func niceStep(x float64) int {
+	for p := 1; ; p *= 10 {
+		for _, m := range []int{1, 2, 5} {
+			if float64(m*p) >= x {
+				return m * p
+			}
+		}
+	}
+}
+
+// scale returns a nice axis top and grid step for values up to peak
+func scale(peak int) (top, step int) {
+	step = niceStep(float64(peak) / 4)
+	top = (peak + step - 1) / step * step
+	if top == 0 {
+		top = step
+	}
+	return top, step
+}
+
+// drawGrid draws horizontal grid lines every step up to top with labels on the left
+func drawGrid(img *image.RGBA, area image.Rectangle, top, step int, suffix string) {
+	for v := 0; v <= top; v += step {
+		y := area.Max.Y - v*area.Dy()/top
+		hLine(img, area.Min.X, area.Max.X, y, colorAxis)
+		drawTextRight(img, area.Min.X-6, y-textHeight/2, strconv.Itoa(v)+suffix, colorText)
+	}
+}
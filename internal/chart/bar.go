@@ -0,0 +1,45 @@
+package chart
+
+import (
+	"image"
+	"strconv"
+)
+
+// Bars renders values as a bar chart. Labels are drawn under the bars,
+// empty labels are skipped.
+func Bars(labels []string, values []int) *image.RGBA {
+	img := newCanvas(Width, Height)
+	area := plotArea()
+
+	peak := 0
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+	top, step := scale(peak)
+	drawGrid(img, area, top, step, "")
+
+	if len(values) == 0 {
+		return img
+	}
+
+	slot := area.Dx() / len(values)
+	barWidth := slot * 3 / 5
+
+	for i, v := range values {
+		x := area.Min.X + i*slot + (slot-barWidth)/2
+		h := v * area.Dy() / top
+		fillRect(img, image.Rect(x, area.Max.Y-h, x+barWidth, area.Max.Y), colorBar)
+
+		center := x + barWidth/2
+		if v > 0 {
+			drawTextCentered(img, center, area.Max.Y-h-textHeight-4, strconv.Itoa(v), colorText)
+		}
+		if i < len(labels) && labels[i] != "" {
+			drawTextCentered(img, center, area.Max.Y+8, labels[i], colorText)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,102 @@
+// Package chart renders simple PNG charts using only the standard library.
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+// Canvas size and plot margins, in pixels
+const (
+	Width  = 600
+	Height = 300
+
+	marginLeft   = 50
+	marginRight  = 20
+	marginTop    = 20
+	marginBottom = 30
+)
+
+// Palette
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorAxis       = color.RGBA{0xcc, 0xcc, 0xcc, 0xff}
+	colorText       = color.RGBA{0x55, 0x55, 0x55, 0xff}
+	colorBar        = color.RGBA{0x4a, 0x90, 0xd9, 0xff}
+	colorLine       = color.RGBA{0x2e, 0xa0, 0x4f, 0xff}
+)
+
+// EncodePNG encodes the image as PNG
+func EncodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// newCanvas returns a blank canvas of the given size
+func newCanvas(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{colorBackground}, image.Point{}, draw.Src)
+	return img
+}
+
+// fillRect fills the rectangle with a color
+func fillRect(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r.Intersect(img.Bounds()), &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// hLine draws a horizontal line from x0 to x1 at y
+func hLine(img *image.RGBA, x0, x1, y int, c color.Color) {
+	fillRect(img, image.Rect(x0, y, x1+1, y+1), c)
+}
+
+// drawLine draws a line of the given thickness between two points
+func drawLine(img *image.RGBA, x0, y0, x1, y1, thickness int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	err := dx + dy
+	half := thickness / 2
+
+	for {
+		fillRect(img, image.Rect(x0-half, y0-half, x0-half+thickness, y0-half+thickness), c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// plotArea returns the rectangle inside the margins
+func plotArea() image.Rectangle {
+	return image.Rect(marginLeft, marginTop, Width-marginRight, Height-marginBottom)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
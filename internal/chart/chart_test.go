@@ -0,0 +1,114 @@
+package chart
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// assertGolden compares img with testdata/name pixel by pixel
+func assertGolden(t *testing.T, name string, img image.Image) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	data, err := EncodePNG(img)
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+		return
+	}
+
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test ./internal/chart -update to create golden files")
+
+	want, err := png.Decode(bytes.NewReader(golden))
+	require.NoError(t, err)
+	got, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	require.Equal(t, want.Bounds(), got.Bounds())
+	for y := want.Bounds().Min.Y; y < want.Bounds().Max.Y; y++ {
+		for x := want.Bounds().Min.X; x < want.Bounds().Max.X; x++ {
+			if want.At(x, y) != got.At(x, y) {
+				t.Fatalf("%s differs from golden file at (%d, %d)", name, x, y)
+			}
+		}
+	}
+}
+
+func TestBars_Golden(t *testing.T) {
+	labels := []string{"10.12", "11.12", "12.12", "13.12", "14.12", "15.12", "16.12"}
+	values := []int{2, 0, 5, 12, 7, 1, 3}
+
+	assertGolden(t, "bars.png", Bars(labels, values))
+}
+
+func TestBars_Empty_Golden(t *testing.T) {
+	assertGolden(t, "bars_empty.png", Bars(nil, nil))
+}
+
+func TestLine_Golden(t *testing.T) {
+	nan := math.NaN()
+	labels := make([]string, 14)
+	labels[0], labels[7], labels[13] = "03.12", "10.12", "16.12"
+	values := []float64{50, 60, nan, 80, 75, 90, 100, nan, nan, 40, 70, 85, 95, 90}
+
+	assertGolden(t, "line.png", Line(labels, values, 100, "%"))
+}
+
+func TestHeatmap_Golden(t *testing.T) {
+	start := time.Date(2024, 9, 23, 0, 0, 0, 0, time.UTC) // Monday
+	values := make([]int, 12*7-3)
+	for i := range values {
+		values[i] = (i * 7) % 11
+		if i%5 == 0 {
+			values[i] = 0
+		}
+	}
+
+	assertGolden(t, "heatmap.png", Heatmap(start, values))
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		peak, top, step int
+	}{
+		{0, 1, 1},
+		{3, 3, 1},
+		{12, 15, 5},
+		{100, 100, 50},
+		{37, 40, 10},
+	}
+
+	for _, tt := range tests {
+		top, step := scale(tt.peak)
+		assert.Equal(t, tt.top, top, "top for %d", tt.peak)
+		assert.Equal(t, tt.step, step, "step for %d", tt.peak)
+	}
+}
+
+func TestHeatLevel(t *testing.T) {
+	assert.Equal(t, 0, heatLevel(0, 10))
+	assert.Equal(t, 1, heatLevel(1, 10))
+	assert.Equal(t, 2, heatLevel(5, 10))
+	assert.Equal(t, 4, heatLevel(10, 10))
+	assert.Equal(t, 0, heatLevel(5, 0))
+}
+
+func TestTextWidth(t *testing.T) {
+	assert.Equal(t, 0, textWidth(""))
+	assert.Equal(t, 6, textWidth("1"))
+	assert.Equal(t, 14, textWidth("12"))
+}
@@ -0,0 +1,78 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+)
+
+// Glyphs of a 3x5 bitmap font, one row per string, '#' is a set pixel.
+// Only characters needed for axis labels are included.
+var glyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", ".#.", ".#.", ".#."},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'.': {"...", "...", "...", "...", ".#."},
+	':': {"...", ".#.", "...", ".#.", "..."},
+	'-': {"...", "...", "###", "...", "..."},
+	'/': {"..#", "..#", ".#.", "#..", "#.."},
+	'%': {"#.#", "..#", ".#.", "#..", "#.#"},
+	' ': {"...", "...", "...", "...", "..."},
+}
+
+const (
+	glyphWidth  = 3
+	glyphHeight = 5
+	// fontScale enlarges every glyph pixel to a square
+	fontScale = 2
+	// glyphSpacing is the gap between characters in font pixels
+	glyphSpacing = 1
+)
+
+// textWidth returns the rendered width of s in pixels
+func textWidth(s string) int {
+	n := len([]rune(s))
+	if n == 0 {
+		return 0
+	}
+	return (n*(glyphWidth+glyphSpacing) - glyphSpacing) * fontScale
+}
+
+// textHeight is the rendered height of a line of text in pixels
+const textHeight = glyphHeight * fontScale
+
+// drawText draws s with its top-left corner at (x, y); unknown characters are skipped
+func drawText(img *image.RGBA, x, y int, s string, c color.Color) {
+	for _, r := range s {
+		glyph, ok := glyphs[r]
+		if ok {
+			for row, line := range glyph {
+				for col, px := range line {
+					if px == '#' {
+						fillRect(img, image.Rect(
+							x+col*fontScale, y+row*fontScale,
+							x+(col+1)*fontScale, y+(row+1)*fontScale,
+						), c)
+					}
+				}
+			}
+		}
+		x += (glyphWidth + glyphSpacing) * fontScale
+	}
+}
+
+// drawTextCentered draws s horizontally centered at x
+func drawTextCentered(img *image.RGBA, x, y int, s string, c color.Color) {
+	drawText(img, x-textWidth(s)/2, y, s, c)
+}
+
+// drawTextRight draws s so that it ends at x
+func drawTextRight(img *image.RGBA, x, y int, s string, c color.Color) {
+	drawText(img, x-textWidth(s), y, s, c)
+}
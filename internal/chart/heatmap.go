@@ -0,0 +1,77 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"time"
+)
+
+// Heatmap intensity levels, from no activity to the busiest days
+var heatLevels = []color.RGBA{
+	{0xeb, 0xed, 0xf0, 0xff},
+	{0xc6, 0xe4, 0x8b, 0xff},
+	{0x7b, 0xc9, 0x6f, 0xff},
+	{0x23, 0x9a, 0x3b, 0xff},
+	{0x19, 0x61, 0x27, 0xff},
+}
+
+const (
+	heatCellGap = 4
+	// heatLabelEvery is how often weeks get a date label
+	heatLabelEvery = 4
+)
+
+// Heatmap renders daily values as a calendar: one column per week and
+// one row per weekday, Monday on top. values[i] belongs to start+i days;
+// start should be a Monday.
+func Heatmap(start time.Time, values []int) *image.RGBA {
+	img := newCanvas(Width, Height)
+	if len(values) == 0 {
+		return img
+	}
+
+	peak := 0
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+
+	weeks := (len(values) + 6) / 7
+	cell := (Width - marginLeft - marginRight) / weeks
+	if rowCell := (Height - marginTop - marginBottom) / 7; rowCell < cell {
+		cell = rowCell
+	}
+	size := cell - heatCellGap
+
+	// Center the calendar horizontally
+	left := (Width - weeks*cell + heatCellGap) / 2
+	top := marginTop
+
+	for i, v := range values {
+		week, weekday := i/7, i%7
+		x := left + week*cell
+		y := top + weekday*cell
+		fillRect(img, image.Rect(x, y, x+size, y+size), heatLevels[heatLevel(v, peak)])
+
+		if weekday == 0 && week%heatLabelEvery == 0 {
+			label := start.AddDate(0, 0, i).Format("02.01")
+			drawText(img, x, top+7*cell+4, label, colorText)
+		}
+	}
+
+	return img
+}
+
+// heatLevel maps a value to one of heatLevels
+func heatLevel(v, peak int) int {
+	if v <= 0 || peak <= 0 {
+		return 0
+	}
+	levels := len(heatLevels) - 1
+	level := (v*levels + peak - 1) / peak
+	if level > levels {
+		level = levels
+	}
+	return level
+}
@@ -0,0 +1,56 @@
+package chart
+
+import (
+	"image"
+	"math"
+)
+
+// lineThickness is the width of lines and points in Line charts
+const lineThickness = 3
+
+// Line renders values from 0 to top as a line chart with grid labels
+// ending in suffix, e.g. "%". NaN values are gaps in the line.
+// Labels are drawn under the points, empty labels are skipped.
+func Line(labels []string, values []float64, top int, suffix string) *image.RGBA {
+	img := newCanvas(Width, Height)
+	area := plotArea()
+
+	_, step := scale(top)
+	drawGrid(img, area, top, step, suffix)
+
+	if len(values) == 0 {
+		return img
+	}
+
+	pointX := func(i int) int {
+		if len(values) == 1 {
+			return area.Min.X + area.Dx()/2
+		}
+		return area.Min.X + i*area.Dx()/(len(values)-1)
+	}
+	pointY := func(v float64) int {
+		v = math.Max(0, math.Min(v, float64(top)))
+		return area.Max.Y - int(math.Round(v*float64(area.Dy())/float64(top)))
+	}
+
+	prev := -1
+	for i, v := range values {
+		if i < len(labels) && labels[i] != "" {
+			drawTextCentered(img, pointX(i), area.Max.Y+8, labels[i], colorText)
+		}
+		if math.IsNaN(v) {
+			prev = -1
+			continue
+		}
+
+		x, y := pointX(i), pointY(v)
+		if prev >= 0 {
+			drawLine(img, pointX(prev), pointY(values[prev]), x, y, lineThickness, colorLine)
+		}
+		// Mark the point so isolated values are visible
+		fillRect(img, image.Rect(x-lineThickness, y-lineThickness, x+lineThickness+1, y+lineThickness+1), colorLine)
+		prev = i
+	}
+
+	return img
+}
@@ -0,0 +1,139 @@
+// Package cloze makes fill-in-the-blank cards from example sentences.
+package cloze
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Blank replaces the word in card texts
+const Blank = "＿＿＿"
+
+// Inflections are matched by prefix: the sentence form must start with the
+// word minus up to maxSuffixCut runes, keeping at least minStem of them,
+// and be at most maxSuffixAdd runes longer than that stem
+// ("run" matches "running", "книга" matches "книгу").
+const (
+	maxSuffixCut = 2
+	maxSuffixAdd = 4
+	minStem      = 3
+)
+
+// Card is a sentence with the word blanked out
+type Card struct {
+	Text   string // the sentence with Blank in place of the word
+	Answer string // the word as written in the sentence
+}
+
+// token is a word or the text between words, with its byte offsets
+type token struct {
+	text       string
+	start, end int
+	word       bool
+}
+
+// tokenize splits s into words and separators. Words are runs of letters,
+// digits and combining marks; apostrophes and hyphens inside a word keep it whole.
+func tokenize(s string) []token {
+	var tokens []token
+	start, inWord := 0, false
+
+	for i, r := range s {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+		if !isWord && inWord && (r == '\'' || r == '’' || r == '-') {
+			// Joiner: part of the word only if a letter follows
+			next, _ := utf8.DecodeRuneInString(s[i+utf8.RuneLen(r):])
+			isWord = unicode.IsLetter(next)
+		}
+		if i > 0 && isWord != inWord {
+			tokens = append(tokens, token{text: s[start:i], start: start, end: i, word: inWord})
+			start = i
+		}
+		inWord = isWord
+	}
+	if start < len(s) {
+		tokens = append(tokens, token{text: s[start:], start: start, end: len(s), word: inWord})
+	}
+	return tokens
+}
+
+// words returns the word tokens of s
+func words(s string) []token {
+	var result []token
+	for _, t := range tokenize(s) {
+		if t.word {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// Make blanks the first occurrence of word in sentence.
+// Phrases blank all their words at once. Returns false if the sentence
+// doesn't contain the word in any recognisable form.
+func Make(sentence, word string) (Card, bool) {
+	target := words(word)
+	if len(target) == 0 {
+		return Card{}, false
+	}
+	tokens := words(sentence)
+
+	for i := 0; i+len(target) <= len(tokens); i++ {
+		matched := true
+		for j, t := range target {
+			if !matches(tokens[i+j].text, t.text) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		start, end := tokens[i].start, tokens[i+len(target)-1].end
+		return Card{
+			Text:   sentence[:start] + Blank + sentence[end:],
+			Answer: sentence[start:end],
+		}, true
+	}
+	return Card{}, false
+}
+
+// matches reports whether form is word or its inflection
+func matches(form, word string) bool {
+	form, word = fold(form), fold(word)
+	if form == word {
+		return true
+	}
+
+	stem := []rune(word)
+	cut := len(stem) - minStem
+	if cut < 0 {
+		// Too short to guess inflections
+		return false
+	}
+	if cut > maxSuffixCut {
+		cut = maxSuffixCut
+	}
+	stem = stem[:len(stem)-cut]
+
+	f := []rune(form)
+	return strings.HasPrefix(form, string(stem)) && len(f)-len(stem) <= maxSuffixAdd
+}
+
+// Check reports whether the typed answer is one of the accepted ones.
+// Case, extra spaces and ё/е are ignored.
+func Check(input string, accepted ...string) bool {
+	input = strings.Join(strings.Fields(fold(input)), " ")
+	for _, a := range accepted {
+		if input != "" && input == strings.Join(strings.Fields(fold(a)), " ") {
+			return true
+		}
+	}
+	return false
+}
+
+func fold(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), "ё", "е")
+}
@@ -0,0 +1,61 @@
+package cloze
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name     string
+		sentence string
+		word     string
+		text     string
+		answer   string
+		ok       bool
+	}{
+		{"exact", "I like apples.", "like", "I ＿＿＿ apples.", "like", true},
+		{"case", "Run every morning", "run", "＿＿＿ every morning", "Run", true},
+		{"inflection", "She is running late.", "run", "She is ＿＿＿ late.", "running", true},
+		{"cut ending", "He studied hard", "study", "He ＿＿＿ hard", "studied", true},
+		{"cyrillic", "Я читаю книгу.", "книга", "Я читаю ＿＿＿.", "книгу", true},
+		{"yo", "Всё хорошо", "все", "＿＿＿ хорошо", "Всё", true},
+		{"umlaut", "Die Mädchen spielen", "Mädchen", "Die ＿＿＿ spielen", "Mädchen", true},
+		{"apostrophe", "It's the dog's bone", "dog's", "It's the ＿＿＿ bone", "dog's", true},
+		{"phrase", "Please give up smoking", "give up", "Please ＿＿＿ smoking", "give up", true},
+		{"first occurrence", "cat and cat", "cat", "＿＿＿ and cat", "cat", true},
+		// Not a whole word or too far from it
+		{"inside word", "concatenate", "cat", "", "", false},
+		{"short word", "goes", "go", "", "", false},
+		{"too long", "runnerships", "run", "", "", false},
+		{"missing", "Nothing here", "apple", "", "", false},
+		{"empty word", "Nothing here", "!", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, ok := Make(tt.sentence, tt.word)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.text, card.Text)
+			assert.Equal(t, tt.answer, card.Answer)
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	var texts []string
+	for _, tok := range words("Well-known, isn't it? -dash 'quoted' x2") {
+		texts = append(texts, tok.text)
+	}
+	assert.Equal(t, []string{"Well-known", "isn't", "it", "dash", "quoted", "x2"}, texts)
+}
+
+func TestCheck(t *testing.T) {
+	assert.True(t, Check("Running", "running"))
+	assert.True(t, Check("  give   up ", "give up"))
+	assert.True(t, Check("всё", "все"))
+	assert.True(t, Check("run", "running", "run"), "base form is accepted too")
+	assert.False(t, Check("ran", "running", "run"))
+	assert.False(t, Check("", ""))
+}
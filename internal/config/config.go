@@ -3,15 +3,54 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"languager/internal/domain"
 
 	"github.com/joho/godotenv"
 )
 
+// Bot update delivery modes
+const (
+	BotModePolling = "polling"
+	BotModeWebhook = "webhook"
+)
+
 // Config holds all application configuration
 type Config struct {
 	BotToken    string
 	BotPassword string
+	BotMode     string
+	Webhook     WebhookConfig
 	Database    DatabaseConfig
+	UserCache   CacheConfig
+	Defaults    DefaultsConfig
+	HTTPAddr    string // health and metrics server, empty disables it
+
+	// Directory of <source>-<target>.tsv dictionaries, empty disables suggestions
+	DictionaryDir string
+
+	// Telegram user IDs allowed to read the audit log and revoke users
+	AdminIDs []int64
+
+	// Default time zone for reminders and day boundaries
+	Location *time.Location
+
+	// Deadline for handling a single update, including DB queries
+	RequestTimeout time.Duration
+	// How long shutdown waits for in-flight handlers and jobs
+	ShutdownTimeout time.Duration
+}
+
+// WebhookConfig holds settings for receiving updates via webhook
+type WebhookConfig struct {
+	Listen      string // local address to listen on, e.g. :8443
+	PublicURL   string // URL registered in Telegram
+	SecretToken string // checked against X-Telegram-Bot-Api-Secret-Token
+	TLSCert     string // optional, serve HTTPS directly
+	TLSKey      string
 }
 
 // DatabaseConfig holds database connection settings
@@ -23,14 +62,37 @@ type DatabaseConfig struct {
 	Password string
 }
 
+// CacheConfig holds settings of the in-process user and settings caches
+type CacheConfig struct {
+	Size int
+	TTL  time.Duration // 0 disables the cache
+}
+
+// DefaultsConfig holds defaults of per-user settings
+type DefaultsConfig struct {
+	PageSize    int // days per page in the day list
+	HistoryDays int // how long words are kept and listed
+	HideDays    int // extra snooze duration offered on cards
+}
+
 // Load reads configuration from environment variables
 func Load() (*Config, error) {
 	// Try to load .env file (ignore error if not exists)
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		BotToken:    os.Getenv("BOT_TOKEN"),
-		BotPassword: os.Getenv("BOT_PASSWORD"),
+		BotToken:      os.Getenv("BOT_TOKEN"),
+		BotPassword:   os.Getenv("BOT_PASSWORD"),
+		BotMode:       getEnv("BOT_MODE", BotModePolling),
+		HTTPAddr:      getEnv("HTTP_ADDR", ":8080"),
+		DictionaryDir: os.Getenv("DICTIONARY_DIR"),
+		Webhook: WebhookConfig{
+			Listen:      getEnv("WEBHOOK_LISTEN", ":8443"),
+			PublicURL:   os.Getenv("WEBHOOK_PUBLIC_URL"),
+			SecretToken: os.Getenv("WEBHOOK_SECRET"),
+			TLSCert:     os.Getenv("WEBHOOK_TLS_CERT"),
+			TLSKey:      os.Getenv("WEBHOOK_TLS_KEY"),
+		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5432"),
@@ -40,6 +102,32 @@ func Load() (*Config, error) {
 		},
 	}
 
+	var err error
+	if cfg.UserCache.Size, err = getEnvInt("USER_CACHE_SIZE", 10000); err != nil {
+		return nil, err
+	}
+	if cfg.UserCache.TTL, err = getEnvDuration("USER_CACHE_TTL", 5*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.RequestTimeout, err = getEnvDuration("REQUEST_TIMEOUT", 10*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.RequestTimeout <= 0 {
+		return nil, fmt.Errorf("REQUEST_TIMEOUT must be positive")
+	}
+	if cfg.ShutdownTimeout, err = getEnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.Location, err = time.LoadLocation(getEnv("TIMEZONE", "Europe/Moscow")); err != nil {
+		return nil, fmt.Errorf("TIMEZONE is invalid: %w", err)
+	}
+	if err := cfg.loadDefaults(); err != nil {
+		return nil, err
+	}
+	if cfg.AdminIDs, err = getEnvInt64List("ADMIN_IDS"); err != nil {
+		return nil, err
+	}
+
 	// Validate required fields
 	if cfg.BotToken == "" {
 		return nil, fmt.Errorf("BOT_TOKEN is required")
@@ -50,10 +138,64 @@ func Load() (*Config, error) {
 	if cfg.Database.Password == "" {
 		return nil, fmt.Errorf("DB_PASSWORD is required")
 	}
+	if err := cfg.validateBotMode(); err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
 
+// loadDefaults reads defaults of per-user settings within the limits users have
+func (c *Config) loadDefaults() error {
+	limits := []struct {
+		key    string
+		def    int
+		lo, hi int
+		dst    *int
+	}{
+		{"DAYS_PAGE_SIZE", 7, 1, domain.MaxPageSize, &c.Defaults.PageSize},
+		{"HISTORY_DAYS", 60, domain.MinHistoryDays, domain.MaxHistoryDays, &c.Defaults.HistoryDays},
+		{"HIDE_DAYS", 7, 1, domain.MaxHideDays, &c.Defaults.HideDays},
+	}
+
+	for _, l := range limits {
+		n, err := getEnvInt(l.key, l.def)
+		if err != nil {
+			return err
+		}
+		if n < l.lo || n > l.hi {
+			return fmt.Errorf("%s must be between %d and %d, got %d", l.key, l.lo, l.hi, n)
+		}
+		*l.dst = n
+	}
+	return nil
+}
+
+// validateBotMode checks that the selected update mode is fully configured
+func (c *Config) validateBotMode() error {
+	switch c.BotMode {
+	case BotModePolling:
+		return nil
+	case BotModeWebhook:
+	default:
+		return fmt.Errorf("BOT_MODE must be %q or %q, got %q", BotModePolling, BotModeWebhook, c.BotMode)
+	}
+
+	if c.Webhook.PublicURL == "" {
+		return fmt.Errorf("WEBHOOK_PUBLIC_URL is required in webhook mode")
+	}
+	if !strings.HasPrefix(c.Webhook.PublicURL, "https://") {
+		return fmt.Errorf("WEBHOOK_PUBLIC_URL must start with https://")
+	}
+	if c.Webhook.SecretToken == "" {
+		return fmt.Errorf("WEBHOOK_SECRET is required in webhook mode")
+	}
+	if (c.Webhook.TLSCert == "") != (c.Webhook.TLSKey == "") {
+		return fmt.Errorf("WEBHOOK_TLS_CERT and WEBHOOK_TLS_KEY must be set together")
+	}
+	return nil
+}
+
 // DSN returns PostgreSQL connection string
 func (c *Config) DSN() string {
 	return fmt.Sprintf(
@@ -73,3 +215,42 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer: %w", key, err)
+	}
+	return n, nil
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration like 5m: %w", key, err)
+	}
+	return d, nil
+}
+
+func getEnvInt64List(key string) ([]int64, error) {
+	var list []int64
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a comma-separated list of user IDs: %w", key, err)
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -161,6 +162,81 @@ func TestLoad_WithDefaults(t *testing.T) {
 	assert.Equal(t, "5432", cfg.Database.Port)
 	assert.Equal(t, "languager", cfg.Database.Name)
 	assert.Equal(t, "languager", cfg.Database.User)
+	assert.Equal(t, "Europe/Moscow", cfg.Location.String())
+	assert.Equal(t, DefaultsConfig{PageSize: 7, HistoryDays: 60, HideDays: 7}, cfg.Defaults)
+}
+
+func TestLoad_UserDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{name: "page size", key: "DAYS_PAGE_SIZE", value: "10"},
+		{name: "page size too big", key: "DAYS_PAGE_SIZE", value: "100", wantErr: true},
+		{name: "history too short", key: "HISTORY_DAYS", value: "1", wantErr: true},
+		{name: "hide days not a number", key: "HIDE_DAYS", value: "week", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BOT_TOKEN", "test_token")
+			t.Setenv("BOT_PASSWORD", "test_password")
+			t.Setenv("DB_PASSWORD", "test_db_password")
+			t.Setenv(tt.key, tt.value)
+
+			cfg, err := Load()
+
+			if tt.wantErr {
+				assert.ErrorContains(t, err, tt.key)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 10, cfg.Defaults.PageSize)
+		})
+	}
+}
+
+func TestLoad_InvalidTimezone(t *testing.T) {
+	t.Setenv("BOT_TOKEN", "test_token")
+	t.Setenv("BOT_PASSWORD", "test_password")
+	t.Setenv("DB_PASSWORD", "test_db_password")
+	t.Setenv("TIMEZONE", "Mars/Olympus")
+
+	_, err := Load()
+	assert.ErrorContains(t, err, "TIMEZONE")
+}
+
+func TestLoad_AdminIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []int64
+		wantErr  bool
+	}{
+		{name: "unset", value: "", expected: nil},
+		{name: "one", value: "123", expected: []int64{123}},
+		{name: "several with spaces", value: "123, 5000000000,", expected: []int64{123, 5000000000}},
+		{name: "not a number", value: "123,bob", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BOT_TOKEN", "test_token")
+			t.Setenv("BOT_PASSWORD", "test_password")
+			t.Setenv("DB_PASSWORD", "test_db_password")
+			t.Setenv("ADMIN_IDS", tt.value)
+
+			cfg, err := Load()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "ADMIN_IDS")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.AdminIDs)
+		})
+	}
 }
 
 func TestLoad_MissingBotPassword(t *testing.T) {
@@ -234,3 +310,121 @@ func TestLoad_MissingDBPassword(t *testing.T) {
 	assert.Nil(t, cfg)
 	assert.Contains(t, err.Error(), "DB_PASSWORD")
 }
+
+func TestGetEnvInt(t *testing.T) {
+	os.Setenv("TEST_INT", "42")
+	defer os.Unsetenv("TEST_INT")
+
+	n, err := getEnvInt("TEST_INT", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, n)
+
+	n, err = getEnvInt("TEST_INT_NOT_SET", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+
+	os.Setenv("TEST_INT", "many")
+	_, err = getEnvInt("TEST_INT", 1)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "TEST_INT")
+}
+
+func TestGetEnvDuration(t *testing.T) {
+	os.Setenv("TEST_DURATION", "90s")
+	defer os.Unsetenv("TEST_DURATION")
+
+	d, err := getEnvDuration("TEST_DURATION", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+
+	d, err = getEnvDuration("TEST_DURATION_NOT_SET", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, d)
+
+	os.Setenv("TEST_DURATION", "soon")
+	_, err = getEnvDuration("TEST_DURATION", time.Minute)
+	assert.Error(t, err)
+}
+
+func TestConfig_ValidateBotMode(t *testing.T) {
+	validWebhook := WebhookConfig{
+		Listen:      ":8443",
+		PublicURL:   "https://bot.example.com/telegram",
+		SecretToken: "secret",
+	}
+
+	tests := []struct {
+		name          string
+		mode          string
+		webhook       WebhookConfig
+		expectedError string
+	}{
+		{
+			name: "polling",
+			mode: BotModePolling,
+		},
+		{
+			name:    "webhook",
+			mode:    BotModeWebhook,
+			webhook: validWebhook,
+		},
+		{
+			name: "webhook with TLS",
+			mode: BotModeWebhook,
+			webhook: WebhookConfig{
+				PublicURL:   validWebhook.PublicURL,
+				SecretToken: validWebhook.SecretToken,
+				TLSCert:     "/certs/cert.pem",
+				TLSKey:      "/certs/key.pem",
+			},
+		},
+		{
+			name:          "unknown mode",
+			mode:          "carrier_pigeon",
+			expectedError: "BOT_MODE",
+		},
+		{
+			name:          "webhook without public URL",
+			mode:          BotModeWebhook,
+			webhook:       WebhookConfig{SecretToken: "secret"},
+			expectedError: "WEBHOOK_PUBLIC_URL",
+		},
+		{
+			name:          "webhook with plain http URL",
+			mode:          BotModeWebhook,
+			webhook:       WebhookConfig{PublicURL: "http://bot.example.com", SecretToken: "secret"},
+			expectedError: "https://",
+		},
+		{
+			name:          "webhook without secret",
+			mode:          BotModeWebhook,
+			webhook:       WebhookConfig{PublicURL: validWebhook.PublicURL},
+			expectedError: "WEBHOOK_SECRET",
+		},
+		{
+			name: "webhook with cert but no key",
+			mode: BotModeWebhook,
+			webhook: WebhookConfig{
+				PublicURL:   validWebhook.PublicURL,
+				SecretToken: validWebhook.SecretToken,
+				TLSCert:     "/certs/cert.pem",
+			},
+			expectedError: "WEBHOOK_TLS_KEY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{BotMode: tt.mode, Webhook: tt.webhook}
+
+			err := cfg.validateBotMode()
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Package dictionary looks up translations in local dictionary files.
+package dictionary
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"languager/internal/domain"
+)
+
+// Dictionary suggests translations of words
+type Dictionary interface {
+	// Lookup returns up to limit translations of word in the pair, best first
+	Lookup(pair domain.LanguagePair, word string, limit int) []string
+}
+
+// TSV is a dictionary of tab-separated files in the FreeDict TSV style:
+//
+//	# comment
+//	word<TAB>translation; another translation
+//
+// A file of the "en-ru" pair also answers "ru-en" lookups, after the
+// entries of a "ru-en" file if there is one.
+type TSV struct {
+	direct  map[domain.LanguagePair]map[string][]string
+	reverse map[domain.LanguagePair]map[string][]string
+}
+
+// NewTSV creates an empty TSV dictionary
+func NewTSV() *TSV {
+	return &TSV{
+		direct:  make(map[domain.LanguagePair]map[string][]string),
+		reverse: make(map[domain.LanguagePair]map[string][]string),
+	}
+}
+
+// LoadDir loads every "<source>-<target>.tsv" file of dir,
+// e.g. en-ru.tsv. Other files are ignored.
+func LoadDir(dir string) (*TSV, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("dictionary dir: %w", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tsv"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	d := NewTSV()
+	for _, path := range paths {
+		pair, err := domain.ParseLanguagePair(strings.TrimSuffix(filepath.Base(path), ".tsv"))
+		if err != nil {
+			continue
+		}
+		if err := d.loadFile(pair, path); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (d *TSV) loadFile(pair domain.LanguagePair, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := d.Load(pair, f); err != nil {
+		return fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// Load adds entries of the pair read from r
+func (d *TSV) Load(pair domain.LanguagePair, r io.Reader) error {
+	reversed := domain.LanguagePair{Source: pair.Target, Target: pair.Source}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		word, translations, ok := strings.Cut(text, "\t")
+		if !ok || normalize(word) == "" {
+			return fmt.Errorf("line %d: expected word<TAB>translations", line)
+		}
+		for _, t := range strings.Split(translations, ";") {
+			t = strings.TrimSpace(t)
+			if t == "" {
+				continue
+			}
+			add(d.direct, pair, word, t)
+			add(d.reverse, reversed, t, strings.TrimSpace(word))
+		}
+	}
+	return scanner.Err()
+}
+
+// Lookup returns up to limit translations of word in the pair, best first
+func (d *TSV) Lookup(pair domain.LanguagePair, word string, limit int) []string {
+	key := normalize(word)
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, index := range []map[domain.LanguagePair]map[string][]string{d.direct, d.reverse} {
+		for _, t := range index[pair][key] {
+			if len(result) == limit {
+				return result
+			}
+			if !seen[normalize(t)] {
+				seen[normalize(t)] = true
+				result = append(result, t)
+			}
+		}
+	}
+	return result
+}
+
+func add(index map[domain.LanguagePair]map[string][]string, pair domain.LanguagePair, word, translation string) {
+	words, ok := index[pair]
+	if !ok {
+		words = make(map[string][]string)
+		index[pair] = words
+	}
+	key := normalize(word)
+	words[key] = append(words[key], translation)
+}
+
+func normalize(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
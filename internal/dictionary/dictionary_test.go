@@ -0,0 +1,78 @@
+package dictionary
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"languager/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	enRu = domain.LanguagePair{Source: "en", Target: "ru"}
+	ruEn = domain.LanguagePair{Source: "ru", Target: "en"}
+)
+
+func TestTSV_Lookup(t *testing.T) {
+	d := NewTSV()
+	require.NoError(t, d.Load(enRu, strings.NewReader(`
+# English-Russian
+hello	привет; здравствуйте
+Hello	алло
+world	мир
+`)))
+
+	tests := []struct {
+		name     string
+		pair     domain.LanguagePair
+		word     string
+		limit    int
+		expected []string
+	}{
+		{"all translations", enRu, "hello", 5, []string{"привет", "здравствуйте", "алло"}},
+		{"case and spaces ignored", enRu, "  HELLO ", 5, []string{"привет", "здравствуйте", "алло"}},
+		{"limit", enRu, "hello", 2, []string{"привет", "здравствуйте"}},
+		{"reverse lookup", ruEn, "Мир", 5, []string{"world"}},
+		{"unknown word", enRu, "goodbye", 5, nil},
+		{"unknown pair", domain.LanguagePair{Source: "de", Target: "ru"}, "hello", 5, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, d.Lookup(tt.pair, tt.word, tt.limit))
+		})
+	}
+}
+
+func TestTSV_Lookup_DirectFirst(t *testing.T) {
+	d := NewTSV()
+	require.NoError(t, d.Load(enRu, strings.NewReader("world\tмир\npeace\tмир\n")))
+	require.NoError(t, d.Load(ruEn, strings.NewReader("мир\tworld; universe\n")))
+
+	assert.Equal(t, []string{"world", "universe", "peace"}, d.Lookup(ruEn, "мир", 5))
+}
+
+func TestTSV_Load_Malformed(t *testing.T) {
+	err := NewTSV().Load(enRu, strings.NewReader("hello\tпривет\nworld мир\n"))
+
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en-ru.tsv"), []byte("hello\tпривет\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.txt"), []byte("not a dictionary"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.tsv"), []byte("not a pair"), 0o644))
+
+	d, err := LoadDir(dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"привет"}, d.Lookup(enRu, "hello", 3))
+
+	_, err = LoadDir(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
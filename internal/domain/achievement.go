@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// AchievementMetric is a number achievements are earned for
+type AchievementMetric string
+
+const (
+	// MetricWordsAdded counts words ever added, including cleaned up ones
+	MetricWordsAdded AchievementMetric = "words_added"
+	// MetricCorrectAnswers counts correct reviews of existing words
+	MetricCorrectAnswers AchievementMetric = "correct_answers"
+	// MetricBestStreak is the longest streak of days with the goal reached
+	MetricBestStreak AchievementMetric = "best_streak"
+	// MetricPerfectSessions is 1 when the event finished a perfect session
+	MetricPerfectSessions AchievementMetric = "perfect_sessions"
+)
+
+// Achievement is earned once Metric reaches Goal. Its name is the catalogue
+// message "achievement.<ID>"; the ID is stored, so it must never change.
+type Achievement struct {
+	ID     string
+	Icon   string
+	Metric AchievementMetric
+	Goal   int
+}
+
+// Achievements are all achievements in the order they are listed.
+// A new one needs a line here and its name in the catalogues;
+// TestAchievements_HaveNames in handler fails until every catalogue has it.
+var Achievements = []Achievement{
+	{ID: "first_word", Icon: "🌱", Metric: MetricWordsAdded, Goal: 1},
+	{ID: "words_100", Icon: "📚", Metric: MetricWordsAdded, Goal: 100},
+	{ID: "words_500", Icon: "🏛", Metric: MetricWordsAdded, Goal: 500},
+	{ID: "streak_7", Icon: "🔥", Metric: MetricBestStreak, Goal: 7},
+	{ID: "streak_30", Icon: "🌋", Metric: MetricBestStreak, Goal: 30},
+	{ID: "correct_500", Icon: "🎯", Metric: MetricCorrectAnswers, Goal: 500},
+	{ID: "perfect_session", Icon: "💎", Metric: MetricPerfectSessions, Goal: 1},
+}
+
+// AchievementProgress holds the user's value of each metric
+type AchievementProgress map[AchievementMetric]int
+
+// Reached reports whether the progress earns the achievement
+func (a Achievement) Reached(p AchievementProgress) bool {
+	return p[a.Metric] >= a.Goal
+}
+
+// AchievementEvent is what has just happened to the user, for metrics
+// that aren't stored
+type AchievementEvent struct {
+	PerfectSession bool
+}
+
+// Apply adds the event to the stored progress
+func (e AchievementEvent) Apply(p AchievementProgress) {
+	if e.PerfectSession {
+		p[MetricPerfectSessions]++
+	}
+}
+
+// AchievementStatus is an achievement as the user sees it
+type AchievementStatus struct {
+	Achievement
+	EarnedAt *time.Time // nil while not earned
+	Progress int        // the user's value of the metric, up to Goal
+}
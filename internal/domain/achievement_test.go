@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAchievements_Definitions(t *testing.T) {
+	metrics := map[AchievementMetric]bool{
+		MetricWordsAdded:      true,
+		MetricCorrectAnswers:  true,
+		MetricBestStreak:      true,
+		MetricPerfectSessions: true,
+	}
+
+	seen := make(map[string]bool)
+	for _, a := range Achievements {
+		assert.False(t, seen[a.ID], "duplicate ID %s", a.ID)
+		seen[a.ID] = true
+		assert.NotEmpty(t, a.Icon, a.ID)
+		assert.True(t, metrics[a.Metric], "unknown metric of %s", a.ID)
+		assert.Positive(t, a.Goal, a.ID)
+	}
+}
+
+func TestAchievement_Reached(t *testing.T) {
+	words100 := Achievement{ID: "words_100", Metric: MetricWordsAdded, Goal: 100}
+
+	assert.False(t, words100.Reached(AchievementProgress{}))
+	assert.False(t, words100.Reached(AchievementProgress{MetricWordsAdded: 99, MetricCorrectAnswers: 500}))
+	assert.True(t, words100.Reached(AchievementProgress{MetricWordsAdded: 100}))
+}
+
+func TestAchievementEvent_Apply(t *testing.T) {
+	progress := AchievementProgress{MetricWordsAdded: 3}
+
+	AchievementEvent{}.Apply(progress)
+	assert.Equal(t, AchievementProgress{MetricWordsAdded: 3}, progress)
+
+	AchievementEvent{PerfectSession: true}.Apply(progress)
+	assert.Equal(t, 1, progress[MetricPerfectSessions])
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// AuditAction is what an audit log entry records. Actions are stored,
+// so their values must never change.
+type AuditAction string
+
+const (
+	AuditAuthGranted  AuditAction = "auth_granted"  // correct password
+	AuditAuthFailed   AuditAction = "auth_failed"   // wrong password
+	AuditAuthRevoked  AuditAction = "auth_revoked"  // an admin revoked a user
+	AuditWordHidden   AuditAction = "word_hidden"   // snoozed or hidden forever
+	AuditWordsCleaned AuditAction = "words_cleaned" // old words removed by cleanup
+	AuditDeckExported AuditAction = "deck_exported" // a pair published as a deck
+	AuditDeckImported AuditAction = "deck_imported" // deck words copied or subscribed to
+	AuditDeckDeleted  AuditAction = "deck_deleted"
+)
+
+// AuditActions are all actions, the ones /audit can filter by
+var AuditActions = []AuditAction{
+	AuditAuthGranted,
+	AuditAuthFailed,
+	AuditAuthRevoked,
+	AuditWordHidden,
+	AuditWordsCleaned,
+	AuditDeckExported,
+	AuditDeckImported,
+	AuditDeckDeleted,
+}
+
+// ParseAuditAction returns the action named s
+func ParseAuditAction(s string) (AuditAction, bool) {
+	for _, a := range AuditActions {
+		if string(a) == s {
+			return a, true
+		}
+	}
+	return "", false
+}
+
+// SystemActor is the actor of entries written by background jobs
+const SystemActor int64 = 0
+
+// AuditEntry is one record of the audit log
+type AuditEntry struct {
+	ID        int64
+	ActorID   int64 // who did it, SystemActor for background jobs
+	Action    AuditAction
+	Target    string         // what it was done to, e.g. "user:42", "word:7", "deck:abcd2345"
+	Metadata  map[string]any // action details, stored as JSON
+	CreatedAt time.Time
+}
+
+// AuditUser, AuditWord and AuditDeck make entry targets
+func AuditUser(userID int64) string { return "user:" + strconv.FormatInt(userID, 10) }
+func AuditWord(wordID int) string   { return "word:" + strconv.Itoa(wordID) }
+func AuditDeck(code string) string  { return "deck:" + code }
+
+// AuditFilter selects audit log entries; zero fields match everything
+type AuditFilter struct {
+	UserID int64 // the actor or the target user
+	Action AuditAction
+	Limit  int
+}
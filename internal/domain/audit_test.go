@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAuditAction(t *testing.T) {
+	for _, a := range AuditActions {
+		parsed, ok := ParseAuditAction(string(a))
+		assert.True(t, ok)
+		assert.Equal(t, a, parsed)
+	}
+
+	_, ok := ParseAuditAction("passwords")
+	assert.False(t, ok)
+}
+
+func TestAuditTargets(t *testing.T) {
+	assert.Equal(t, "user:5000000000", AuditUser(5000000000))
+	assert.Equal(t, "word:7", AuditWord(7))
+	assert.Equal(t, "deck:abcd2345", AuditDeck("abcd2345"))
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Deck is a language pair of a user published under a share code.
+// It holds the owner's words of the pair, including ones added later.
+type Deck struct {
+	ID          int
+	OwnerID     int64
+	Code        string // share code, the deep link is /start deck_<code>
+	Name        string
+	Pair        LanguagePair
+	WordCount   int
+	Subscribers int
+	CreatedAt   time.Time
+}
+
+// MaxDeckNameLength limits deck names, in characters
+const MaxDeckNameLength = 64
+
+// DeckLinkPrefix starts deep link payloads of decks
+const DeckLinkPrefix = "deck_"
+
+// DeckCodeAlphabet is what share codes are made of: no look-alike characters,
+// and only ones allowed in deep link payloads
+const DeckCodeAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+
+// DeckCodeLength is the length of share codes
+const DeckCodeLength = 8
+
+// ParseDeckCode returns the share code of a deep link payload ("deck_<code>")
+// or a code typed by hand
+func ParseDeckCode(s string) (string, bool) {
+	code := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), DeckLinkPrefix))
+	if len(code) != DeckCodeLength {
+		return "", false
+	}
+	for _, r := range code {
+		if !strings.ContainsRune(DeckCodeAlphabet, r) {
+			return "", false
+		}
+	}
+	return code, true
+}
+
+// Link returns the deep link payload of the deck
+func (d Deck) Link() string {
+	return DeckLinkPrefix + d.Code
+}
+
+// DeckRole is what a user may do with a deck
+type DeckRole int
+
+const (
+	// DeckVisitor knows the code and may copy the words or subscribe
+	DeckVisitor DeckRole = iota
+	// DeckSubscriber gets the owner's words and may unsubscribe
+	DeckSubscriber
+	// DeckOwner published the deck and may delete it
+	DeckOwner
+)
+
+// DeckView is a deck as seen by a user
+type DeckView struct {
+	Deck
+	Role   DeckRole
+	Follow bool // the subscriber gets words the owner adds later
+}
+
+// DeckSubscription links a subscriber to a deck
+type DeckSubscription struct {
+	DeckID int
+	UserID int64
+	Follow bool
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDeckCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"deck_abc234de", "abc234de", true},
+		{"abc234de", "abc234de", true},
+		{" ABC234DE ", "abc234de", true},
+		{"deck_abc", "", false},
+		{"deck_abc234de1", "", false},
+		{"deck_abc0o4de", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		code, ok := ParseDeckCode(tt.input)
+		assert.Equal(t, tt.expected, code, tt.input)
+		assert.Equal(t, tt.ok, ok, tt.input)
+	}
+}
+
+func TestDeck_Link(t *testing.T) {
+	assert.Equal(t, "deck_abc234de", Deck{Code: "abc234de"}.Link())
+}
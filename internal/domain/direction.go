@@ -0,0 +1,63 @@
+package domain
+
+// ReviewDirection is which side of a pair a review card shows
+type ReviewDirection string
+
+const (
+	// DirectionForward shows the word and asks for the translation
+	DirectionForward ReviewDirection = "forward"
+	// DirectionReverse shows the translation and asks for the word
+	DirectionReverse ReviewDirection = "reverse"
+	// DirectionMixed picks a side at random for every card; a preference only
+	DirectionMixed ReviewDirection = "mixed"
+	// DirectionCloze shows an example sentence with the word blanked out;
+	// a review mode of its own, not a preference
+	DirectionCloze ReviewDirection = "cloze"
+)
+
+// DefaultReviewDirection is used until the user picks one
+const DefaultReviewDirection = DirectionMixed
+
+// ReviewDirections are the preferences offered to users
+var ReviewDirections = []ReviewDirection{DirectionForward, DirectionReverse, DirectionMixed}
+
+// ParseReviewDirection parses a stored preference
+func ParseReviewDirection(s string) (ReviewDirection, bool) {
+	for _, d := range ReviewDirections {
+		if string(d) == s {
+			return d, true
+		}
+	}
+	return "", false
+}
+
+// Pick returns the direction of the next card: mixed is resolved with coin
+func (d ReviewDirection) Pick(coin func() bool) ReviewDirection {
+	switch d {
+	case DirectionForward, DirectionReverse:
+		return d
+	}
+	if coin() {
+		return DirectionForward
+	}
+	return DirectionReverse
+}
+
+// Short returns a one-letter code for callback data
+func (d ReviewDirection) Short() string {
+	if d == DirectionReverse {
+		return "r"
+	}
+	return "f"
+}
+
+// ParseShortDirection parses a code returned by Short
+func ParseShortDirection(s string) (ReviewDirection, bool) {
+	switch s {
+	case "f":
+		return DirectionForward, true
+	case "r":
+		return DirectionReverse, true
+	}
+	return "", false
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReviewDirection(t *testing.T) {
+	for _, d := range ReviewDirections {
+		parsed, ok := ParseReviewDirection(string(d))
+		assert.True(t, ok)
+		assert.Equal(t, d, parsed)
+	}
+
+	_, ok := ParseReviewDirection("sideways")
+	assert.False(t, ok)
+}
+
+func TestReviewDirection_Pick(t *testing.T) {
+	heads := func() bool { return true }
+	tails := func() bool { return false }
+
+	assert.Equal(t, DirectionForward, DirectionForward.Pick(tails))
+	assert.Equal(t, DirectionReverse, DirectionReverse.Pick(heads))
+	assert.Equal(t, DirectionForward, DirectionMixed.Pick(heads))
+	assert.Equal(t, DirectionReverse, DirectionMixed.Pick(tails))
+}
+
+func TestShortDirection(t *testing.T) {
+	for _, d := range []ReviewDirection{DirectionForward, DirectionReverse} {
+		parsed, ok := ParseShortDirection(d.Short())
+		assert.True(t, ok)
+		assert.Equal(t, d, parsed)
+	}
+
+	_, ok := ParseShortDirection("x")
+	assert.False(t, ok)
+}
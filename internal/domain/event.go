@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// Event is something that happened to a user's words or reviews, published by services
+// to whoever needs to react (stats, achievements, decks, audit)
+type Event interface {
+	// EventName identifies the event type in logs and metrics
+	EventName() string
+}
+
+// WordSaved is published when a user saves a new word
+type WordSaved struct {
+	UserID      int64
+	WordID      int
+	Pair        LanguagePair
+	Word        string
+	Translation string
+}
+
+// WordHidden is published when a user hides a word from reviews
+type WordHidden struct {
+	UserID int64
+	WordID int
+	Until  time.Time // zero when hidden forever
+}
+
+// Forever reports whether the word is hidden for good
+func (e WordHidden) Forever() bool {
+	return e.Until.IsZero()
+}
+
+// ReviewGraded is published when a review answer is recorded
+type ReviewGraded struct {
+	UserID    int64
+	WordID    int
+	Correct   bool
+	Direction ReviewDirection
+	Mastered  bool // the answer made the word mastered
+}
+
+// GoalReached is published when a user's activity reaches the daily goal
+type GoalReached struct {
+	UserID int64
+	Streak int // current streak including today
+	Best   int
+}
+
+// SessionFinished is published when a user answers the last card of a review session
+type SessionFinished struct {
+	UserID  int64
+	Total   int // cards shown
+	Correct int
+	Perfect bool // see ReviewSession.Perfect
+}
+
+func (WordSaved) EventName() string       { return "word_saved" }
+func (WordHidden) EventName() string      { return "word_hidden" }
+func (ReviewGraded) EventName() string    { return "review_graded" }
+func (GoalReached) EventName() string     { return "goal_reached" }
+func (SessionFinished) EventName() string { return "session_finished" }
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// GroupWord is a word of a group chat's shared vocabulary
+type GroupWord struct {
+	ID          int
+	ChatID      int64
+	AddedBy     int64 // Telegram ID of the member who added the word
+	Word        string
+	Translation string
+	CreatedAt   time.Time
+}
+
+// GroupQuiz is a multiple-choice question posted to a group chat.
+// The first member to pick the right translation wins a point.
+type GroupQuiz struct {
+	ID       int
+	ChatID   int64
+	Word     GroupWord
+	Options  []GroupWord // shuffled, one of them is Word
+	WinnerID int64       // 0 while unsolved
+}
+
+// GroupScore is a line of a group's leaderboard
+type GroupScore struct {
+	UserID int64
+	Name   string
+	Points int
+}
+
+// groupWordSeparators split "/add word - translation", in order of preference
+var groupWordSeparators = []string{" — ", " – ", " - ", "=", ":"}
+
+// ParseGroupWord splits the argument of /add into the word and its translation
+func ParseGroupWord(s string) (word, translation string, ok bool) {
+	for _, sep := range groupWordSeparators {
+		word, translation, found := strings.Cut(s, sep)
+		if !found {
+			continue
+		}
+		word, translation = strings.TrimSpace(word), strings.TrimSpace(translation)
+		if word == "" || translation == "" {
+			return "", "", false
+		}
+		return word, translation, true
+	}
+	return "", "", false
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGroupWord(t *testing.T) {
+	tests := []struct {
+		input       string
+		word        string
+		translation string
+		ok          bool
+	}{
+		{"apple - яблоко", "apple", "яблоко", true},
+		{"apple — яблоко", "apple", "яблоко", true},
+		{"apple=яблоко", "apple", "яблоко", true},
+		{" apple : яблоко ", "apple", "яблоко", true},
+		{"well-known - известный", "well-known", "известный", true},
+		{"apple -", "", "", false},
+		{"apple", "", "", false},
+		{"", "", "", false},
+	}
+
+	for _, tt := range tests {
+		word, translation, ok := ParseGroupWord(tt.input)
+		assert.Equal(t, tt.word, word, tt.input)
+		assert.Equal(t, tt.translation, translation, tt.input)
+		assert.Equal(t, tt.ok, ok, tt.input)
+	}
+}
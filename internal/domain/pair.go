@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LanguagePair is the language of words and the language of their translations
+type LanguagePair struct {
+	Source string // ISO 639-1 code of the word language
+	Target string // ISO 639-1 code of the translation language
+}
+
+// DefaultLanguagePair is the pair of words added before pairs existed
+var DefaultLanguagePair = LanguagePair{Source: "en", Target: "ru"}
+
+// StudyLanguages are the languages pairs can be made of, with their flags
+var StudyLanguages = []struct {
+	Code string
+	Flag string
+}{
+	{"en", "🇬🇧"},
+	{"de", "🇩🇪"},
+	{"es", "🇪🇸"},
+	{"fr", "🇫🇷"},
+	{"it", "🇮🇹"},
+	{"ru", "🇷🇺"},
+}
+
+// ParseLanguagePair parses a pair in the "en-ru" form returned by String
+func ParseLanguagePair(s string) (LanguagePair, error) {
+	source, target, ok := strings.Cut(s, "-")
+	if !ok {
+		return LanguagePair{}, fmt.Errorf("malformed language pair %q", s)
+	}
+	p := LanguagePair{Source: source, Target: target}
+	if !IsStudyLanguage(source) || !IsStudyLanguage(target) {
+		return LanguagePair{}, fmt.Errorf("unsupported language pair %q", s)
+	}
+	if source == target {
+		return LanguagePair{}, fmt.Errorf("language pair %q has the same languages", s)
+	}
+	return p, nil
+}
+
+// IsStudyLanguage reports whether code is one of StudyLanguages
+func IsStudyLanguage(code string) bool {
+	return languageFlag(code) != ""
+}
+
+// String returns the pair as "en-ru"
+func (p LanguagePair) String() string {
+	return p.Source + "-" + p.Target
+}
+
+// Label returns the pair for buttons, e.g. "🇬🇧 EN → 🇷🇺 RU"
+func (p LanguagePair) Label() string {
+	return LanguageLabel(p.Source) + " → " + LanguageLabel(p.Target)
+}
+
+// LanguageLabel returns a study language for buttons, e.g. "🇩🇪 DE"
+func LanguageLabel(code string) string {
+	return strings.TrimSpace(languageFlag(code) + " " + strings.ToUpper(code))
+}
+
+func languageFlag(code string) string {
+	for _, l := range StudyLanguages {
+		if l.Code == code {
+			return l.Flag
+		}
+	}
+	return ""
+}
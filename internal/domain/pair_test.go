@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLanguagePair(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected LanguagePair
+		wantErr  bool
+	}{
+		{input: "en-ru", expected: LanguagePair{Source: "en", Target: "ru"}},
+		{input: "de-en", expected: LanguagePair{Source: "de", Target: "en"}},
+		{input: "en", wantErr: true},
+		{input: "en-en", wantErr: true},
+		{input: "xx-ru", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		pair, err := ParseLanguagePair(tt.input)
+
+		if tt.wantErr {
+			assert.Error(t, err, tt.input)
+			continue
+		}
+		assert.NoError(t, err, tt.input)
+		assert.Equal(t, tt.expected, pair)
+		assert.Equal(t, tt.input, pair.String())
+	}
+}
+
+func TestLanguagePair_Label(t *testing.T) {
+	assert.Equal(t, "🇬🇧 EN → 🇷🇺 RU", DefaultLanguagePair.Label())
+	assert.Equal(t, "🇪🇸 ES → 🇩🇪 DE", LanguagePair{Source: "es", Target: "de"}.Label())
+}
+
+func TestLanguageLabel(t *testing.T) {
+	assert.Equal(t, "🇫🇷 FR", LanguageLabel("fr"))
+	assert.Equal(t, "XX", LanguageLabel("xx"))
+}
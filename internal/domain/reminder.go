@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// AllWeekdays is a weekday mask with every day enabled
+const AllWeekdays uint8 = 1<<7 - 1
+
+// ReminderSettings holds a user's review reminder schedule
+type ReminderSettings struct {
+	UserID int64
+	Times  []int // minutes after local midnight, sorted
+	Days   uint8 // bit N set = reminders on time.Weekday(N)
+	Paused bool
+}
+
+// NewReminderSettings returns an empty schedule active on every day
+func NewReminderSettings(userID int64) *ReminderSettings {
+	return &ReminderSettings{UserID: userID, Days: AllWeekdays}
+}
+
+// HasDay reports whether reminders are enabled on the weekday
+func (s *ReminderSettings) HasDay(d time.Weekday) bool {
+	return s.Days&(1<<uint(d)) != 0
+}
+
+// ToggleDay enables or disables reminders on the weekday
+func (s *ReminderSettings) ToggleDay(d time.Weekday) {
+	s.Days ^= 1 << uint(d)
+}
+
+// AddTime adds a reminder time, ignoring duplicates
+func (s *ReminderSettings) AddTime(minutes int) {
+	for _, t := range s.Times {
+		if t == minutes {
+			return
+		}
+	}
+	s.Times = append(s.Times, minutes)
+	sort.Ints(s.Times)
+}
+
+// RemoveTime removes a reminder time
+func (s *ReminderSettings) RemoveTime(minutes int) {
+	times := s.Times[:0]
+	for _, t := range s.Times {
+		if t != minutes {
+			times = append(times, t)
+		}
+	}
+	s.Times = times
+}
+
+// Active reports whether the schedule can produce reminders
+func (s *ReminderSettings) Active() bool {
+	return !s.Paused && len(s.Times) > 0 && s.Days != 0
+}
+
+// DueSlots returns scheduled reminder moments in (now-window, now].
+// Slots are computed in loc, so DST changes shift them with the wall clock.
+func (s *ReminderSettings) DueSlots(now time.Time, loc *time.Location, window time.Duration) []time.Time {
+	if !s.Active() {
+		return nil
+	}
+
+	local := now.In(loc)
+	from := now.Add(-window)
+
+	var slots []time.Time
+	// The window may cross local midnight, so check yesterday as well
+	for offset := -1; offset <= 0; offset++ {
+		day := local.AddDate(0, 0, offset)
+		if !s.HasDay(day.Weekday()) {
+			continue
+		}
+		for _, t := range s.Times {
+			slot := time.Date(day.Year(), day.Month(), day.Day(), t/60, t%60, 0, 0, loc)
+			if slot.After(from) && !slot.After(now) {
+				slots = append(slots, slot)
+			}
+		}
+	}
+	return slots
+}
+
+// ParseClock parses a HH:MM time of day into minutes after midnight
+func ParseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock formats minutes after midnight as HH:MM
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
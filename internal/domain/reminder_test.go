@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderSettings_Days(t *testing.T) {
+	s := NewReminderSettings(123)
+	assert.True(t, s.HasDay(time.Sunday))
+	assert.True(t, s.HasDay(time.Saturday))
+
+	s.ToggleDay(time.Sunday)
+	assert.False(t, s.HasDay(time.Sunday))
+	assert.True(t, s.HasDay(time.Monday))
+
+	s.ToggleDay(time.Sunday)
+	assert.Equal(t, AllWeekdays, s.Days)
+}
+
+func TestReminderSettings_Times(t *testing.T) {
+	s := NewReminderSettings(123)
+	s.AddTime(20 * 60)
+	s.AddTime(9 * 60)
+	s.AddTime(9 * 60)
+	assert.Equal(t, []int{9 * 60, 20 * 60}, s.Times)
+
+	s.RemoveTime(9 * 60)
+	assert.Equal(t, []int{20 * 60}, s.Times)
+}
+
+func TestReminderSettings_DueSlots(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	// Monday 2024-12-16 09:05 MSK
+	now := time.Date(2024, 12, 16, 9, 5, 0, 0, moscow)
+	nine := time.Date(2024, 12, 16, 9, 0, 0, 0, moscow)
+
+	tests := []struct {
+		name     string
+		settings ReminderSettings
+		now      time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "slot within window",
+			settings: ReminderSettings{Times: []int{9 * 60}, Days: AllWeekdays},
+			now:      now,
+			expected: []time.Time{nine},
+		},
+		{
+			name:     "slot exactly now",
+			settings: ReminderSettings{Times: []int{9 * 60}, Days: AllWeekdays},
+			now:      nine,
+			expected: []time.Time{nine},
+		},
+		{
+			name:     "slot older than window",
+			settings: ReminderSettings{Times: []int{8 * 60}, Days: AllWeekdays},
+			now:      now,
+			expected: nil,
+		},
+		{
+			name:     "slot in the future",
+			settings: ReminderSettings{Times: []int{10 * 60}, Days: AllWeekdays},
+			now:      now,
+			expected: nil,
+		},
+		{
+			name:     "weekday disabled",
+			settings: ReminderSettings{Times: []int{9 * 60}, Days: AllWeekdays &^ (1 << uint(time.Monday))},
+			now:      now,
+			expected: nil,
+		},
+		{
+			name:     "paused",
+			settings: ReminderSettings{Times: []int{9 * 60}, Days: AllWeekdays, Paused: true},
+			now:      now,
+			expected: nil,
+		},
+		{
+			name:     "window crosses midnight",
+			settings: ReminderSettings{Times: []int{23*60 + 55}, Days: 1 << uint(time.Sunday)},
+			now:      time.Date(2024, 12, 16, 0, 2, 0, 0, moscow),
+			expected: []time.Time{time.Date(2024, 12, 15, 23, 55, 0, 0, moscow)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots := tt.settings.DueSlots(tt.now, moscow, 15*time.Minute)
+			assert.Equal(t, tt.expected, slots)
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	minutes, err := ParseClock("09:30")
+	assert.NoError(t, err)
+	assert.Equal(t, 9*60+30, minutes)
+	assert.Equal(t, "09:30", FormatClock(minutes))
+
+	for _, invalid := range []string{"", "9", "25:00", "12:60", "noon"} {
+		_, err := ParseClock(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// WeeklyReport summarizes a user's learning over one week
+type WeeklyReport struct {
+	WeekStart      time.Time // Monday, civil date
+	WordsAdded     int
+	Reviews        int
+	CorrectReviews int
+	Mastered       []Word     // words mastered during the week
+	Failing        []HardWord // words failed during the week, most failures first
+	Streak         int
+	BestStreak     int
+}
+
+// Accuracy returns the share of correct reviews in percent
+func (r WeeklyReport) Accuracy() int {
+	return accuracy(r.CorrectReviews, r.Reviews)
+}
+
+// Empty reports whether nothing was learned during the week
+func (r WeeklyReport) Empty() bool {
+	return r.WordsAdded == 0 && r.Reviews == 0
+}
+
+// WeekStart returns the Monday of the week containing the civil day
+func WeekStart(day time.Time) time.Time {
+	sinceMonday := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -sinceMonday)
+}
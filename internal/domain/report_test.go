@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		day  time.Time
+	}{
+		{name: "monday", day: monday},
+		{name: "wednesday", day: monday.AddDate(0, 0, 2)},
+		{name: "sunday", day: monday.AddDate(0, 0, 6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, monday, WeekStart(tt.day))
+		})
+	}
+}
+
+func TestWeeklyReport_Empty(t *testing.T) {
+	assert.True(t, WeeklyReport{Streak: 3}.Empty())
+	assert.False(t, WeeklyReport{WordsAdded: 1}.Empty())
+	assert.False(t, WeeklyReport{Reviews: 1}.Empty())
+}
+
+func TestWeeklyReport_Accuracy(t *testing.T) {
+	assert.Equal(t, 0, WeeklyReport{}.Accuracy())
+	assert.Equal(t, 75, WeeklyReport{Reviews: 8, CorrectReviews: 6}.Accuracy())
+}
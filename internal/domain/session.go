@@ -0,0 +1,113 @@
+package domain
+
+// SessionSizes are the batch sizes offered for a review session; 0 means all due words
+var SessionSizes = []int{10, 20, 50, 0}
+
+// ReviewSession is a fixed batch of words reviewed one by one.
+// It is kept in StateData, so it must stay JSON-serializable.
+type ReviewSession struct {
+	WordIDs []int `json:"word_ids"`
+	Pos     int   `json:"pos"` // index of the current word
+	// Direction of the current card, picked when it is shown
+	Direction ReviewDirection `json:"direction,omitempty"`
+	Correct   int             `json:"correct"`
+	Failed    []int           `json:"failed,omitempty"`
+	Skipped   []int           `json:"skipped,omitempty"`
+}
+
+// PerfectSessionMin is the smallest session that counts as perfect
+const PerfectSessionMin = 10
+
+// NewReviewSession starts a session over the given words
+func NewReviewSession(wordIDs []int) *ReviewSession {
+	return &ReviewSession{WordIDs: wordIDs}
+}
+
+// Done reports whether all words have been shown
+func (s *ReviewSession) Done() bool {
+	return s.Pos >= len(s.WordIDs)
+}
+
+// Current returns the ID of the word to show, false if the session is done
+func (s *ReviewSession) Current() (int, bool) {
+	if s.Done() {
+		return 0, false
+	}
+	return s.WordIDs[s.Pos], true
+}
+
+// Total returns the number of words in the session
+func (s *ReviewSession) Total() int {
+	return len(s.WordIDs)
+}
+
+// Answer records the current word as remembered or not and moves on
+func (s *ReviewSession) Answer(correct bool) {
+	id, ok := s.Current()
+	if !ok {
+		return
+	}
+	if correct {
+		s.Correct++
+	} else {
+		s.Failed = append(s.Failed, id)
+	}
+	s.Pos++
+}
+
+// Skip moves on without grading the current word
+func (s *ReviewSession) Skip() {
+	id, ok := s.Current()
+	if !ok {
+		return
+	}
+	s.Skipped = append(s.Skipped, id)
+	s.Pos++
+}
+
+// Discard removes the current word from the session, e.g. if it was deleted
+func (s *ReviewSession) Discard() {
+	if s.Done() {
+		return
+	}
+	s.WordIDs = append(s.WordIDs[:s.Pos], s.WordIDs[s.Pos+1:]...)
+}
+
+// Finish ends the session early, keeping only the words already shown
+func (s *ReviewSession) Finish() {
+	s.WordIDs = s.WordIDs[:s.Pos]
+}
+
+// Perfect reports whether a finished session of at least PerfectSessionMin
+// words was answered correctly without skips
+func (s *ReviewSession) Perfect() bool {
+	return s.Done() && s.Total() >= PerfectSessionMin && s.Correct == s.Total()
+}
+
+// Missed returns failed and skipped words in the order they were shown
+func (s *ReviewSession) Missed() []int {
+	missed := make(map[int]bool, len(s.Failed)+len(s.Skipped))
+	for _, id := range s.Failed {
+		missed[id] = true
+	}
+	for _, id := range s.Skipped {
+		missed[id] = true
+	}
+
+	var ids []int
+	for _, id := range s.WordIDs[:s.Pos] {
+		if missed[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// RepeatMissed returns a new session over the missed words, nil if nothing was missed
+func (s *ReviewSession) RepeatMissed() *ReviewSession {
+	missed := s.Missed()
+	if len(missed) == 0 {
+		return nil
+	}
+	return NewReviewSession(missed)
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewSession_Flow(t *testing.T) {
+	s := NewReviewSession([]int{1, 2, 3, 4})
+
+	id, ok := s.Current()
+	assert.True(t, ok)
+	assert.Equal(t, 1, id)
+
+	s.Answer(true)
+	s.Answer(false)
+	s.Skip()
+	assert.False(t, s.Done())
+
+	id, _ = s.Current()
+	assert.Equal(t, 4, id)
+	s.Answer(true)
+
+	assert.True(t, s.Done())
+	_, ok = s.Current()
+	assert.False(t, ok)
+
+	assert.Equal(t, 2, s.Correct)
+	assert.Equal(t, []int{2}, s.Failed)
+	assert.Equal(t, []int{3}, s.Skipped)
+	assert.Equal(t, []int{2, 3}, s.Missed())
+
+	// Answers after the end are ignored
+	s.Answer(false)
+	s.Skip()
+	assert.Equal(t, 4, s.Pos)
+	assert.Equal(t, []int{2}, s.Failed)
+}
+
+func TestReviewSession_DiscardAndFinish(t *testing.T) {
+	s := NewReviewSession([]int{1, 2, 3, 4})
+	s.Answer(true)
+	s.Discard()
+
+	assert.Equal(t, []int{1, 3, 4}, s.WordIDs)
+	id, _ := s.Current()
+	assert.Equal(t, 3, id)
+
+	s.Skip()
+	s.Finish()
+
+	assert.True(t, s.Done())
+	assert.Equal(t, 2, s.Total())
+	assert.Equal(t, []int{3}, s.Missed())
+}
+
+func TestReviewSession_RepeatMissed(t *testing.T) {
+	s := NewReviewSession([]int{1, 2, 3})
+	s.Answer(false)
+	s.Answer(true)
+	s.Skip()
+
+	repeat := s.RepeatMissed()
+	require.NotNil(t, repeat)
+	assert.Equal(t, []int{1, 3}, repeat.WordIDs)
+	assert.Equal(t, 0, repeat.Pos)
+
+	perfect := NewReviewSession([]int{1})
+	perfect.Answer(true)
+	assert.Nil(t, perfect.RepeatMissed())
+}
+
+func TestReviewSession_Perfect(t *testing.T) {
+	ids := make([]int, PerfectSessionMin)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	answer := func(s *ReviewSession, last func()) *ReviewSession {
+		for i := 0; i < PerfectSessionMin-1; i++ {
+			s.Answer(true)
+		}
+		assert.False(t, s.Perfect(), "unfinished")
+		last()
+		return s
+	}
+
+	s := NewReviewSession(ids)
+	assert.True(t, answer(s, func() { s.Answer(true) }).Perfect())
+
+	s = NewReviewSession(ids)
+	assert.False(t, answer(s, func() { s.Answer(false) }).Perfect())
+
+	s = NewReviewSession(ids)
+	assert.False(t, answer(s, s.Skip).Perfect())
+
+	short := NewReviewSession([]int{1, 2})
+	short.Answer(true)
+	short.Answer(true)
+	assert.False(t, short.Perfect(), "too short")
+}
+
+func TestReviewSession_JSON(t *testing.T) {
+	state := &StateData{State: StateReviewSession, Session: NewReviewSession([]int{5, 6})}
+	state.Session.Answer(false)
+
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	var restored StateData
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, state, &restored)
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Setting keys as stored in user_settings
+const (
+	SettingPageSize    = "page_size"
+	SettingHistoryDays = "history_days"
+	SettingHideDays    = "hide_days"
+	SettingTimezone    = "timezone"
+	SettingDirection   = "review_direction"
+	SettingLanguage    = "language"
+	SettingPair        = "language_pair"
+	SettingAutoSwap    = "auto_swap"
+
+	// SettingClientLanguage keeps the last language_code sent by Telegram,
+	// for messages sent outside of an update (reminders, reports)
+	SettingClientLanguage = "client_language"
+)
+
+// LanguageAuto follows the language of the user's Telegram client
+const LanguageAuto = "auto"
+
+// Setting limits
+const (
+	MaxPageSize    = 20
+	MinHistoryDays = 7
+	MaxHistoryDays = 365
+	MaxHideDays    = 365
+)
+
+// SnoozePresets are the snooze durations in days every card offers
+var SnoozePresets = []int{1, 3, 7, 30}
+
+// Settings are a user's preferences. Missing values come from defaults.
+type Settings struct {
+	PageSize    int             // days per page in the day list
+	HistoryDays int             // how long words are kept and listed
+	HideDays    int             // user-defined snooze duration in days
+	Location    *time.Location  // where days start and reminders fire
+	Direction   ReviewDirection // which side review cards show
+	Language    string          // LanguageAuto or an interface language code
+	ClientLang  string          // last language_code of the Telegram client
+	Pair        LanguagePair    // active pair: new words, lists, reviews and stats
+	AutoSwap    bool            // swap word and translation typed in the wrong order
+}
+
+// Set parses value and assigns it to the setting named key
+func (s *Settings) Set(key, value string) error {
+	switch key {
+	case SettingPageSize:
+		return setInt(&s.PageSize, key, value, 1, MaxPageSize)
+	case SettingHistoryDays:
+		return setInt(&s.HistoryDays, key, value, MinHistoryDays, MaxHistoryDays)
+	case SettingHideDays:
+		return setInt(&s.HideDays, key, value, 1, MaxHideDays)
+	case SettingTimezone:
+		loc, err := time.LoadLocation(value)
+		if err != nil || value == "" || value == "Local" {
+			return fmt.Errorf("%s: unknown time zone %q", key, value)
+		}
+		s.Location = loc
+		return nil
+	case SettingDirection:
+		d, ok := ParseReviewDirection(value)
+		if !ok {
+			return fmt.Errorf("%s: unknown direction %q", key, value)
+		}
+		s.Direction = d
+		return nil
+	case SettingLanguage:
+		// Whether the language is supported is up to the settings service
+		if value != LanguageAuto && !isLanguageCode(value) {
+			return fmt.Errorf("%s: malformed language %q", key, value)
+		}
+		s.Language = value
+		return nil
+	case SettingPair:
+		pair, err := ParseLanguagePair(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		s.Pair = pair
+		return nil
+	case SettingAutoSwap:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false: %w", key, err)
+		}
+		s.AutoSwap = v
+		return nil
+	case SettingClientLanguage:
+		// Any code is kept, unsupported ones resolve to the default language
+		if len(value) > 35 {
+			return fmt.Errorf("%s: language code too long", key)
+		}
+		s.ClientLang = value
+		return nil
+	default:
+		return fmt.Errorf("unknown setting %q", key)
+	}
+}
+
+// Get returns the setting named key in its stored form
+func (s Settings) Get(key string) string {
+	switch key {
+	case SettingPageSize:
+		return strconv.Itoa(s.PageSize)
+	case SettingHistoryDays:
+		return strconv.Itoa(s.HistoryDays)
+	case SettingHideDays:
+		return strconv.Itoa(s.HideDays)
+	case SettingTimezone:
+		if s.Location == nil {
+			return ""
+		}
+		return s.Location.String()
+	case SettingDirection:
+		return string(s.Direction)
+	case SettingLanguage:
+		if s.Language == "" {
+			return LanguageAuto
+		}
+		return s.Language
+	case SettingClientLanguage:
+		return s.ClientLang
+	case SettingPair:
+		return s.Pair.String()
+	case SettingAutoSwap:
+		return strconv.FormatBool(s.AutoSwap)
+	default:
+		return ""
+	}
+}
+
+// SnoozeDays returns snooze durations offered on cards:
+// the presets plus the user-defined one, in ascending order
+func (s Settings) SnoozeDays() []int {
+	days := append([]int(nil), SnoozePresets...)
+	for _, d := range SnoozePresets {
+		if d == s.HideDays {
+			return days
+		}
+	}
+	if s.HideDays > 0 {
+		days = append(days, s.HideDays)
+		sort.Ints(days)
+	}
+	return days
+}
+
+func setInt(dst *int, key, value string, lo, hi int) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%s must be a number: %w", key, err)
+	}
+	if n < lo || n > hi {
+		return fmt.Errorf("%s must be between %d and %d, got %d", key, lo, hi, n)
+	}
+	*dst = n
+	return nil
+}
+
+// isLanguageCode reports whether s is a bare language code such as "en"
+func isLanguageCode(s string) bool {
+	return len(s) >= 2 && len(s) <= 3 && strings.Trim(s, "abcdefghijklmnopqrstuvwxyz") == ""
+}
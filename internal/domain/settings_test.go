@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettings_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{name: "page size", key: SettingPageSize, value: "10"},
+		{name: "page size too big", key: SettingPageSize, value: "100", wantErr: true},
+		{name: "page size not a number", key: SettingPageSize, value: "ten", wantErr: true},
+		{name: "history", key: SettingHistoryDays, value: "90"},
+		{name: "history too short", key: SettingHistoryDays, value: "1", wantErr: true},
+		{name: "hide days", key: SettingHideDays, value: "3"},
+		{name: "hide days zero", key: SettingHideDays, value: "0", wantErr: true},
+		{name: "timezone", key: SettingTimezone, value: "Asia/Tokyo"},
+		{name: "unknown timezone", key: SettingTimezone, value: "Mars/Olympus", wantErr: true},
+		{name: "local timezone", key: SettingTimezone, value: "Local", wantErr: true},
+		{name: "direction", key: SettingDirection, value: "reverse"},
+		{name: "unknown direction", key: SettingDirection, value: "up", wantErr: true},
+		{name: "language", key: SettingLanguage, value: "en"},
+		{name: "automatic language", key: SettingLanguage, value: LanguageAuto},
+		{name: "malformed language", key: SettingLanguage, value: "EN", wantErr: true},
+		{name: "language with region", key: SettingLanguage, value: "en-US", wantErr: true},
+		{name: "client language", key: SettingClientLanguage, value: "pt-br"},
+		{name: "language pair", key: SettingPair, value: "de-ru"},
+		{name: "unsupported language pair", key: SettingPair, value: "ru-ru", wantErr: true},
+		{name: "auto swap", key: SettingAutoSwap, value: "true"},
+		{name: "auto swap not a bool", key: SettingAutoSwap, value: "maybe", wantErr: true},
+		{name: "unknown key", key: "color", value: "red", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Settings
+			err := s.Set(tt.key, tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, Settings{}, s)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, s.Get(tt.key))
+		})
+	}
+}
+
+func TestSettings_Get(t *testing.T) {
+	s := Settings{
+		PageSize:    7,
+		HistoryDays: 60,
+		HideDays:    7,
+		Location:    time.UTC,
+		Direction:   DirectionMixed,
+	}
+
+	assert.Equal(t, "7", s.Get(SettingPageSize))
+	assert.Equal(t, "60", s.Get(SettingHistoryDays))
+	assert.Equal(t, "UTC", s.Get(SettingTimezone))
+	assert.Equal(t, "mixed", s.Get(SettingDirection))
+	assert.Equal(t, "", s.Get("color"))
+	assert.Equal(t, "", Settings{}.Get(SettingTimezone))
+}
+
+func TestSettings_SnoozeDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		hideDays int
+		expected []int
+	}{
+		{name: "preset", hideDays: 7, expected: []int{1, 3, 7, 30}},
+		{name: "user-defined", hideDays: 14, expected: []int{1, 3, 7, 14, 30}},
+		{name: "longer than presets", hideDays: 90, expected: []int{1, 3, 7, 30, 90}},
+		{name: "not set", hideDays: 0, expected: []int{1, 3, 7, 30}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Settings{HideDays: tt.hideDays}.SnoozeDays())
+		})
+	}
+	assert.Equal(t, []int{1, 3, 7, 30}, SnoozePresets)
+}
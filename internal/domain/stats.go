@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+// DailyCount is a number of events on one day
+type DailyCount struct {
+	Day   time.Time // civil date, see CivilDay
+	Count int
+}
+
+// DailyReviews are graded reviews on one day
+type DailyReviews struct {
+	Day     time.Time // civil date, see CivilDay
+	Total   int
+	Correct int
+}
+
+// HardWord is a word the user fails most often
+type HardWord struct {
+	Word        string
+	Translation string
+	Failures    int
+	Reviews     int
+}
+
+// DirectionReviews are graded reviews in one direction
+type DirectionReviews struct {
+	Direction ReviewDirection
+	Total     int
+	Correct   int
+}
+
+// Accuracy returns the share of correct reviews in percent
+func (r DirectionReviews) Accuracy() int {
+	return accuracy(r.Correct, r.Total)
+}
+
+// WordTotals are counters over all user's words
+type WordTotals struct {
+	Total             int
+	HiddenTemporarily int
+	HiddenForever     int
+	Mastered          int
+	AvgTimeToMastery  time.Duration // 0 if no word is mastered
+}
+
+// UserStats is a user's statistics dashboard
+type UserStats struct {
+	WordTotals
+	Reviews        int
+	CorrectReviews int
+	AddedPerDay    []DailyCount   // oldest first, days without words included
+	AddedPerWeek   []DailyCount   // Day is the first day of the week
+	ReviewsPerDay  []DailyReviews // oldest first, days without reviews included
+	Hardest        []HardWord
+	ByDirection    []DirectionReviews // forward first, cloze last; reviews before directions were tracked are left out
+}
+
+// Accuracy returns the share of correct reviews in percent
+func (s UserStats) Accuracy() int {
+	return accuracy(s.CorrectReviews, s.Reviews)
+}
+
+func accuracy(correct, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return correct * 100 / total
+}
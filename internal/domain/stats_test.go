@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserStats_Accuracy(t *testing.T) {
+	assert.Equal(t, 0, UserStats{}.Accuracy())
+	assert.Equal(t, 66, UserStats{Reviews: 3, CorrectReviews: 2}.Accuracy())
+	assert.Equal(t, 100, UserStats{Reviews: 5, CorrectReviews: 5}.Accuracy())
+}
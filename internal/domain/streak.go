@@ -0,0 +1,106 @@
+package domain
+
+import "time"
+
+// DefaultDailyGoal is the number of words a day counts toward a streak by default
+const DefaultDailyGoal = 5
+
+// DailyGoalPresets are the goals offered in /goal
+var DailyGoalPresets = []int{3, 5, 10, 20}
+
+// freezeCooldown is how often a freeze can be used
+const freezeCooldown = 7
+
+// Activity is what a user did on one day
+type Activity struct {
+	WordsAdded int
+	Reviews    int
+}
+
+// Total returns the number of words that count toward the daily goal
+func (a Activity) Total() int {
+	return a.WordsAdded + a.Reviews
+}
+
+// Streak holds a user's study streak and daily goal.
+// Days are civil dates as returned by CivilDay.
+type Streak struct {
+	UserID        int64
+	Current       int
+	Best          int
+	LastGoalDay   *time.Time // last day the goal was reached
+	DailyGoal     int
+	FreezeEnabled bool       // a missed day is forgiven once a week
+	FreezeUsedOn  *time.Time // day that was covered by the last freeze
+}
+
+// NewStreak returns an empty streak with the default goal
+func NewStreak(userID int64) *Streak {
+	return &Streak{UserID: userID, DailyGoal: DefaultDailyGoal}
+}
+
+// CivilDay returns the date of t in loc as midnight UTC,
+// so days can be compared and subtracted without DST surprises
+func CivilDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween returns the number of days from a to b
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// FreezeAvailable reports whether a freeze can cover the given day
+func (s *Streak) FreezeAvailable(day time.Time) bool {
+	if !s.FreezeEnabled {
+		return false
+	}
+	return s.FreezeUsedOn == nil || daysBetween(*s.FreezeUsedOn, day) >= freezeCooldown
+}
+
+// CurrentAt returns the streak as of today; it is 0 once the streak is broken
+func (s *Streak) CurrentAt(today time.Time) int {
+	if s.LastGoalDay == nil {
+		return 0
+	}
+
+	switch daysBetween(*s.LastGoalDay, today) {
+	case 0, 1:
+		return s.Current
+	case 2:
+		// Yesterday was missed, but a freeze would still save the streak
+		if s.FreezeAvailable(today.AddDate(0, 0, -1)) {
+			return s.Current
+		}
+	}
+	return 0
+}
+
+// RecordGoal extends the streak when the daily goal is reached today.
+// Returns true if a freeze was spent on yesterday.
+func (s *Streak) RecordGoal(today time.Time) (usedFreeze bool) {
+	if s.LastGoalDay != nil {
+		switch gap := daysBetween(*s.LastGoalDay, today); {
+		case gap <= 0:
+			return false
+		case gap == 1:
+			s.Current++
+		case gap == 2 && s.FreezeAvailable(today.AddDate(0, 0, -1)):
+			yesterday := today.AddDate(0, 0, -1)
+			s.FreezeUsedOn = &yesterday
+			s.Current++
+			usedFreeze = true
+		default:
+			s.Current = 1
+		}
+	} else {
+		s.Current = 1
+	}
+
+	if s.Current > s.Best {
+		s.Best = s.Current
+	}
+	s.LastGoalDay = &today
+	return usedFreeze
+}
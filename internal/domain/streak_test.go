@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 12, d, 0, 0, 0, 0, time.UTC)
+}
+
+func dayPtr(d int) *time.Time {
+	t := day(d)
+	return &t
+}
+
+func TestCivilDay(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	// 22:30 UTC is already the next day in Moscow
+	late := time.Date(2024, 12, 15, 22, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, day(16), CivilDay(late, moscow))
+	assert.Equal(t, day(15), CivilDay(late, time.UTC))
+}
+
+func TestStreak_RecordGoal(t *testing.T) {
+	tests := []struct {
+		name           string
+		streak         Streak
+		today          time.Time
+		expectedCur    int
+		expectedBest   int
+		expectedFreeze bool
+	}{
+		{
+			name:         "first goal",
+			streak:       Streak{},
+			today:        day(10),
+			expectedCur:  1,
+			expectedBest: 1,
+		},
+		{
+			name:         "same day twice",
+			streak:       Streak{Current: 3, Best: 3, LastGoalDay: dayPtr(10)},
+			today:        day(10),
+			expectedCur:  3,
+			expectedBest: 3,
+		},
+		{
+			name:         "next day",
+			streak:       Streak{Current: 3, Best: 5, LastGoalDay: dayPtr(9)},
+			today:        day(10),
+			expectedCur:  4,
+			expectedBest: 5,
+		},
+		{
+			name:         "new best",
+			streak:       Streak{Current: 5, Best: 5, LastGoalDay: dayPtr(9)},
+			today:        day(10),
+			expectedCur:  6,
+			expectedBest: 6,
+		},
+		{
+			name:         "missed day without freeze",
+			streak:       Streak{Current: 5, Best: 5, LastGoalDay: dayPtr(8)},
+			today:        day(10),
+			expectedCur:  1,
+			expectedBest: 5,
+		},
+		{
+			name:           "missed day with freeze",
+			streak:         Streak{Current: 5, Best: 5, LastGoalDay: dayPtr(8), FreezeEnabled: true},
+			today:          day(10),
+			expectedCur:    6,
+			expectedBest:   6,
+			expectedFreeze: true,
+		},
+		{
+			name:         "freeze used this week",
+			streak:       Streak{Current: 5, Best: 5, LastGoalDay: dayPtr(8), FreezeEnabled: true, FreezeUsedOn: dayPtr(4)},
+			today:        day(10),
+			expectedCur:  1,
+			expectedBest: 5,
+		},
+		{
+			name:         "two missed days",
+			streak:       Streak{Current: 5, Best: 5, LastGoalDay: dayPtr(7), FreezeEnabled: true},
+			today:        day(10),
+			expectedCur:  1,
+			expectedBest: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.streak
+			usedFreeze := s.RecordGoal(tt.today)
+
+			assert.Equal(t, tt.expectedCur, s.Current)
+			assert.Equal(t, tt.expectedBest, s.Best)
+			assert.Equal(t, tt.expectedFreeze, usedFreeze)
+			assert.Equal(t, tt.today, *s.LastGoalDay)
+		})
+	}
+}
+
+func TestStreak_CurrentAt(t *testing.T) {
+	s := Streak{Current: 4, Best: 4, LastGoalDay: dayPtr(10)}
+
+	assert.Equal(t, 4, s.CurrentAt(day(10)))
+	assert.Equal(t, 4, s.CurrentAt(day(11)))
+	assert.Equal(t, 0, s.CurrentAt(day(12)))
+
+	s.FreezeEnabled = true
+	assert.Equal(t, 4, s.CurrentAt(day(12)))
+	assert.Equal(t, 0, s.CurrentAt(day(13)))
+}
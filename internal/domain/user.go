@@ -13,16 +13,34 @@ type User struct {
 type UserState string
 
 const (
-	StateIdle               UserState = "idle"
-	StateWaitingWord        UserState = "waiting_word"
-	StateWaitingTranslation UserState = "waiting_translation"
-	StateWaitingPassword    UserState = "waiting_password"
+	StateIdle                UserState = "idle"
+	StateWaitingWord         UserState = "waiting_word"
+	StateWaitingTranslation  UserState = "waiting_translation"
+	StateWaitingPassword     UserState = "waiting_password"
+	StateWaitingReminderTime UserState = "waiting_reminder_time"
+	StateReviewSession       UserState = "review_session"
+	StateWaitingExample      UserState = "waiting_example"
+	StateWaitingCloze        UserState = "waiting_cloze"
+	StateWaitingDeckName     UserState = "waiting_deck_name"
 )
 
 // StateData holds temporary data for user's current state
 type StateData struct {
-	State       UserState
-	CurrentWord string
-	MessageID   int // For editing messages
-}
+	State       UserState `json:"state"`
+	CurrentWord string    `json:"current_word,omitempty"`
+	MessageID   int       `json:"message_id,omitempty"` // For editing messages
+
+	// Suggestions are dictionary translations of CurrentWord offered as buttons
+	Suggestions []string `json:"suggestions,omitempty"`
+
+	// WordID and Answer are the word an example is added to or the cloze card
+	// being answered; Answer is the blanked-out form of the word
+	WordID int    `json:"word_id,omitempty"`
+	Answer string `json:"answer,omitempty"`
 
+	// DeckCode is the deck of a /start deep link opened before logging in
+	DeckCode string `json:"deck_code,omitempty"`
+
+	// Session is the review session in progress or just finished
+	Session *ReviewSession `json:"session,omitempty"`
+}
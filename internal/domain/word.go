@@ -4,18 +4,22 @@ import "time"
 
 // Word represents a word-translation pair
 type Word struct {
-	ID           int
-	UserID       int64
-	Word         string
-	Translation  string
-	CreatedAt    time.Time
-	HiddenUntil  *time.Time
+	ID            int
+	UserID        int64
+	Word          string
+	Translation   string
+	CreatedAt     time.Time
+	HiddenUntil   *time.Time
 	HiddenForever bool
+	// Example is a sentence using the word, loaded for cloze cards only
+	Example string
 }
 
+// MasteryStreak is how many correct reviews in a row make a word mastered
+const MasteryStreak = 3
+
 // WordPair is a simplified version for display
 type WordPair struct {
 	Word        string
 	Translation string
 }
-
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+var btnAchievements = tele.Btn{
+	Unique: "achievements",
+	Text:   "btn.achievements",
+}
+
+// handleAchievements lists earned and locked achievements (/achievements or the menu button)
+func (h *Handler) handleAchievements(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	if c.Callback() != nil {
+		// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+	} else if !h.requireAuth(c) {
+		return nil
+	}
+
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+
+	statuses, err := h.achievementService.List(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to list achievements", zap.Error(err), zap.Int64("user_id", userID))
+		if c.Callback() != nil {
+			return nil // Callback уже подтверждён
+		}
+		return c.Send(tr.T("error.generic"))
+	}
+
+	text := achievementsText(tr, statuses, settings)
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(localBtn(tr, btnMainMenu)))
+
+	if c.Callback() != nil {
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+	}
+	return c.Send(text, markup)
+}
+
+// SendAchievement announces an earned achievement (implements service.AchievementSender)
+func (h *Handler) SendAchievement(ctx context.Context, userID int64, achievement domain.Achievement) error {
+	h.logger.Info("Achievement earned", zap.Int64("user_id", userID), zap.String("achievement", achievement.ID))
+
+	tr := h.userLocalizer(ctx, userID)
+	_, err := h.bot.Send(tele.ChatID(userID), tr.T("achievement.earned", achievement.Icon, tr.T("achievement."+achievement.ID)))
+	return recipientError(err)
+}
+
+// achievementsText lists earned achievements with dates and locked ones with progress
+func achievementsText(tr *i18n.Localizer, statuses []domain.AchievementStatus, settings domain.Settings) string {
+	var b strings.Builder
+	b.WriteString(tr.T("achievement.title"))
+
+	earned := 0
+	for _, s := range statuses {
+		name := tr.T("achievement." + s.ID)
+		b.WriteString("\n")
+		if s.EarnedAt != nil {
+			earned++
+			b.WriteString(tr.T("achievement.earned_line", s.Icon, name, s.EarnedAt.In(settings.Location).Format("02.01.2006")))
+		} else {
+			b.WriteString(tr.T("achievement.locked_line", name, s.Progress, s.Goal))
+		}
+	}
+
+	b.WriteString("\n\n" + tr.T("achievement.total", earned, len(statuses)))
+	return b.String()
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAchievements_HaveNames(t *testing.T) {
+	for _, lang := range i18n.Languages {
+		for _, a := range domain.Achievements {
+			assert.True(t, i18n.Has(lang, "achievement."+a.ID), "%s has no name in %s", a.ID, lang)
+		}
+	}
+}
+
+func TestAchievementsText(t *testing.T) {
+	earnedAt := time.Date(2024, 12, 1, 23, 30, 0, 0, time.UTC)
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	statuses := []domain.AchievementStatus{
+		{Achievement: domain.Achievement{ID: "first_word", Icon: "🌱", Goal: 1}, EarnedAt: &earnedAt, Progress: 1},
+		{Achievement: domain.Achievement{ID: "words_100", Icon: "📚", Goal: 100}, Progress: 42},
+	}
+
+	text := achievementsText(i18n.For(i18n.English), statuses, domain.Settings{Location: moscow})
+
+	assert.Contains(t, text, "🌱 First word — 02.12.2024")
+	assert.Contains(t, text, "🔒 100 words — 42/100")
+	assert.Contains(t, text, "Earned: 1 of 2")
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+// audit records an action of the sender; a failure is logged and doesn't stop the action
+func (h *Handler) audit(c tele.Context, action domain.AuditAction, target string, metadata map[string]any) {
+	userID := c.Sender().ID
+	if err := h.auditService.Record(middleware.Ctx(c), userID, action, target, metadata); err != nil {
+		h.logger.Error("Failed to write audit log",
+			zap.Error(err),
+			zap.Int64("user_id", userID),
+			zap.String("action", string(action)),
+			zap.String("target", target),
+		)
+	}
+}
+
+// senderMetadata describes who is behind a user ID in audit entries about them
+func senderMetadata(u *tele.User) map[string]any {
+	metadata := map[string]any{}
+	if u.Username != "" {
+		metadata["username"] = u.Username
+	}
+	if name := strings.TrimSpace(u.FirstName + " " + u.LastName); name != "" {
+		metadata["name"] = name
+	}
+	return metadata
+}
+
+// requireAdmin tells non-admins the command isn't for them
+func (h *Handler) requireAdmin(c tele.Context, tr *i18n.Localizer) bool {
+	if h.auditService.IsAdmin(c.Sender().ID) {
+		return true
+	}
+	_ = c.Send(tr.T("admin.only"))
+	return false
+}
+
+// handleRevoke revokes a user's access (/revoke <user id>, admins only)
+func (h *Handler) handleRevoke(c tele.Context) error {
+	tr := h.tr(c)
+	if !h.requireAdmin(c, tr) {
+		return nil
+	}
+
+	targetID, err := strconv.ParseInt(strings.TrimSpace(c.Message().Payload), 10, 64)
+	if err != nil {
+		return c.Send(tr.T("admin.revoke_usage"))
+	}
+
+	if err := h.authService.RevokeUser(middleware.Ctx(c), targetID); err != nil {
+		h.logger.Error("Failed to revoke user", zap.Error(err), zap.Int64("target_id", targetID))
+		return c.Send(tr.T("error.generic"))
+	}
+	h.ResetState(targetID)
+
+	h.logger.Info("User revoked", zap.Int64("admin_id", c.Sender().ID), zap.Int64("target_id", targetID))
+	h.audit(c, domain.AuditAuthRevoked, domain.AuditUser(targetID), nil)
+	return c.Send(tr.T("admin.revoked", targetID))
+}
+
+// handleAudit shows the latest audit log entries (/audit [user id] [action], admins only)
+func (h *Handler) handleAudit(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+	if !h.requireAdmin(c, tr) {
+		return nil
+	}
+
+	filter, ok := parseAuditFilter(c.Message().Payload)
+	if !ok {
+		actions := make([]string, len(domain.AuditActions))
+		for i, a := range domain.AuditActions {
+			actions[i] = string(a)
+		}
+		return c.Send(tr.T("audit.usage", strings.Join(actions, ", ")))
+	}
+
+	entries, err := h.auditService.List(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to list audit log", zap.Error(err), zap.Int64("user_id", userID))
+		return c.Send(tr.T("error.generic"))
+	}
+	return c.Send(auditText(tr, entries, settings.Location))
+}
+
+// parseAuditFilter reads "[user id] [action]" in any order
+func parseAuditFilter(payload string) (domain.AuditFilter, bool) {
+	var filter domain.AuditFilter
+	for _, arg := range strings.Fields(payload) {
+		if id, err := strconv.ParseInt(arg, 10, 64); err == nil && filter.UserID == 0 {
+			filter.UserID = id
+			continue
+		}
+		if action, ok := domain.ParseAuditAction(strings.ToLower(arg)); ok && filter.Action == "" {
+			filter.Action = action
+			continue
+		}
+		return domain.AuditFilter{}, false
+	}
+	return filter, true
+}
+
+// auditText lists entries one per line: time, actor, action, target and metadata
+func auditText(tr *i18n.Localizer, entries []domain.AuditEntry, loc *time.Location) string {
+	if len(entries) == 0 {
+		return tr.T("audit.empty")
+	}
+
+	var b strings.Builder
+	b.WriteString(tr.T("audit.title"))
+	for _, e := range entries {
+		actor := strconv.FormatInt(e.ActorID, 10)
+		if e.ActorID == domain.SystemActor {
+			actor = tr.T("audit.system")
+		}
+
+		parts := []string{e.CreatedAt.In(loc).Format("02.01.2006 15:04"), actor, string(e.Action)}
+		if e.Target != "" {
+			parts = append(parts, e.Target)
+		}
+		if len(e.Metadata) > 0 {
+			// Keys are sorted, so lines read the same every time
+			if metadata, err := json.Marshal(e.Metadata); err == nil {
+				parts = append(parts, string(metadata))
+			}
+		}
+		b.WriteString("\n\n" + strings.Join(parts, " · "))
+	}
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+	tele "gopkg.in/telebot.v3"
+)
+
+func TestParseAuditFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		expected domain.AuditFilter
+		ok       bool
+	}{
+		{name: "empty", payload: "", expected: domain.AuditFilter{}, ok: true},
+		{name: "user", payload: "123", expected: domain.AuditFilter{UserID: 123}, ok: true},
+		{name: "action", payload: "auth_failed", expected: domain.AuditFilter{Action: domain.AuditAuthFailed}, ok: true},
+		{name: "both in any order", payload: " AUTH_FAILED  5000000000 ", expected: domain.AuditFilter{UserID: 5000000000, Action: domain.AuditAuthFailed}, ok: true},
+		{name: "unknown action", payload: "123 passwords", ok: false},
+		{name: "two users", payload: "123 456", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, ok := parseAuditFilter(tt.payload)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, filter)
+		})
+	}
+}
+
+func TestAuditText(t *testing.T) {
+	tr := i18n.For(i18n.English)
+	moscow := time.FixedZone("MSK", 3*60*60)
+	at := time.Date(2024, 12, 1, 23, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, tr.T("audit.empty"), auditText(tr, nil, moscow))
+
+	text := auditText(tr, []domain.AuditEntry{
+		{ActorID: 123, Action: domain.AuditAuthFailed, Target: "user:123", Metadata: map[string]any{"username": "bob", "name": "Bob"}, CreatedAt: at},
+		{ActorID: domain.SystemActor, Action: domain.AuditWordsCleaned, Metadata: map[string]any{"words": 3}, CreatedAt: at},
+	}, moscow)
+
+	assert.Contains(t, text, tr.T("audit.title"))
+	assert.Contains(t, text, `02.12.2024 02:30 · 123 · auth_failed · user:123 · {"name":"Bob","username":"bob"}`)
+	assert.Contains(t, text, `02.12.2024 02:30 · system · words_cleaned · {"words":3}`)
+}
+
+func TestSenderMetadata(t *testing.T) {
+	assert.Equal(t, map[string]any{"username": "bob", "name": "Bob Smith"},
+		senderMetadata(&tele.User{ID: 1, Username: "bob", FirstName: "Bob", LastName: "Smith"}))
+	assert.Equal(t, map[string]any{}, senderMetadata(&tele.User{ID: 1}))
+}
@@ -3,13 +3,17 @@ package handler
 import (
 	"fmt"
 	"html"
-	"math/rand"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 	"unicode"
 
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/metrics"
+	"languager/internal/middleware"
+
 	"go.uber.org/zap"
 	tele "gopkg.in/telebot.v3"
 )
@@ -24,13 +28,33 @@ func cleanCallbackData(data string) string {
 	}, strings.TrimSpace(data))
 }
 
+// dayLabel returns a user-friendly name of a day in the list.
+// today is the current time in the user's time zone.
+func dayLabel(tr *i18n.Localizer, day domain.Day, today time.Time) string {
+	date := day.Date
+
+	// Check if today
+	if date.Year() == today.Year() && date.Month() == today.Month() && date.Day() == today.Day() {
+		return tr.T("day.today")
+	}
+
+	// Check if yesterday
+	yesterday := today.AddDate(0, 0, -1)
+	if date.Year() == yesterday.Year() && date.Month() == yesterday.Month() && date.Day() == yesterday.Day() {
+		return tr.T("day.yesterday")
+	}
+
+	// Return formatted date
+	return date.Format("2 ") + tr.Month(date.Month()) + date.Format(" 2006")
+}
+
 // handleEditError handles errors from c.Edit() - просто логирует ошибки
 // Callback уже должен быть подтверждён до вызова этой функции
 func (h *Handler) handleEditError(err error, c tele.Context, userID int64) bool {
 	if err == nil {
 		return false
 	}
-	
+
 	errStr := err.Error()
 	// If message is not modified, it means it was already edited by another callback
 	if strings.Contains(errStr, "message is not modified") {
@@ -40,7 +64,7 @@ func (h *Handler) handleEditError(err error, c tele.Context, userID int64) bool
 		)
 		return true // Уже изменено, это нормально
 	}
-	
+
 	// Log the error
 	h.logger.Warn("Failed to edit message",
 		zap.Error(err),
@@ -68,59 +92,121 @@ func (h *Handler) handleCallback(c tele.Context) error {
 		zap.Int64("user_id", c.Sender().ID),
 	)
 
-	// Handle specific button callbacks by Unique first
-	switch callback.Unique {
+	route, handle := h.callbackRoute(callback.Unique, data)
+	if handle == nil {
+		// If it's not handled, acknowledge it anyway
+		h.logger.Warn("Unhandled callback in handleCallback",
+			zap.String("data", data),
+			zap.String("unique", callback.Unique),
+		)
+		return c.Respond()
+	}
+
+	// Buttons of group messages are quiz answers only
+	if middleware.IsGroup(c.Chat()) && route != "group_quiz" {
+		h.logger.Warn("Private callback in a group", zap.String("data", data), zap.Int64("chat_id", c.Chat().ID))
+		return c.Respond()
+	}
+
+	defer metrics.ObserveSince(metrics.CallbackDuration.WithLabelValues(route), time.Now())
+	return handle(c)
+}
+
+// callbackRoute picks the handler for a callback and names it for metrics
+func (h *Handler) callbackRoute(unique, data string) (string, tele.HandlerFunc) {
+	// Handle specific button callbacks by Unique first.
+	// If Unique is empty, try Data (for buttons with Unique that didn't come through)
+	key := unique
+	if key == "" {
+		key = data
+	}
+
+	switch key {
 	case "view_days", "back_to_days":
-		return h.handleViewDays(c)
+		return "view_days", h.handleViewDays
 	case "random_pair", "more":
-		return h.handleRandomPair(c)
+		return "random_pair", h.handleRandomPair
 	case "cancel":
-		return h.handleCancel(c)
+		return "cancel", h.handleCancel
 	case "back", "main_menu":
-		return h.handleStart(c)
-	}
-
-	// If Unique is empty, try to handle by Data (for buttons with Unique that didn't come through)
-	if callback.Unique == "" {
-		switch data {
-		case "view_days", "back_to_days":
-			return h.handleViewDays(c)
-		case "random_pair", "more":
-			return h.handleRandomPair(c)
-		case "cancel":
-			return h.handleCancel(c)
-		case "back", "main_menu":
-			return h.handleStart(c)
-		}
+		return "main_menu", h.handleStart
+	case "stats":
+		return "stats", h.handleStats
+	case "stats_charts":
+		return "stats_charts", h.handleStatsCharts
+	case "review_problems":
+		return "review_problems", h.handleReviewProblems
+	case "session":
+		return "session", h.handleSessionMenu
+	case "settings":
+		return "settings", h.handleSettings
+	case "pairs":
+		return "pairs", h.handlePairsMenu
+	case "cloze":
+		return "cloze", h.handleCloze
+	case "decks":
+		return "decks", h.handleDecks
+	case "achievements":
+		return "achievements", h.handleAchievements
 	}
 
 	// Handle by Data prefix (dynamic buttons)
+	withData := func(handle func(tele.Context, string) error) tele.HandlerFunc {
+		return func(c tele.Context) error { return handle(c, data) }
+	}
+
 	switch {
 	case strings.HasPrefix(data, "page_"):
-		return h.handlePagination(c, data)
+		return "pagination", withData(h.handlePagination)
 	case strings.HasPrefix(data, "day_"):
-		return h.handleDaySelection(c, data)
+		return "day_selection", withData(h.handleDaySelection)
+	case strings.HasPrefix(data, "snooze_"):
+		return "snooze", withData(h.handleSnooze)
 	case strings.HasPrefix(data, "hide_7d_"):
-		return h.handleHideFor7Days(c, data)
+		return "hide_7d", withData(h.handleSnooze)
 	case strings.HasPrefix(data, "hide_forever_"):
-		return h.handleHideForeverConfirm(c, data)
+		return "hide_forever", withData(h.handleHideForeverConfirm)
 	case strings.HasPrefix(data, "confirm_hide_"):
-		return h.handleConfirmHideForever(c, data)
+		return "confirm_hide", withData(h.handleConfirmHideForever)
 	case strings.HasPrefix(data, "cancel_hide_"):
-		return h.handleCancelHide(c, data)
-	}
-
-	// If it's not handled, acknowledge it anyway
-	h.logger.Warn("Unhandled callback in handleCallback",
-		zap.String("data", data),
-		zap.String("unique", callback.Unique),
-	)
-	return c.Respond()
+		return "cancel_hide", withData(h.handleCancelHide)
+	case strings.HasPrefix(data, "rem_"):
+		return "reminders", withData(h.handleReminderCallback)
+	case strings.HasPrefix(data, "goal_"):
+		return "goal", withData(h.handleGoalCallback)
+	case strings.HasPrefix(data, "sess_"):
+		return "session", withData(h.handleSessionCallback)
+	case strings.HasPrefix(data, "dir_"):
+		return "direction", withData(h.handleDirectionCallback)
+	case strings.HasPrefix(data, "report_"):
+		return "report", withData(h.handleReportCallback)
+	case strings.HasPrefix(data, "grade_"):
+		return "grade", withData(h.handleGrade)
+	case strings.HasPrefix(data, "set_"), strings.HasPrefix(data, "setv_"):
+		return "settings", withData(h.handleSettingsCallback)
+	case strings.HasPrefix(data, "pair_"):
+		return "pairs", withData(h.handlePairCallback)
+	case strings.HasPrefix(data, "swap_"):
+		return "swap", withData(h.handleSwap)
+	case strings.HasPrefix(data, "sugg_"):
+		return "suggestion", withData(h.handleSuggestion)
+	case strings.HasPrefix(data, "example_"):
+		return "example", withData(h.handleExample)
+	case strings.HasPrefix(data, "cloze_give_up"):
+		return "cloze", withData(h.handleClozeGiveUp)
+	case strings.HasPrefix(data, "deck_"):
+		return "decks", withData(h.handleDeckCallback)
+	case strings.HasPrefix(data, "gq_"):
+		return "group_quiz", withData(h.handleGroupAnswer)
+	}
+
+	return "", nil
 }
 
 // handleViewDays shows list of days with words
 func (h *Handler) handleViewDays(c tele.Context) error {
 	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
 
 	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
 	if c.Callback() != nil {
@@ -130,7 +216,9 @@ func (h *Handler) handleViewDays(c tele.Context) error {
 	}
 
 	// Get first page
-	days, totalPages, err := h.wordService.GetDaysList(userID, 1)
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+	days, totalPages, err := h.wordService.GetDaysList(ctx, userID, 1, settings)
 	if err != nil {
 		h.logger.Error("Failed to get days list", zap.Error(err))
 		return nil // Callback уже подтверждён
@@ -142,12 +230,13 @@ func (h *Handler) handleViewDays(c tele.Context) error {
 	}
 
 	// Build message
-	text := "📅 Вот твои дни:\n\n"
+	text := tr.T("days.title") + "\n\n"
 	markup := &tele.ReplyMarkup{}
 	rows := []tele.Row{}
 
+	today := time.Now().In(settings.Location)
 	for _, day := range days {
-		btnText := fmt.Sprintf("%s (%d)", day.DisplayString(), day.WordCount)
+		btnText := fmt.Sprintf("%s (%d)", dayLabel(tr, day, today), day.WordCount)
 		btn := markup.Data(btnText, "day_"+day.DateString())
 		rows = append(rows, markup.Row(btn))
 	}
@@ -161,7 +250,7 @@ func (h *Handler) handleViewDays(c tele.Context) error {
 	}
 
 	// Add back button
-	rows = append(rows, markup.Row(btnBack))
+	rows = append(rows, markup.Row(localBtn(tr, btnBack)))
 
 	markup.Inline(rows...)
 
@@ -179,8 +268,6 @@ func (h *Handler) handleViewDays(c tele.Context) error {
 
 // handleRandomPair shows a random word-translation pair
 func (h *Handler) handleRandomPair(c tele.Context) error {
-	userID := c.Sender().ID
-
 	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ, до блокировки
 	if c.Callback() != nil {
 		if err := c.Respond(); err != nil {
@@ -188,58 +275,69 @@ func (h *Handler) handleRandomPair(c tele.Context) error {
 		}
 	}
 
-	// Получаем или создаём блокировку для этого пользователя
-	h.callbackMux.Lock()
-	lock, exists := h.callbackLocks[userID]
-	if !exists {
-		lock = &sync.Mutex{}
-		h.callbackLocks[userID] = lock
-	}
-	h.callbackMux.Unlock()
+	return h.showRandomPair(c)
+}
+
+// Review modes
+type reviewMode int
+
+const (
+	reviewRandom   reviewMode = iota // any visible word
+	reviewProblems                   // words whose last review failed
+)
+
+// showRandomPair shows a random pair; the callback must be answered by the caller
+func (h *Handler) showRandomPair(c tele.Context) error {
+	return h.showPair(c, reviewRandom)
+}
+
+// showPair shows the next pair of the review mode; the callback must be answered by the caller
+func (h *Handler) showPair(c tele.Context, mode reviewMode) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
 
 	// Блокируем обработку для этого пользователя
+	lock := h.userLock(userID)
 	lock.Lock()
 	defer lock.Unlock()
 
-	word, err := h.wordService.GetRandomPair(userID)
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+
+	title, gradePrefix, next := tr.T("review.random"), "grade_", btnMore
+	pick := h.wordService.GetRandomPair
+	if mode == reviewProblems {
+		title, gradePrefix, next = tr.T("review.problem"), "grade_p_", btnMoreProblems
+		pick = h.wordService.GetProblemPair
+	}
+
+	word, err := pick(ctx, userID, settings.Pair)
 	if err != nil {
 		h.logger.Error("Failed to get random word", zap.Error(err))
 		return nil // Callback уже подтверждён
 	}
 
 	if word == nil {
+		if mode == reviewProblems {
+			return h.showNoProblems(c, tr)
+		}
 		// Callback уже подтверждён
 		return nil
 	}
 
-	// Рандомно выбираем, что показывать открыто, а что под спойлером
-	rand.Seed(time.Now().UnixNano())
-	showWordFirst := rand.Intn(2) == 0
-
-	escWord := html.EscapeString(word.Word)
-	escTranslation := html.EscapeString(word.Translation)
-
-	var visibleText, spoilerText string
-	if showWordFirst {
-		visibleText = fmt.Sprintf("📝 %s", escWord)
-		spoilerText = fmt.Sprintf("🔄 %s", escTranslation)
-	} else {
-		visibleText = fmt.Sprintf("🔄 %s", escTranslation)
-		spoilerText = fmt.Sprintf("📝 %s", escWord)
-	}
-
-	// Формируем текст со спойлером в формате HTML
-	// В Telegram Bot API спойлеры работают через тег <tg-spoiler>текст</tg-spoiler>
-	text := fmt.Sprintf("🎲 Случайная пара:\n\n%s\n<tg-spoiler>%s</tg-spoiler>", visibleText, spoilerText)
+	direction := pickDirection(settings.Direction)
+	text := title + "\n\n" + formatPair(word, direction)
 
 	markup := &tele.ReplyMarkup{}
 	markup.Inline(
-		markup.Row(btnMore),
 		markup.Row(
-			markup.Data("💤 Не показывать 7 дней", fmt.Sprintf("hide_7d_%d", word.ID)),
-			markup.Data("♿️ Не показывать никогда", fmt.Sprintf("hide_forever_%d", word.ID)),
+			markup.Data(tr.T("btn.remember"), fmt.Sprintf("%sok_%s_%d", gradePrefix, direction.Short(), word.ID)),
+			markup.Data(tr.T("btn.forgot"), fmt.Sprintf("%sfail_%s_%d", gradePrefix, direction.Short(), word.ID)),
 		),
-		markup.Row(btnBack),
+		markup.Row(localBtn(tr, next)),
+		snoozeRow(tr, markup, settings, word.ID),
+		markup.Row(markup.Data(tr.T("btn.hide_forever"), fmt.Sprintf("hide_forever_%d", word.ID))),
+		markup.Row(localBtn(tr, btnBack)),
 	)
 
 	// Edit message - только edit, никаких send
@@ -262,6 +360,104 @@ func (h *Handler) handleRandomPair(c tele.Context) error {
 	return c.Send(text, markup, &tele.SendOptions{ParseMode: "HTML"})
 }
 
+// handleGrade records whether the user remembered the word and shows the next pair
+func (h *Handler) handleGrade(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	// Extract result and word ID
+	data = strings.TrimSpace(data)
+	grade := strings.TrimPrefix(data, "grade_")
+	mode := reviewRandom
+	if strings.HasPrefix(grade, "p_") {
+		mode = reviewProblems
+		grade = strings.TrimPrefix(grade, "p_")
+	}
+	correct := strings.HasPrefix(grade, "ok_")
+	wordIDStr := strings.TrimPrefix(strings.TrimPrefix(grade, "ok_"), "fail_")
+
+	// Cards sent before directions were tracked carry only the word ID
+	var direction domain.ReviewDirection
+	if short, rest, found := strings.Cut(wordIDStr, "_"); found {
+		direction, _ = domain.ParseShortDirection(short)
+		wordIDStr = rest
+	}
+
+	wordID, err := strconv.Atoi(wordIDStr)
+	if err != nil {
+		h.logger.Error("Failed to parse word ID", zap.Error(err), zap.String("data", data))
+		return c.Respond()
+	}
+
+	mastered, err := h.wordService.GradeReview(ctx, userID, wordID, correct, direction)
+	if err != nil {
+		h.logger.Error("Failed to record review", zap.Error(err), zap.Int("word_id", wordID))
+		return c.Respond()
+	}
+	// Counted once graded, after the next card is shown
+	defer h.trackActivity(c, domain.Activity{Reviews: 1})
+
+	// Answer the callback here, showRandomPair doesn't
+	response := &tele.CallbackResponse{}
+	if mastered {
+		response.Text = h.tr(c).T("review.mastered")
+	}
+	if err := c.Respond(response); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	return h.showPair(c, mode)
+}
+
+// userLock returns the mutex serializing callbacks of one user
+func (h *Handler) userLock(userID int64) *sync.Mutex {
+	// Получаем или создаём блокировку для этого пользователя
+	h.callbackMux.Lock()
+	defer h.callbackMux.Unlock()
+
+	lock, exists := h.callbackLocks[userID]
+	if !exists {
+		lock = &sync.Mutex{}
+		h.callbackLocks[userID] = lock
+	}
+	return lock
+}
+
+// formatPair renders a word card: the side picked by direction is shown,
+// the other one is under a spoiler
+func formatPair(word *domain.Word, direction domain.ReviewDirection) string {
+	escWord := html.EscapeString(word.Word)
+	escTranslation := html.EscapeString(word.Translation)
+
+	var visibleText, spoilerText string
+	if direction == domain.DirectionReverse {
+		visibleText = fmt.Sprintf("🔄 %s", escTranslation)
+		spoilerText = fmt.Sprintf("📝 %s", escWord)
+	} else {
+		visibleText = fmt.Sprintf("📝 %s", escWord)
+		spoilerText = fmt.Sprintf("🔄 %s", escTranslation)
+	}
+
+	// Формируем текст со спойлером в формате HTML
+	// В Telegram Bot API спойлеры работают через тег <tg-spoiler>текст</tg-spoiler>
+	return fmt.Sprintf("%s\n<tg-spoiler>%s</tg-spoiler>", visibleText, spoilerText)
+}
+
+// showNoProblems tells the user there are no problem words left
+func (h *Handler) showNoProblems(c tele.Context, tr *i18n.Localizer) error {
+	text := tr.T("review.no_problems")
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(localBtn(tr, btnRandomPair)), markup.Row(localBtn(tr, btnMainMenu)))
+
+	if c.Callback() != nil {
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, c.Sender().ID)
+		}
+		return nil
+	}
+	return c.Send(text, markup)
+}
+
 // handleCancel cancels current operation and resets state
 func (h *Handler) handleCancel(c tele.Context) error {
 	userID := c.Sender().ID
@@ -275,9 +471,10 @@ func (h *Handler) handleCancel(c tele.Context) error {
 
 	h.ResetState(userID)
 
+	tr := h.tr(c)
 	if err := c.Edit(
-		"🏠 Главное меню\n\nВыберите действие:",
-		mainMenuMarkup(),
+		h.mainMenuText(middleware.Ctx(c), tr, userID),
+		mainMenuMarkup(tr),
 	); err != nil {
 		h.handleEditError(err, c, userID)
 		// Callback уже подтверждён, просто логируем ошибку
@@ -288,6 +485,7 @@ func (h *Handler) handleCancel(c tele.Context) error {
 // handlePagination handles page navigation
 func (h *Handler) handlePagination(c tele.Context, data string) error {
 	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
 
 	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
 	if c.Callback() != nil {
@@ -305,7 +503,9 @@ func (h *Handler) handlePagination(c tele.Context, data string) error {
 		return nil
 	}
 
-	days, totalPages, err := h.wordService.GetDaysList(userID, page)
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+	days, totalPages, err := h.wordService.GetDaysList(ctx, userID, page, settings)
 	if err != nil {
 		h.logger.Error("Failed to get days list", zap.Error(err))
 		return nil // Callback уже подтверждён
@@ -317,12 +517,13 @@ func (h *Handler) handlePagination(c tele.Context, data string) error {
 	}
 
 	// Build message
-	text := "📅 Вот твои дни:\n\n"
+	text := tr.T("days.title") + "\n\n"
 	markup := &tele.ReplyMarkup{}
 	rows := []tele.Row{}
 
+	today := time.Now().In(settings.Location)
 	for _, day := range days {
-		btnText := fmt.Sprintf("%s (%d)", day.DisplayString(), day.WordCount)
+		btnText := fmt.Sprintf("%s (%d)", dayLabel(tr, day, today), day.WordCount)
 		btn := markup.Data(btnText, "day_"+day.DateString())
 		rows = append(rows, markup.Row(btn))
 	}
@@ -342,7 +543,7 @@ func (h *Handler) handlePagination(c tele.Context, data string) error {
 	}
 
 	// Add back button
-	rows = append(rows, markup.Row(btnBack))
+	rows = append(rows, markup.Row(localBtn(tr, btnBack)))
 
 	markup.Inline(rows...)
 
@@ -357,6 +558,7 @@ func (h *Handler) handlePagination(c tele.Context, data string) error {
 // handleDaySelection shows words for selected day
 func (h *Handler) handleDaySelection(c tele.Context, data string) error {
 	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
 
 	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
 	if c.Callback() != nil {
@@ -370,7 +572,9 @@ func (h *Handler) handleDaySelection(c tele.Context, data string) error {
 	dateStr := strings.TrimPrefix(data, "day_")
 	h.logger.Info("Handling day selection", zap.String("date", dateStr), zap.String("original_data", data), zap.Int64("user_id", userID))
 
-	words, err := h.wordService.GetWordsByDate(userID, dateStr)
+	settings := h.userSettings(ctx, userID)
+	tr, loc := localizer(c, settings), settings.Location
+	words, err := h.wordService.GetWordsByDate(ctx, userID, settings.Pair, dateStr, loc)
 	if err != nil {
 		h.logger.Error("Failed to get words by date", zap.Error(err))
 		return nil // Callback уже подтверждён
@@ -382,14 +586,14 @@ func (h *Handler) handleDaySelection(c tele.Context, data string) error {
 	}
 
 	// Build message with all words
-	text := fmt.Sprintf("📝 Слова за выбранный день (%d):\n\n", len(words))
+	text := tr.T("days.words", len(words)) + "\n\n"
 	for i, word := range words {
 		// Determine status emoji
 		var statusEmoji string
 		if word.HiddenForever {
 			statusEmoji = "♿️"
 		} else if word.HiddenUntil != nil && word.HiddenUntil.After(time.Now()) {
-			statusEmoji = "💤"
+			statusEmoji = tr.T("days.snoozed_until", word.HiddenUntil.In(loc).Format("02.01"))
 		} else {
 			statusEmoji = "💡"
 		}
@@ -398,7 +602,7 @@ func (h *Handler) handleDaySelection(c tele.Context, data string) error {
 
 	markup := &tele.ReplyMarkup{}
 	markup.Inline(
-		markup.Row(btnBackToDays, btnMainMenu),
+		markup.Row(localBtn(tr, btnBackToDays), localBtn(tr, btnMainMenu)),
 	)
 
 	// Edit message - только edit, никаких send
@@ -409,9 +613,47 @@ func (h *Handler) handleDaySelection(c tele.Context, data string) error {
 	return nil
 }
 
-// handleHideFor7Days hides a word for 7 days and shows success message with "Ещё" button
-func (h *Handler) handleHideFor7Days(c tele.Context, data string) error {
+// snoozeRow offers to hide the word for each of the user's snooze durations
+func snoozeRow(tr *i18n.Localizer, markup *tele.ReplyMarkup, settings domain.Settings, wordID int) tele.Row {
+	row := tele.Row{}
+	for _, days := range settings.SnoozeDays() {
+		row = append(row, markup.Data(tr.T("btn.snooze", days), fmt.Sprintf("snooze_%d_%d", days, wordID)))
+	}
+	return row
+}
+
+// parseSnoozeData extracts days and word ID from snooze_<days>_<id>.
+// Legacy hide_7d_<id> buttons snooze for defaultDays. Callback data comes
+// from the client, so days outside of 1..domain.MaxHideDays are rejected:
+// they would unhide the word or overflow the duration. Any value in range
+// is accepted, so cards shown before the user changed hide_days still work.
+func parseSnoozeData(data string, defaultDays int) (days, wordID int, err error) {
+	data = strings.TrimSpace(data)
+	if rest, ok := strings.CutPrefix(data, "hide_7d_"); ok {
+		wordID, err = strconv.Atoi(rest)
+		return defaultDays, wordID, err
+	}
+
+	daysStr, idStr, ok := strings.Cut(strings.TrimPrefix(data, "snooze_"), "_")
+	if !ok {
+		return 0, 0, fmt.Errorf("malformed snooze data %q", data)
+	}
+	if days, err = strconv.Atoi(daysStr); err != nil {
+		return 0, 0, err
+	}
+	if days < 1 || days > domain.MaxHideDays {
+		return 0, 0, fmt.Errorf("snooze days %d out of range", days)
+	}
+	if wordID, err = strconv.Atoi(idStr); err != nil {
+		return 0, 0, err
+	}
+	return days, wordID, nil
+}
+
+// handleSnooze hides a word for the picked number of days and shows success message with "Ещё" button
+func (h *Handler) handleSnooze(c tele.Context, data string) error {
 	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
 
 	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
 	if c.Callback() != nil {
@@ -420,25 +662,26 @@ func (h *Handler) handleHideFor7Days(c tele.Context, data string) error {
 		}
 	}
 
-	// Extract word ID
-	data = strings.TrimSpace(data)
-	wordIDStr := strings.TrimPrefix(data, "hide_7d_")
-	wordID, err := strconv.Atoi(wordIDStr)
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+	days, wordID, err := parseSnoozeData(data, settings.HideDays)
 	if err != nil {
-		h.logger.Error("Failed to parse word ID", zap.Error(err), zap.String("data", data))
+		h.logger.Error("Failed to parse snooze data", zap.Error(err), zap.String("data", data))
 		return nil // Callback уже подтверждён
 	}
 
 	// Hide the word
-	if err := h.wordService.HideWordFor7Days(wordID); err != nil {
-		h.logger.Error("Failed to hide word for 7 days", zap.Error(err), zap.Int("word_id", wordID))
+	duration := time.Duration(days) * 24 * time.Hour
+	if err := h.wordService.SnoozeWord(ctx, userID, wordID, duration); err != nil {
+		h.logger.Error("Failed to snooze word", zap.Error(err), zap.Int("word_id", wordID), zap.Int("days", days))
 		return nil // Callback уже подтверждён
 	}
 
 	// Show success message with "Ещё" button
-	text := "✅ Слово скрыто на 7 дней"
+	until := time.Now().Add(duration).In(settings.Location)
+	text := tr.T("hide.snoozed", tr.N("n.days", days), until.Format("02.01.2006"))
 	markup := &tele.ReplyMarkup{}
-	markup.Inline(markup.Row(btnMore))
+	markup.Inline(markup.Row(localBtn(tr, btnMore)))
 
 	// Edit message - только edit, никаких send
 	if err := c.Edit(text, markup); err != nil {
@@ -469,12 +712,13 @@ func (h *Handler) handleHideForeverConfirm(c tele.Context, data string) error {
 	}
 
 	// Show confirmation message
-	text := "❓ Точно ли хочешь убрать слово из повторения? Его придётся внести ещё раз"
+	tr := h.tr(c)
+	text := tr.T("hide.confirm")
 	markup := &tele.ReplyMarkup{}
 	markup.Inline(
 		markup.Row(
-			markup.Data("✅ Да", fmt.Sprintf("confirm_hide_%d", wordID)),
-			markup.Data("❌ Нет", fmt.Sprintf("cancel_hide_%d", wordID)),
+			markup.Data(tr.T("btn.yes"), fmt.Sprintf("confirm_hide_%d", wordID)),
+			markup.Data(tr.T("btn.no"), fmt.Sprintf("cancel_hide_%d", wordID)),
 		),
 	)
 
@@ -489,6 +733,7 @@ func (h *Handler) handleHideForeverConfirm(c tele.Context, data string) error {
 // handleConfirmHideForever permanently hides a word and shows success message with "Ещё" button
 func (h *Handler) handleConfirmHideForever(c tele.Context, data string) error {
 	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
 
 	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
 	if c.Callback() != nil {
@@ -507,15 +752,16 @@ func (h *Handler) handleConfirmHideForever(c tele.Context, data string) error {
 	}
 
 	// Hide the word forever
-	if err := h.wordService.HideWordForever(wordID); err != nil {
+	if err := h.wordService.HideWordForever(ctx, userID, wordID); err != nil {
 		h.logger.Error("Failed to hide word forever", zap.Error(err), zap.Int("word_id", wordID))
 		return nil // Callback уже подтверждён
 	}
 
 	// Show success message with "Ещё" button
-	text := "✅ Слово скрыто навсегда"
+	tr := h.tr(c)
+	text := tr.T("hide.forever")
 	markup := &tele.ReplyMarkup{}
-	markup.Inline(markup.Row(btnMore))
+	markup.Inline(markup.Row(localBtn(tr, btnMore)))
 
 	// Edit message - только edit, никаких send
 	if err := c.Edit(text, markup); err != nil {
@@ -539,4 +785,3 @@ func (h *Handler) handleCancelHide(c tele.Context, data string) error {
 	// Show a new random pair (we don't have GetWordByID method to restore the original word)
 	return h.handleRandomPair(c)
 }
-
@@ -2,8 +2,14 @@ package handler
 
 import (
 	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
 )
 
 func TestCleanCallbackData(t *testing.T) {
@@ -56,3 +62,194 @@ func TestCleanCallbackData(t *testing.T) {
 		})
 	}
 }
+
+func TestCallbackRoute(t *testing.T) {
+	h := &Handler{}
+
+	tests := []struct {
+		name          string
+		unique        string
+		data          string
+		expectedRoute string
+	}{
+		{name: "by unique", unique: "view_days", expectedRoute: "view_days"},
+		{name: "back to days", unique: "back_to_days", expectedRoute: "view_days"},
+		{name: "more", unique: "more", expectedRoute: "random_pair"},
+		{name: "by data without unique", data: "random_pair", expectedRoute: "random_pair"},
+		{name: "main menu", data: "main_menu", expectedRoute: "main_menu"},
+		{name: "cancel", unique: "cancel", expectedRoute: "cancel"},
+		{name: "pagination", data: "page_2", expectedRoute: "pagination"},
+		{name: "day", data: "day_20241212", expectedRoute: "day_selection"},
+		{name: "hide 7 days", data: "hide_7d_5", expectedRoute: "hide_7d"},
+		{name: "snooze", data: "snooze_30_5", expectedRoute: "snooze"},
+		{name: "hide forever", data: "hide_forever_5", expectedRoute: "hide_forever"},
+		{name: "confirm hide", data: "confirm_hide_5", expectedRoute: "confirm_hide"},
+		{name: "cancel hide", data: "cancel_hide_5", expectedRoute: "cancel_hide"},
+		{name: "reminders", data: "rem_day_1", expectedRoute: "reminders"},
+		{name: "goal", data: "goal_10", expectedRoute: "goal"},
+		{name: "grade", data: "grade_ok_5", expectedRoute: "grade"},
+		{name: "stats", unique: "stats", expectedRoute: "stats"},
+		{name: "stats charts", unique: "stats_charts", expectedRoute: "stats_charts"},
+		{name: "review problems", unique: "review_problems", expectedRoute: "review_problems"},
+		{name: "report", data: "report_toggle", expectedRoute: "report"},
+		{name: "session menu", unique: "session", expectedRoute: "session"},
+		{name: "direction", data: "dir_reverse", expectedRoute: "direction"},
+		{name: "session answer", data: "sess_ok_3", expectedRoute: "session"},
+		{name: "grade problem word", data: "grade_p_fail_5", expectedRoute: "grade"},
+		{name: "settings menu", unique: "settings", expectedRoute: "settings"},
+		{name: "setting option", data: "set_page_size", expectedRoute: "settings"},
+		{name: "setting value", data: "setv_timezone:Asia/Tokyo", expectedRoute: "settings"},
+		{name: "pairs menu", unique: "pairs", expectedRoute: "pairs"},
+		{name: "pair switch", data: "pair_set_de-ru", expectedRoute: "pairs"},
+		{name: "new pair", data: "pair_new", expectedRoute: "pairs"},
+		{name: "swap word", data: "swap_42", expectedRoute: "swap"},
+		{name: "suggestion", data: "sugg_0", expectedRoute: "suggestion"},
+		{name: "add example", data: "example_42", expectedRoute: "example"},
+		{name: "cloze card", unique: "cloze", expectedRoute: "cloze"},
+		{name: "cloze give up", data: "cloze_give_up", expectedRoute: "cloze"},
+		{name: "decks menu", unique: "decks", expectedRoute: "decks"},
+		{name: "deck view", data: "deck_v_abc234de", expectedRoute: "decks"},
+		{name: "deck publish", data: "deck_publish", expectedRoute: "decks"},
+		{name: "achievements", unique: "achievements", expectedRoute: "achievements"},
+		{name: "group quiz answer", data: "gq_3_7", expectedRoute: "group_quiz"},
+		{name: "unknown", data: "something_else", expectedRoute: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, handle := h.callbackRoute(tt.unique, tt.data)
+			assert.Equal(t, tt.expectedRoute, route)
+			assert.Equal(t, tt.expectedRoute != "", handle != nil)
+		})
+	}
+}
+
+func TestUpdateType(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   tele.Update
+		expected string
+	}{
+		{name: "callback", update: tele.Update{Callback: &tele.Callback{}}, expected: "callback"},
+		{name: "command", update: tele.Update{Message: &tele.Message{Text: "/start"}}, expected: "command"},
+		{name: "text", update: tele.Update{Message: &tele.Message{Text: "hello"}}, expected: "text"},
+		{name: "sticker", update: tele.Update{Message: &tele.Message{}}, expected: "message"},
+		{name: "inline query", update: tele.Update{Query: &tele.Query{}}, expected: "inline_query"},
+		{name: "other", update: tele.Update{}, expected: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, updateType(tt.update))
+		})
+	}
+}
+
+func TestParseSnoozeData(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantDays   int
+		wantWordID int
+		wantErr    bool
+	}{
+		{name: "preset", data: "snooze_3_42", wantDays: 3, wantWordID: 42},
+		{name: "legacy button", data: "hide_7d_42", wantDays: 14, wantWordID: 42},
+		{name: "missing word", data: "snooze_3", wantErr: true},
+		{name: "bad days", data: "snooze_x_42", wantErr: true},
+		{name: "bad legacy id", data: "hide_7d_x", wantErr: true},
+		{name: "longest", data: "snooze_365_42", wantDays: 365, wantWordID: 42},
+		{name: "zero days", data: "snooze_0_42", wantErr: true},
+		{name: "negative days", data: "snooze_-5_42", wantErr: true},
+		{name: "too many days", data: "snooze_366_42", wantErr: true},
+		{name: "duration overflow", data: "snooze_9999999999_42", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, wordID, err := parseSnoozeData(tt.data, 14)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDays, days)
+			assert.Equal(t, tt.wantWordID, wordID)
+		})
+	}
+}
+
+func TestSnoozeRow(t *testing.T) {
+	markup := &tele.ReplyMarkup{}
+
+	row := snoozeRow(i18n.For(i18n.Russian), markup, domain.Settings{HideDays: 14}, 42)
+
+	require.Len(t, row, 5)
+	assert.Equal(t, "💤 1д", row[0].Text)
+	assert.Equal(t, "snooze_1_42", row[0].Unique)
+	assert.Equal(t, "snooze_14_42", row[3].Unique)
+	assert.Equal(t, "💤 30д", row[4].Text)
+
+	row = snoozeRow(i18n.For(i18n.English), markup, domain.Settings{HideDays: 14}, 42)
+	assert.Equal(t, "💤 30d", row[4].Text)
+}
+
+func TestDayLabel(t *testing.T) {
+	// Late evening in Moscow is already the next day in UTC
+	moscow := time.FixedZone("MSK", 3*3600)
+	today := time.Date(2024, 12, 16, 23, 30, 0, 0, moscow)
+	date := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		lang     i18n.Lang
+		date     time.Time
+		expected string
+	}{
+		{
+			name:     "today",
+			lang:     i18n.Russian,
+			date:     date(time.December, 16),
+			expected: "Сегодня",
+		},
+		{
+			name:     "yesterday",
+			lang:     i18n.Russian,
+			date:     date(time.December, 15),
+			expected: "Вчера",
+		},
+		{
+			name:     "two days ago",
+			lang:     i18n.Russian,
+			date:     date(time.December, 14),
+			expected: "14 дек 2024",
+		},
+		{
+			name:     "specific date",
+			lang:     i18n.Russian,
+			date:     date(time.June, 15),
+			expected: "15 июн 2024",
+		},
+		{
+			name:     "today in English",
+			lang:     i18n.English,
+			date:     date(time.December, 16),
+			expected: "Today",
+		},
+		{
+			name:     "specific date in English",
+			lang:     i18n.English,
+			date:     date(time.May, 1),
+			expected: "1 May 2024",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := domain.Day{Date: tt.date}
+			result := dayLabel(i18n.For(tt.lang), day, today)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
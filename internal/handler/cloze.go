@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"languager/internal/cloze"
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+	"languager/internal/service"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+var (
+	btnCloze = tele.Btn{
+		Unique: "cloze",
+		Text:   "btn.cloze",
+	}
+	btnClozeNext = tele.Btn{
+		Unique: "cloze",
+		Text:   "btn.more",
+	}
+)
+
+// exampleButton offers to add an example sentence to a just saved word
+func exampleButton(tr *i18n.Localizer, markup *tele.ReplyMarkup, wordID int) tele.Row {
+	return markup.Row(markup.Data(tr.T("btn.example"), fmt.Sprintf("example_%d", wordID)))
+}
+
+// handleExample asks for an example sentence of the word (example_<id>)
+func (h *Handler) handleExample(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	wordID, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(data), "example_"))
+	if err != nil {
+		h.logger.Error("Failed to parse word ID", zap.Error(err), zap.String("data", data))
+		return nil // Callback уже подтверждён
+	}
+
+	words, err := h.wordService.GetWordsByIDs(ctx, userID, []int{wordID})
+	if err != nil || len(words) == 0 {
+		h.logger.Warn("Word for example not found", zap.Error(err), zap.Int("word_id", wordID))
+		return nil // Callback уже подтверждён
+	}
+	word := words[0]
+
+	h.SetState(userID, &domain.StateData{
+		State:       domain.StateWaitingExample,
+		CurrentWord: word.Word,
+		WordID:      word.ID,
+	})
+
+	tr := h.tr(c)
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(localBtn(tr, btnCancel)))
+	return c.Send(tr.T("example.prompt", word.Word), markup)
+}
+
+// saveExample stores the example sentence sent in StateWaitingExample
+func (h *Handler) saveExample(c tele.Context, tr *i18n.Localizer, state *domain.StateData, example string) error {
+	userID := c.Sender().ID
+
+	err := h.wordService.SetExample(middleware.Ctx(c), userID, state.WordID, state.CurrentWord, example)
+	switch {
+	case errors.Is(err, service.ErrExampleMismatch):
+		// Keep waiting, the user may fix the sentence
+		return c.Send(tr.T("example.mismatch", state.CurrentWord))
+	case errors.Is(err, service.ErrExampleTooLong):
+		return c.Send(tr.T("example.too_long", service.MaxExampleLength))
+	case err != nil:
+		h.logger.Error("Failed to save example", zap.Error(err), zap.Int64("user_id", userID), zap.Int("word_id", state.WordID))
+		h.ResetState(userID)
+		return c.Send(tr.T("error.generic"))
+	}
+
+	h.logger.Info("Example saved", zap.Int64("user_id", userID), zap.Int("word_id", state.WordID))
+
+	// Back to adding words, as after saving a word
+	h.SetState(userID, &domain.StateData{State: domain.StateWaitingWord})
+	return c.Send(tr.T("example.saved"))
+}
+
+// handleCloze shows a cloze card of a random word with an example
+func (h *Handler) handleCloze(c tele.Context) error {
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if c.Callback() != nil {
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+	}
+
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	lock := h.userLock(userID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+
+	word, card, err := h.wordService.NextCloze(ctx, userID, settings.Pair)
+	if err != nil {
+		h.logger.Error("Failed to get cloze card", zap.Error(err), zap.Int64("user_id", userID))
+		return nil // Callback уже подтверждён
+	}
+
+	markup := &tele.ReplyMarkup{}
+	var text string
+	if word == nil {
+		text = tr.T("cloze.empty")
+		markup.Inline(markup.Row(localBtn(tr, btnMainMenu)))
+	} else {
+		h.SetState(userID, &domain.StateData{
+			State:       domain.StateWaitingCloze,
+			CurrentWord: word.Word,
+			WordID:      word.ID,
+			Answer:      card.Answer,
+		})
+		text = tr.T("cloze.card", card.Text, word.Translation)
+		markup.Inline(
+			markup.Row(markup.Data(tr.T("btn.cloze_give_up"), "cloze_give_up")),
+			markup.Row(localBtn(tr, btnMainMenu)),
+		)
+	}
+
+	if c.Callback() != nil {
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+	}
+	return c.Send(text, markup)
+}
+
+// handleClozeGiveUp reveals the answer of the card in progress (cloze_give_up)
+func (h *Handler) handleClozeGiveUp(c tele.Context, data string) error {
+	userID := c.Sender().ID
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	lock := h.userLock(userID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// The card was answered already or belongs to an older message
+	state := h.GetState(userID)
+	if state.State != domain.StateWaitingCloze {
+		h.logger.Info("Outdated cloze card", zap.Int64("user_id", userID), zap.String("data", data))
+		return nil // Callback уже подтверждён
+	}
+
+	text, markup := h.gradeCloze(c, state, false)
+	if err := c.Edit(text, markup); err != nil {
+		h.handleEditError(err, c, userID)
+	}
+	h.trackActivity(c, domain.Activity{Reviews: 1})
+	return nil
+}
+
+// checkCloze grades the answer typed in StateWaitingCloze.
+// Both the form used in the sentence and the saved word are accepted.
+func (h *Handler) checkCloze(c tele.Context, answer string) error {
+	lock := h.userLock(c.Sender().ID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// "Don't know" may have been tapped meanwhile
+	state := h.GetState(c.Sender().ID)
+	if state.State != domain.StateWaitingCloze {
+		return nil
+	}
+
+	text, markup := h.gradeCloze(c, state, cloze.Check(answer, state.Answer, state.CurrentWord))
+	if err := c.Send(text, markup); err != nil {
+		return err
+	}
+	h.trackActivity(c, domain.Activity{Reviews: 1})
+	return nil
+}
+
+// gradeCloze records the review of the card and returns the result message.
+// The caller must hold the user lock and track the activity after sending.
+func (h *Handler) gradeCloze(c tele.Context, state *domain.StateData, correct bool) (string, *tele.ReplyMarkup) {
+	userID := c.Sender().ID
+	tr := h.tr(c)
+
+	h.ResetState(userID)
+
+	mastered, err := h.wordService.GradeReview(middleware.Ctx(c), userID, state.WordID, correct, domain.DirectionCloze)
+	if err != nil {
+		h.logger.Error("Failed to record review",
+			zap.Error(err),
+			zap.Int64("user_id", userID),
+			zap.Int("word_id", state.WordID),
+		)
+	}
+
+	text := tr.T("cloze.wrong", state.Answer)
+	if correct {
+		text = tr.T("cloze.correct", state.Answer)
+	}
+	if mastered {
+		text += "\n\n" + tr.T("review.mastered")
+	}
+
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(
+		markup.Row(localBtn(tr, btnClozeNext)),
+		markup.Row(localBtn(tr, btnMainMenu)),
+	)
+	return text, markup
+}
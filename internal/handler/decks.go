@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+	"languager/internal/service"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+var btnDecks = tele.Btn{
+	Unique: "decks",
+	Text:   "btn.decks",
+}
+
+// handleDecks lists user's decks and subscriptions (/decks, "decks" button).
+// "/decks <code>" opens a deck by its share code.
+func (h *Handler) handleDecks(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	if c.Callback() != nil {
+		// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+	} else {
+		if !h.requireAuth(c) {
+			return nil
+		}
+		if code, ok := domain.ParseDeckCode(c.Message().Payload); ok {
+			return h.showDeck(c, code, "")
+		}
+	}
+
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+
+	own, subscribed, err := h.deckService.ListDecks(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to list decks", zap.Error(err), zap.Int64("user_id", userID))
+		if c.Callback() != nil {
+			return nil // Callback уже подтверждён
+		}
+		return c.Send(tr.T("error.generic"))
+	}
+
+	text, markup := decksMenu(tr, own, subscribed, settings.Pair)
+	if c.Callback() != nil {
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+	}
+	return c.Send(text, markup)
+}
+
+// handleDeckCallback handles deck buttons: deck_publish starts publishing the active pair,
+// the others carry the share code: deck_v_, deck_copy_, deck_sub_, deck_follow_,
+// deck_unfollow_, deck_unsub_, deck_del_ (asks to confirm) and deck_delok_
+func (h *Handler) handleDeckCallback(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	action := strings.TrimPrefix(strings.TrimSpace(data), "deck_")
+	if action == "publish" {
+		return h.askDeckName(c)
+	}
+
+	action, code, _ := strings.Cut(action, "_")
+	tr := h.tr(c)
+
+	var notice string
+	var err error
+	switch action {
+	case "v":
+	case "copy":
+		var copied int
+		if copied, err = h.deckService.Copy(ctx, userID, code); err == nil {
+			notice = tr.T("deck.copied", tr.N("n.words", copied))
+			h.audit(c, domain.AuditDeckImported, domain.AuditDeck(code), map[string]any{"words": copied, "subscribe": false})
+		}
+	case "sub":
+		var copied int
+		if copied, err = h.deckService.Subscribe(ctx, userID, code); err == nil {
+			notice = tr.T("deck.subscribed", tr.N("n.words", copied))
+			h.audit(c, domain.AuditDeckImported, domain.AuditDeck(code), map[string]any{"words": copied, "subscribe": true})
+		}
+	case "follow", "unfollow":
+		err = h.deckService.SetFollow(ctx, userID, code, action == "follow")
+	case "unsub":
+		if err = h.deckService.Unsubscribe(ctx, userID, code); err == nil {
+			notice = tr.T("deck.unsubscribed")
+		}
+	case "del":
+		markup := &tele.ReplyMarkup{}
+		markup.Inline(markup.Row(
+			markup.Data(tr.T("btn.yes"), "deck_delok_"+code),
+			markup.Data(tr.T("btn.no"), "deck_v_"+code),
+		))
+		if err := c.Edit(tr.T("deck.delete_confirm"), markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+	case "delok":
+		if err = h.deckService.Delete(ctx, userID, code); err == nil {
+			h.logger.Info("Deck deleted", zap.Int64("user_id", userID), zap.String("code", code))
+			h.audit(c, domain.AuditDeckDeleted, domain.AuditDeck(code), nil)
+			markup := &tele.ReplyMarkup{}
+			markup.Inline(markup.Row(localBtn(tr, btnDecks)))
+			if err := c.Edit(tr.T("deck.deleted"), markup); err != nil {
+				h.handleEditError(err, c, userID)
+			}
+			return nil
+		}
+	default:
+		h.logger.Warn("Unknown deck action", zap.String("data", data))
+		return nil // Callback уже подтверждён
+	}
+
+	if err != nil {
+		return h.deckError(c, tr, err, code)
+	}
+	if notice != "" {
+		h.logger.Info("Deck action", zap.Int64("user_id", userID), zap.String("action", action), zap.String("code", code))
+	}
+	return h.showDeck(c, code, notice)
+}
+
+// askDeckName asks for the name of a deck of the active language pair
+func (h *Handler) askDeckName(c tele.Context) error {
+	userID := c.Sender().ID
+	settings := h.userSettings(middleware.Ctx(c), userID)
+	tr := localizer(c, settings)
+
+	h.SetState(userID, &domain.StateData{State: domain.StateWaitingDeckName})
+
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(localBtn(tr, btnCancel)))
+	if err := c.Edit(tr.T("deck.name_prompt", settings.Pair.Label()), markup); err != nil {
+		h.handleEditError(err, c, userID)
+	}
+	return nil
+}
+
+// publishDeck publishes the active language pair under the name sent in StateWaitingDeckName
+func (h *Handler) publishDeck(c tele.Context, tr *i18n.Localizer, name string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+	pair := h.userSettings(ctx, userID).Pair
+
+	deck, err := h.deckService.Publish(ctx, userID, pair, name)
+	switch {
+	case errors.Is(err, service.ErrInvalidDeckName):
+		// Keep waiting for a better name
+		return c.Send(tr.T("deck.name_invalid", domain.MaxDeckNameLength))
+	case errors.Is(err, service.ErrDeckExists):
+		h.ResetState(userID)
+		return c.Send(tr.T("deck.exists", pair.Label()))
+	case err != nil:
+		h.logger.Error("Failed to publish deck", zap.Error(err), zap.Int64("user_id", userID))
+		h.ResetState(userID)
+		return c.Send(tr.T("error.generic"))
+	}
+
+	h.logger.Info("Deck published",
+		zap.Int64("user_id", userID),
+		zap.String("code", deck.Code),
+		zap.Stringer("pair", pair),
+		zap.Int("words", deck.WordCount),
+	)
+	h.audit(c, domain.AuditDeckExported, domain.AuditDeck(deck.Code), map[string]any{
+		"name":  deck.Name,
+		"pair":  pair.String(),
+		"words": deck.WordCount,
+	})
+	h.ResetState(userID)
+	return h.showDeck(c, deck.Code, tr.T("deck.published"))
+}
+
+// showDeck shows the deck with the share code, preceded by notice if any.
+// Edits the message of a callback, sends a new one otherwise.
+func (h *Handler) showDeck(c tele.Context, code, notice string) error {
+	userID := c.Sender().ID
+	tr := h.tr(c)
+
+	view, err := h.deckService.Open(middleware.Ctx(c), userID, code)
+	if err != nil {
+		return h.deckError(c, tr, err, code)
+	}
+
+	text, markup := deckCard(tr, view, h.bot.Me.Username)
+	if notice != "" {
+		text = notice + "\n\n" + text
+	}
+	if c.Callback() != nil {
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+	}
+	return c.Send(text, markup)
+}
+
+// deckError tells the user why a deck action failed
+func (h *Handler) deckError(c tele.Context, tr *i18n.Localizer, err error, code string) error {
+	text := tr.T("error.generic")
+	switch {
+	case errors.Is(err, service.ErrDeckNotFound):
+		text = tr.T("deck.not_found")
+	case errors.Is(err, service.ErrDeckForbidden):
+		text = tr.T("deck.forbidden")
+	default:
+		h.logger.Error("Deck action failed", zap.Error(err), zap.Int64("user_id", c.Sender().ID), zap.String("code", code))
+	}
+
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(localBtn(tr, btnDecks)))
+	if c.Callback() != nil {
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, c.Sender().ID)
+		}
+		return nil
+	}
+	return c.Send(text, markup)
+}
+
+// decksMenu lists own decks and subscriptions and offers to publish the active pair
+func decksMenu(tr *i18n.Localizer, own, subscribed []domain.Deck, active domain.LanguagePair) (string, *tele.ReplyMarkup) {
+	markup := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0, len(own)+len(subscribed)+2)
+
+	text := tr.T("deck.menu")
+	published := false
+	for _, d := range own {
+		rows = append(rows, markup.Row(markup.Data("📤 "+d.Name+" · "+d.Pair.Label(), "deck_v_"+d.Code)))
+		published = published || d.Pair == active
+	}
+	for _, d := range subscribed {
+		rows = append(rows, markup.Row(markup.Data("📥 "+d.Name+" · "+d.Pair.Label(), "deck_v_"+d.Code)))
+	}
+	if len(own)+len(subscribed) == 0 {
+		text += "\n\n" + tr.T("deck.none")
+	}
+
+	if !published {
+		rows = append(rows, markup.Row(markup.Data(tr.T("btn.deck_publish", active.Label()), "deck_publish")))
+	}
+	rows = append(rows, markup.Row(localBtn(tr, btnMainMenu)))
+	markup.Inline(rows...)
+
+	return text, markup
+}
+
+// deckCard describes the deck with the buttons the user's role allows
+func deckCard(tr *i18n.Localizer, view *domain.DeckView, botUsername string) (string, *tele.ReplyMarkup) {
+	markup := &tele.ReplyMarkup{}
+	code := view.Code
+
+	text := tr.T("deck.card", view.Name, view.Pair.Label(), tr.N("n.words", view.WordCount), view.Subscribers)
+
+	var rows []tele.Row
+	switch view.Role {
+	case domain.DeckOwner:
+		text += "\n\n" + tr.T("deck.owner", "https://t.me/"+botUsername+"?start="+view.Link(), code)
+		rows = append(rows, markup.Row(markup.Data(tr.T("btn.deck_delete"), "deck_del_"+code)))
+
+	case domain.DeckSubscriber:
+		follow, toggle := "switch.false", "deck_follow_"
+		if view.Follow {
+			follow, toggle = "switch.true", "deck_unfollow_"
+		}
+		text += "\n\n" + tr.T("deck.subscriber")
+		rows = append(rows,
+			markup.Row(markup.Data(tr.T("btn.deck_follow", tr.T(follow)), toggle+code)),
+			markup.Row(markup.Data(tr.T("btn.deck_sync"), "deck_copy_"+code)),
+			markup.Row(markup.Data(tr.T("btn.deck_unsubscribe"), "deck_unsub_"+code)),
+		)
+
+	default:
+		text += "\n\n" + tr.T("deck.visitor")
+		rows = append(rows,
+			markup.Row(markup.Data(tr.T("btn.deck_subscribe"), "deck_sub_"+code)),
+			markup.Row(markup.Data(tr.T("btn.deck_copy"), "deck_copy_"+code)),
+		)
+	}
+	rows = append(rows, markup.Row(localBtn(tr, btnDecks)))
+	markup.Inline(rows...)
+
+	return text, markup
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"testing"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecksMenu(t *testing.T) {
+	german := domain.LanguagePair{Source: "de", Target: "ru"}
+	own := []domain.Deck{{Code: "abc234de", Name: "Food", Pair: domain.DefaultLanguagePair}}
+	subscribed := []domain.Deck{{Code: "xyz789ab", Name: "Verbs", Pair: german}}
+
+	tests := []struct {
+		name       string
+		own        []domain.Deck
+		subscribed []domain.Deck
+		active     domain.LanguagePair
+		expected   []string
+	}{
+		{
+			name:       "active pair published",
+			own:        own,
+			subscribed: subscribed,
+			active:     domain.DefaultLanguagePair,
+			expected:   []string{"deck_v_abc234de", "deck_v_xyz789ab", "main_menu"},
+		},
+		{
+			name:       "active pair not published",
+			own:        own,
+			subscribed: subscribed,
+			active:     german,
+			expected:   []string{"deck_v_abc234de", "deck_v_xyz789ab", "deck_publish", "main_menu"},
+		},
+		{
+			name:     "no decks",
+			active:   german,
+			expected: []string{"deck_publish", "main_menu"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, markup := decksMenu(i18n.For(i18n.Russian), tt.own, tt.subscribed, tt.active)
+
+			require.Len(t, markup.InlineKeyboard, len(tt.expected))
+			for i, unique := range tt.expected {
+				assert.Equal(t, unique, markup.InlineKeyboard[i][0].Unique)
+			}
+		})
+	}
+}
+
+func TestDeckCard(t *testing.T) {
+	deck := domain.Deck{Code: "abc234de", Name: "Food", Pair: domain.DefaultLanguagePair, WordCount: 3}
+
+	tests := []struct {
+		name     string
+		view     domain.DeckView
+		expected []string
+	}{
+		{
+			name:     "owner",
+			view:     domain.DeckView{Deck: deck, Role: domain.DeckOwner},
+			expected: []string{"deck_del_abc234de", "decks"},
+		},
+		{
+			name:     "following subscriber",
+			view:     domain.DeckView{Deck: deck, Role: domain.DeckSubscriber, Follow: true},
+			expected: []string{"deck_unfollow_abc234de", "deck_copy_abc234de", "deck_unsub_abc234de", "decks"},
+		},
+		{
+			name:     "visitor",
+			view:     domain.DeckView{Deck: deck, Role: domain.DeckVisitor},
+			expected: []string{"deck_sub_abc234de", "deck_copy_abc234de", "decks"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, markup := deckCard(i18n.For(i18n.English), &tt.view, "languager_bot")
+
+			assert.Contains(t, text, "Food")
+			require.Len(t, markup.InlineKeyboard, len(tt.expected))
+			for i, unique := range tt.expected {
+				assert.Equal(t, unique, markup.InlineKeyboard[i][0].Unique)
+			}
+		})
+	}
+
+	t.Run("owner gets the link", func(t *testing.T) {
+		text, _ := deckCard(i18n.For(i18n.English), &domain.DeckView{Deck: deck, Role: domain.DeckOwner}, "languager_bot")
+		assert.Contains(t, text, "https://t.me/languager_bot?start=deck_abc234de")
+	})
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"math/rand"
+	"strings"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+// directionLabel names a review direction in menus and stats
+func directionLabel(tr *i18n.Localizer, d domain.ReviewDirection) string {
+	return tr.T("direction." + string(d))
+}
+
+// pickDirection returns the direction of the next card from the user's preference
+func pickDirection(preference domain.ReviewDirection) domain.ReviewDirection {
+	return preference.Pick(func() bool { return rand.Intn(2) == 0 })
+}
+
+// handleDirection shows the review direction menu (/direction)
+func (h *Handler) handleDirection(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	if !h.requireAuth(c) {
+		return nil
+	}
+
+	tr := h.tr(c)
+	direction, err := h.settingsService.ReviewDirection(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get review direction", zap.Error(err))
+		return c.Send(tr.T("error.generic"))
+	}
+
+	text, markup := directionMenu(tr, direction)
+	return c.Send(text, markup)
+}
+
+// handleDirectionCallback stores the picked direction (dir_*)
+func (h *Handler) handleDirectionCallback(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	direction, ok := domain.ParseReviewDirection(strings.TrimPrefix(strings.TrimSpace(data), "dir_"))
+	if !ok {
+		h.logger.Warn("Unknown review direction", zap.String("data", data))
+		return nil
+	}
+
+	settings, err := h.settingsService.Set(ctx, userID, domain.SettingDirection, string(direction))
+	if err != nil {
+		h.logger.Error("Failed to set review direction", zap.Error(err), zap.Int64("user_id", userID))
+		return nil // Callback уже подтверждён
+	}
+
+	text, markup := directionMenu(localizer(c, settings), direction)
+	if err := c.Edit(text, markup); err != nil {
+		h.handleEditError(err, c, userID)
+	}
+	return nil
+}
+
+// directionMenu renders direction options, marking the current one
+func directionMenu(tr *i18n.Localizer, current domain.ReviewDirection) (string, *tele.ReplyMarkup) {
+	markup := &tele.ReplyMarkup{}
+
+	rows := make([]tele.Row, 0, len(domain.ReviewDirections)+1)
+	for _, d := range domain.ReviewDirections {
+		label := directionLabel(tr, d)
+		if d == current {
+			label = "✅ " + label
+		}
+		rows = append(rows, markup.Row(markup.Data(label, "dir_"+string(d))))
+	}
+	rows = append(rows, markup.Row(localBtn(tr, btnMainMenu)))
+	markup.Inline(rows...)
+
+	return tr.T("direction.prompt"), markup
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"testing"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatPair(t *testing.T) {
+	word := &domain.Word{Word: "a<b", Translation: "привет"}
+
+	assert.Equal(t, "📝 a&lt;b\n<tg-spoiler>🔄 привет</tg-spoiler>", formatPair(word, domain.DirectionForward))
+	assert.Equal(t, "🔄 привет\n<tg-spoiler>📝 a&lt;b</tg-spoiler>", formatPair(word, domain.DirectionReverse))
+}
+
+func TestDirectionMenu(t *testing.T) {
+	_, markup := directionMenu(i18n.For(i18n.Russian), domain.DirectionReverse)
+
+	require.Len(t, markup.InlineKeyboard, len(domain.ReviewDirections)+1)
+	assert.Equal(t, "dir_forward", markup.InlineKeyboard[0][0].Unique)
+	assert.Equal(t, "📝 Слово → перевод", markup.InlineKeyboard[0][0].Text)
+	assert.Equal(t, "✅ 🔄 Перевод → слово", markup.InlineKeyboard[1][0].Text)
+	assert.Equal(t, "dir_mixed", markup.InlineKeyboard[2][0].Unique)
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+	"languager/internal/service"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+// groupReady checks that the bot works in the group chat. A group is enabled
+// by the first command of an authorized user; other members may use it after that.
+func (h *Handler) groupReady(c tele.Context, tr *i18n.Localizer) bool {
+	ctx := middleware.Ctx(c)
+	chat := c.Chat()
+
+	enabled, err := h.groupService.IsEnabled(ctx, chat.ID)
+	if err != nil {
+		h.logger.Error("Failed to check group", zap.Error(err), zap.Int64("chat_id", chat.ID))
+		_ = c.Send(tr.T("error.generic"))
+		return false
+	}
+	if enabled {
+		return true
+	}
+
+	userID := c.Sender().ID
+	authorized, err := h.authService.IsAuthorized(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to check authorization", zap.Error(err), zap.Int64("user_id", userID))
+		_ = c.Send(tr.T("error.generic"))
+		return false
+	}
+	if !authorized {
+		_ = c.Send(tr.T("group.not_enabled"))
+		return false
+	}
+
+	if err := h.groupService.Enable(ctx, chat.ID, chat.Title, userID); err != nil {
+		h.logger.Error("Failed to enable group", zap.Error(err), zap.Int64("chat_id", chat.ID))
+		_ = c.Send(tr.T("error.generic"))
+		return false
+	}
+	h.logger.Info("Group enabled", zap.Int64("chat_id", chat.ID), zap.Int64("user_id", userID))
+	_ = c.Send(tr.T("group.enabled"))
+	return true
+}
+
+// handleGroupAdd adds a word to the group's vocabulary (/add word - translation)
+func (h *Handler) handleGroupAdd(c tele.Context) error {
+	tr := h.tr(c)
+	if !h.groupReady(c, tr) {
+		return nil
+	}
+
+	chatID := c.Chat().ID
+	word, err := h.groupService.AddWord(middleware.Ctx(c), chatID, c.Sender().ID, c.Message().Payload)
+	switch {
+	case errors.Is(err, service.ErrInvalidGroupWord):
+		return c.Reply(tr.T("group.add_usage", service.MaxGroupWordLength))
+	case errors.Is(err, service.ErrGroupWordExists):
+		return c.Reply(tr.T("group.add_exists"))
+	case err != nil:
+		h.logger.Error("Failed to add group word", zap.Error(err), zap.Int64("chat_id", chatID))
+		return c.Reply(tr.T("error.generic"))
+	}
+
+	h.logger.Info("Group word added",
+		zap.Int64("chat_id", chatID),
+		zap.Int64("user_id", c.Sender().ID),
+		zap.Int("word_id", word.ID),
+	)
+	return c.Reply(tr.T("group.added", word.Word, word.Translation))
+}
+
+// handleGroupQuiz posts a question about a random group word (/quiz)
+func (h *Handler) handleGroupQuiz(c tele.Context) error {
+	tr := h.tr(c)
+	if !h.groupReady(c, tr) {
+		return nil
+	}
+
+	chatID := c.Chat().ID
+	quiz, err := h.groupService.NewQuiz(middleware.Ctx(c), chatID)
+	if errors.Is(err, service.ErrNotEnoughGroupWords) {
+		return c.Send(tr.T("group.quiz_empty"))
+	}
+	if err != nil {
+		h.logger.Error("Failed to create group quiz", zap.Error(err), zap.Int64("chat_id", chatID))
+		return c.Send(tr.T("error.generic"))
+	}
+
+	text, markup := groupQuizCard(tr, quiz)
+	return c.Send(text, markup)
+}
+
+// handleGroupAnswer records a member's answer to a quiz (gq_<quiz id>_<word id>).
+// The answer is reported in the callback response, so it is sent after grading.
+func (h *Handler) handleGroupAnswer(c tele.Context, data string) error {
+	tr := h.tr(c)
+	userID := c.Sender().ID
+
+	quizID, optionID, ok := parseGroupAnswer(data)
+	if !ok || !middleware.IsGroup(c.Chat()) {
+		h.logger.Warn("Invalid group answer", zap.String("data", data))
+		return c.Respond()
+	}
+
+	chatID := c.Chat().ID
+	outcome, quiz, err := h.groupService.Answer(middleware.Ctx(c), chatID, quizID, userID, memberName(c.Sender()), optionID)
+	if errors.Is(err, service.ErrQuizNotFound) {
+		return c.Respond(&tele.CallbackResponse{Text: tr.T("group.quiz_gone")})
+	}
+	if err != nil {
+		h.logger.Error("Failed to record group answer", zap.Error(err), zap.Int64("chat_id", chatID), zap.Int("quiz_id", quizID))
+		return c.Respond(&tele.CallbackResponse{Text: tr.T("error.generic")})
+	}
+
+	response := &tele.CallbackResponse{}
+	switch outcome {
+	case service.QuizWrong:
+		response.Text = tr.T("group.answer_wrong")
+	case service.QuizRepeated:
+		response.Text = tr.T("group.answer_repeated")
+	case service.QuizTaken:
+		response.Text = tr.T("group.answer_taken")
+	case service.QuizWon:
+		response.Text = tr.T("group.answer_won")
+	}
+	if err := c.Respond(response); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	if outcome != service.QuizWon {
+		return nil
+	}
+
+	h.logger.Info("Group quiz won", zap.Int64("chat_id", chatID), zap.Int("quiz_id", quizID), zap.Int64("user_id", userID))
+	text := tr.T("group.quiz_solved", quiz.Word.Word, quiz.Word.Translation, memberName(c.Sender()))
+	if err := c.Edit(text); err != nil {
+		h.handleEditError(err, c, userID)
+	}
+	return nil
+}
+
+// handleGroupTop posts the group's leaderboard (/top)
+func (h *Handler) handleGroupTop(c tele.Context) error {
+	tr := h.tr(c)
+	if !h.groupReady(c, tr) {
+		return nil
+	}
+
+	chatID := c.Chat().ID
+	scores, err := h.groupService.Top(middleware.Ctx(c), chatID)
+	if err != nil {
+		h.logger.Error("Failed to get group leaderboard", zap.Error(err), zap.Int64("chat_id", chatID))
+		return c.Send(tr.T("error.generic"))
+	}
+	return c.Send(groupTopText(tr, scores))
+}
+
+// groupQuizCard asks for the translation of the quiz word, one button per option
+func groupQuizCard(tr *i18n.Localizer, quiz *domain.GroupQuiz) (string, *tele.ReplyMarkup) {
+	markup := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0, len(quiz.Options))
+	for _, o := range quiz.Options {
+		rows = append(rows, markup.Row(markup.Data(o.Translation, fmt.Sprintf("gq_%d_%d", quiz.ID, o.ID))))
+	}
+	markup.Inline(rows...)
+
+	return tr.T("group.quiz", quiz.Word.Word), markup
+}
+
+// groupTopText formats the leaderboard
+func groupTopText(tr *i18n.Localizer, scores []domain.GroupScore) string {
+	if len(scores) == 0 {
+		return tr.T("group.top_empty")
+	}
+
+	var b strings.Builder
+	b.WriteString(tr.T("group.top"))
+	for i, s := range scores {
+		b.WriteString("\n" + tr.T("group.top_line", i+1, s.Name, s.Points))
+	}
+	return b.String()
+}
+
+// parseGroupAnswer splits gq_<quiz id>_<word id>
+func parseGroupAnswer(data string) (quizID, wordID int, ok bool) {
+	quiz, word, found := strings.Cut(strings.TrimPrefix(strings.TrimSpace(data), "gq_"), "_")
+	if !found {
+		return 0, 0, false
+	}
+	quizID, err := strconv.Atoi(quiz)
+	if err != nil {
+		return 0, 0, false
+	}
+	wordID, err = strconv.Atoi(word)
+	if err != nil {
+		return 0, 0, false
+	}
+	return quizID, wordID, true
+}
+
+// memberName is how a group member appears in quiz results and the leaderboard
+func memberName(u *tele.User) string {
+	name := strings.TrimSpace(u.FirstName + " " + u.LastName)
+	if name == "" {
+		name = u.Username
+	}
+	return name
+}
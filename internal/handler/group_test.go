@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"testing"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+func TestParseGroupAnswer(t *testing.T) {
+	tests := []struct {
+		data   string
+		quizID int
+		wordID int
+		ok     bool
+	}{
+		{"gq_3_7", 3, 7, true},
+		{" gq_12_345 ", 12, 345, true},
+		{"gq_3", 0, 0, false},
+		{"gq_a_7", 0, 0, false},
+		{"gq_3_b", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		quizID, wordID, ok := parseGroupAnswer(tt.data)
+		assert.Equal(t, tt.quizID, quizID, tt.data)
+		assert.Equal(t, tt.wordID, wordID, tt.data)
+		assert.Equal(t, tt.ok, ok, tt.data)
+	}
+}
+
+func TestGroupQuizCard(t *testing.T) {
+	quiz := &domain.GroupQuiz{
+		ID:   3,
+		Word: domain.GroupWord{ID: 7, Word: "apple", Translation: "яблоко"},
+		Options: []domain.GroupWord{
+			{ID: 8, Translation: "груша"},
+			{ID: 7, Translation: "яблоко"},
+		},
+	}
+
+	text, markup := groupQuizCard(i18n.For(i18n.English), quiz)
+
+	assert.Contains(t, text, "apple")
+	require.Len(t, markup.InlineKeyboard, 2)
+	assert.Equal(t, "груша", markup.InlineKeyboard[0][0].Text)
+	assert.Equal(t, "gq_3_8", markup.InlineKeyboard[0][0].Unique)
+	assert.Equal(t, "gq_3_7", markup.InlineKeyboard[1][0].Unique)
+}
+
+func TestGroupTopText(t *testing.T) {
+	tr := i18n.For(i18n.English)
+
+	assert.Equal(t, tr.T("group.top_empty"), groupTopText(tr, nil))
+
+	text := groupTopText(tr, []domain.GroupScore{
+		{UserID: 1, Name: "Alice", Points: 5},
+		{UserID: 2, Name: "Bob", Points: 2},
+	})
+	assert.Contains(t, text, "1. Alice — 5")
+	assert.Contains(t, text, "2. Bob — 2")
+}
+
+func TestMemberName(t *testing.T) {
+	assert.Equal(t, "Alice Smith", memberName(&tele.User{FirstName: "Alice", LastName: "Smith", Username: "alice"}))
+	assert.Equal(t, "Alice", memberName(&tele.User{FirstName: "Alice"}))
+	assert.Equal(t, "alice", memberName(&tele.User{Username: "alice"}))
+}
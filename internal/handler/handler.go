@@ -1,10 +1,17 @@
 package handler
 
 import (
+	"context"
+	"strings"
 	"sync"
+	"time"
 
 	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/metrics"
+	"languager/internal/middleware"
 	"languager/internal/service"
+	"languager/internal/shutdown"
 
 	"go.uber.org/zap"
 	tele "gopkg.in/telebot.v3"
@@ -17,37 +24,92 @@ type Handler struct {
 	wordService *service.WordService
 	logger      *zap.Logger
 
-	// User states (in-memory state machine)
-	states   map[int64]*domain.StateData
-	stateMux sync.RWMutex
+	reminderService *service.ReminderService
+	streakService   *service.StreakService
+	statsService    *service.StatsService
+	reportService   *service.ReportService
+	settingsService *service.SettingsService
+
+	// Translation suggestions for new words
+	dictionaryService *service.DictionaryService
+	// Word search for inline queries
+	searchService *service.SearchService
+	// Shared decks of words
+	deckService *service.DeckService
+	// Group chats' vocabularies and quizzes
+	groupService *service.GroupService
+	// Achievements listed by /achievements
+	achievementService *service.AchievementService
+	// Audit log and admins
+	auditService *service.AuditService
+
+	// User states (state machine, persisted on shutdown)
+	stateService *service.StateService
+
+	// Deadline for handling a single update
+	requestTimeout time.Duration
 
 	// Callback processing locks per user (prevents race conditions)
 	callbackLocks map[int64]*sync.Mutex
 	callbackMux   sync.RWMutex
 }
 
+// Services are the services the handler works with
+type Services struct {
+	Auth        *service.AuthService
+	Word        *service.WordService
+	State       *service.StateService
+	Reminder    *service.ReminderService
+	Streak      *service.StreakService
+	Stats       *service.StatsService
+	Report      *service.ReportService
+	Settings    *service.SettingsService
+	Dictionary  *service.DictionaryService
+	Search      *service.SearchService
+	Deck        *service.DeckService
+	Group       *service.GroupService
+	Achievement *service.AchievementService
+	Audit       *service.AuditService
+}
+
 // NewHandler creates a new handler instance
-func NewHandler(
-	bot *tele.Bot,
-	authService *service.AuthService,
-	wordService *service.WordService,
-	logger *zap.Logger,
-) *Handler {
+func NewHandler(bot *tele.Bot, services Services, requestTimeout time.Duration, logger *zap.Logger) *Handler {
 	return &Handler{
-		bot:           bot,
-		authService:   authService,
-		wordService:   wordService,
-		logger:        logger,
-		states:        make(map[int64]*domain.StateData),
-		callbackLocks: make(map[int64]*sync.Mutex),
+		bot:                bot,
+		authService:        services.Auth,
+		wordService:        services.Word,
+		stateService:       services.State,
+		reminderService:    services.Reminder,
+		streakService:      services.Streak,
+		statsService:       services.Stats,
+		reportService:      services.Report,
+		settingsService:    services.Settings,
+		logger:             logger,
+		dictionaryService:  services.Dictionary,
+		searchService:      services.Search,
+		deckService:        services.Deck,
+		groupService:       services.Group,
+		achievementService: services.Achievement,
+		auditService:       services.Audit,
+		requestTimeout:     requestTimeout,
+		callbackLocks:      make(map[int64]*sync.Mutex),
 	}
 }
 
-// RegisterHandlers registers all bot handlers
-func (h *Handler) RegisterHandlers() {
+// RegisterHandlers registers all bot handlers.
+// Contexts of in-flight updates are cancelled together with ctx,
+// and every handler is tracked by the shutdown coordinator.
+func (h *Handler) RegisterHandlers(ctx context.Context, coordinator *shutdown.Coordinator) {
+	h.bot.Use(middleware.InFlight(coordinator))
+
+	// Every update gets its own deadline for DB queries
+	h.bot.Use(middleware.RequestContext(ctx, h.requestTimeout))
+
 	// Add middleware to log ALL updates
 	h.bot.Use(func(next tele.HandlerFunc) tele.HandlerFunc {
 		return func(c tele.Context) error {
+			metrics.Updates.WithLabelValues(updateType(c.Update())).Inc()
+
 			callback := c.Callback()
 			if callback != nil {
 				h.logger.Info("UPDATE: CallbackQuery received",
@@ -70,33 +132,89 @@ func (h *Handler) RegisterHandlers() {
 		}
 	})
 
-	// Commands
-	h.bot.Handle("/start", h.handleStart)
+	// Commands of private chats; their state and words are the sender's,
+	// so they ignore groups
+	private := middleware.PrivateOnly()
+	h.bot.Handle("/start", h.handleStart, private)
+	h.bot.Handle("/reminders", h.handleReminders, private)
+	h.bot.Handle("/goal", h.handleGoal, private)
+	h.bot.Handle("/stats", h.handleStats, private)
+	h.bot.Handle("/report", h.handleReport, private)
+	h.bot.Handle("/direction", h.handleDirection, private)
+	h.bot.Handle("/settings", h.handleSettings, private)
+	h.bot.Handle("/decks", h.handleDecks, private)
+	h.bot.Handle("/achievements", h.handleAchievements, private)
+	h.bot.Handle("/audit", h.handleAudit, private)
+	h.bot.Handle("/revoke", h.handleRevoke, private)
+
+	// Group chat commands, data of the group is kept apart from members' words
+	group := middleware.GroupOnly()
+	h.bot.Handle("/add", h.handleGroupAdd, group)
+	h.bot.Handle("/quiz", h.handleGroupQuiz, group)
+	h.bot.Handle("/top", h.handleGroupTop, group)
 
 	// Text messages
-	h.bot.Handle(tele.OnText, h.handleText)
+	h.bot.Handle(tele.OnText, h.handleText, private)
+
+	// Inline queries: @bot <text> in any chat
+	h.bot.Handle(tele.OnQuery, h.handleInlineQuery)
 
 	// Generic callback handler for ALL callbacks
 	h.bot.Handle(tele.OnCallback, h.handleCallback)
 }
 
+// updateType classifies an update for metrics
+func updateType(u tele.Update) string {
+	switch {
+	case u.Callback != nil:
+		return "callback"
+	case u.Message != nil && strings.HasPrefix(u.Message.Text, "/"):
+		return "command"
+	case u.Message != nil && u.Message.Text != "":
+		return "text"
+	case u.Message != nil:
+		return "message"
+	case u.Query != nil:
+		return "inline_query"
+	default:
+		return "other"
+	}
+}
+
 // GetState returns user's current state
 func (h *Handler) GetState(userID int64) *domain.StateData {
-	h.stateMux.RLock()
-	defer h.stateMux.RUnlock()
-
-	state, exists := h.states[userID]
-	if !exists {
-		return &domain.StateData{State: domain.StateIdle}
-	}
-	return state
+	return h.stateService.Get(userID)
 }
 
 // SetState sets user's state
 func (h *Handler) SetState(userID int64, state *domain.StateData) {
-	h.stateMux.Lock()
-	defer h.stateMux.Unlock()
-	h.states[userID] = state
+	h.stateService.Set(userID, state)
+}
+
+// requireAuth checks that the sender is authorized and asks for the password otherwise
+func (h *Handler) requireAuth(c tele.Context) bool {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	if err := h.authService.EnsureUserExists(ctx, userID); err != nil {
+		h.logger.Error("Failed to ensure user exists", zap.Error(err))
+		_ = c.Send(h.tr(c).T("error.generic"))
+		return false
+	}
+
+	authorized, err := h.authService.IsAuthorized(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to check authorization", zap.Error(err))
+		_ = c.Send(h.tr(c).T("error.generic"))
+		return false
+	}
+
+	if !authorized {
+		h.ResetState(userID)
+		_ = c.Send(h.tr(c).T("auth.prompt"))
+		return false
+	}
+	return true
 }
 
 // ResetState resets user to idle state
@@ -104,45 +222,77 @@ func (h *Handler) ResetState(userID int64) {
 	h.SetState(userID, &domain.StateData{State: domain.StateIdle})
 }
 
-// Inline keyboard buttons
+// tr returns the localizer of the update's sender
+func (h *Handler) tr(c tele.Context) *i18n.Localizer {
+	return localizer(c, h.userSettings(middleware.Ctx(c), c.Sender().ID))
+}
+
+// localizer returns the localizer of the update's sender with known settings
+func localizer(c tele.Context, settings domain.Settings) *i18n.Localizer {
+	return i18n.For(i18n.Resolve(settings.Language, c.Sender().LanguageCode, settings.ClientLang))
+}
+
+// userLocalizer returns the localizer of a user outside of an update
+func (h *Handler) userLocalizer(ctx context.Context, userID int64) *i18n.Localizer {
+	settings := h.userSettings(ctx, userID)
+	return i18n.For(i18n.Resolve(settings.Language, "", settings.ClientLang))
+}
+
+// localBtn translates a button whose Text is a catalogue key
+func localBtn(tr *i18n.Localizer, btn tele.Btn) tele.Btn {
+	btn.Text = tr.T(btn.Text)
+	return btn
+}
+
+// Inline keyboard buttons. Texts are catalogue keys, see localBtn.
 var (
 	btnViewDays = tele.Btn{
 		Unique: "view_days",
-		Text:   "📅 Посмотреть дни",
+		Text:   "btn.view_days",
 	}
 	btnRandomPair = tele.Btn{
 		Unique: "random_pair",
-		Text:   "🎲 Случайная пара",
+		Text:   "btn.random_pair",
 	}
 	btnCancel = tele.Btn{
 		Unique: "cancel",
-		Text:   "❌ Отменить",
+		Text:   "btn.cancel",
 	}
 	btnMore = tele.Btn{
 		Unique: "more",
-		Text:   "🔄 Ещё",
+		Text:   "btn.more",
 	}
 	btnBack = tele.Btn{
 		Unique: "back",
-		Text:   "🏠 Назад",
+		Text:   "btn.back",
 	}
 	btnBackToDays = tele.Btn{
 		Unique: "back_to_days",
-		Text:   "◀️ К дням",
+		Text:   "btn.back_to_days",
 	}
 	btnMainMenu = tele.Btn{
 		Unique: "main_menu",
-		Text:   "🏠 Главное меню",
+		Text:   "btn.main_menu",
+	}
+	btnStats = tele.Btn{
+		Unique: "stats",
+		Text:   "btn.stats",
 	}
 )
 
 // mainMenuMarkup returns the main menu keyboard
-func mainMenuMarkup() *tele.ReplyMarkup {
+func mainMenuMarkup(tr *i18n.Localizer) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
 	menu.Inline(
-		menu.Row(btnViewDays),
-		menu.Row(btnRandomPair),
+		menu.Row(localBtn(tr, btnViewDays)),
+		menu.Row(localBtn(tr, btnRandomPair)),
+		menu.Row(localBtn(tr, btnSession)),
+		menu.Row(localBtn(tr, btnCloze)),
+		menu.Row(localBtn(tr, btnStats)),
+		menu.Row(localBtn(tr, btnPairs)),
+		menu.Row(localBtn(tr, btnDecks)),
+		menu.Row(localBtn(tr, btnAchievements)),
+		menu.Row(localBtn(tr, btnSettings)),
 	)
 	return menu
 }
-
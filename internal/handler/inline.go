@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"strconv"
+
+	"languager/internal/domain"
+	"languager/internal/middleware"
+	"languager/internal/service"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+// inlineCacheTime is how long Telegram may reuse an answer, in seconds;
+// matches the search cache so new words appear at the same time
+var inlineCacheTime = int(service.SearchCacheTTL.Seconds())
+
+// handleInlineQuery searches the sender's saved words (@bot <text> in any chat).
+// Unauthorized users get a button leading to the bot to log in.
+func (h *Handler) handleInlineQuery(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+	tr := h.tr(c)
+
+	authorized, err := h.authService.IsAuthorized(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to check authorization", zap.Error(err), zap.Int64("user_id", userID))
+		return nil
+	}
+	if !authorized {
+		return c.Answer(&tele.QueryResponse{
+			Results:           tele.Results{},
+			IsPersonal:        true,
+			SwitchPMText:      tr.T("inline.login"),
+			SwitchPMParameter: "inline",
+		})
+	}
+
+	words, err := h.searchService.Search(ctx, userID, c.Query().Text)
+	if err != nil {
+		h.logger.Error("Failed to search words", zap.Error(err), zap.Int64("user_id", userID))
+		return nil
+	}
+
+	response := &tele.QueryResponse{
+		Results:    inlineResults(words),
+		CacheTime:  inlineCacheTime,
+		IsPersonal: true,
+	}
+	if len(words) == 0 {
+		response.SwitchPMText = tr.T("inline.not_found")
+		response.SwitchPMParameter = "inline"
+	}
+	return c.Answer(response)
+}
+
+// inlineResults turns words into articles sending "word — translation"
+func inlineResults(words []domain.Word) tele.Results {
+	results := make(tele.Results, 0, len(words))
+	for _, w := range words {
+		text := w.Word + " — " + w.Translation
+		result := &tele.ArticleResult{Title: text, Text: text}
+		result.SetResultID(strconv.Itoa(w.ID))
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"testing"
+
+	"languager/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	tele "gopkg.in/telebot.v3"
+)
+
+func TestInlineResults(t *testing.T) {
+	results := inlineResults([]domain.Word{
+		{ID: 7, Word: "apple", Translation: "яблоко"},
+		{ID: 8, Word: "pineapple", Translation: "ананас"},
+	})
+
+	assert.Len(t, results, 2)
+	article := results[0].(*tele.ArticleResult)
+	assert.Equal(t, "7", article.ResultID())
+	assert.Equal(t, "apple — яблоко", article.Title)
+	assert.Equal(t, "apple — яблоко", article.Text)
+
+	assert.NotNil(t, inlineResults(nil), "Telegram rejects null results")
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"strings"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+var btnPairs = tele.Btn{
+	Unique: "pairs",
+	Text:   "btn.pairs",
+}
+
+// handlePairsMenu shows user's language pairs with the active one marked
+func (h *Handler) handlePairsMenu(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	settings := h.userSettings(ctx, userID)
+	pairs, err := h.wordService.GetLanguagePairs(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get language pairs", zap.Error(err), zap.Int64("user_id", userID))
+		return nil // Callback уже подтверждён
+	}
+
+	text, markup := pairsMenu(localizer(c, settings), pairs, settings.Pair)
+	if err := c.Edit(text, markup); err != nil {
+		h.handleEditError(err, c, userID)
+	}
+	return nil
+}
+
+// handlePairCallback handles pair buttons: pair_set_<src>-<tgt> activates a pair,
+// pair_new and pair_src_<src> walk through creating a new one
+func (h *Handler) handlePairCallback(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+	action := strings.TrimPrefix(strings.TrimSpace(data), "pair_")
+
+	switch {
+	case strings.HasPrefix(action, "set_"):
+		// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+
+		value := strings.TrimPrefix(action, "set_")
+		settings, err := h.settingsService.Set(ctx, userID, domain.SettingPair, value)
+		if err != nil {
+			h.logger.Warn("Failed to set language pair", zap.Error(err), zap.String("data", data))
+			return nil // Callback уже подтверждён
+		}
+		h.logger.Info("Language pair switched", zap.Int64("user_id", userID), zap.String("pair", value))
+
+		pairs, err := h.wordService.GetLanguagePairs(ctx, userID)
+		if err != nil {
+			h.logger.Error("Failed to get language pairs", zap.Error(err), zap.Int64("user_id", userID))
+			return nil // Callback уже подтверждён
+		}
+
+		text, markup := pairsMenu(localizer(c, settings), pairs, settings.Pair)
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+
+	case action == "new":
+		// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+
+		text, markup := sourceLanguageMenu(h.tr(c))
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+
+	case strings.HasPrefix(action, "src_"):
+		// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+
+		source := strings.TrimPrefix(action, "src_")
+		if !domain.IsStudyLanguage(source) {
+			h.logger.Warn("Unknown source language", zap.String("data", data))
+			return nil // Callback уже подтверждён
+		}
+
+		text, markup := targetLanguageMenu(h.tr(c), source)
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+	}
+
+	h.logger.Warn("Unknown pair action", zap.String("data", data))
+	return c.Respond()
+}
+
+// pairsMenu lists pairs the user has words in plus the active one
+func pairsMenu(tr *i18n.Localizer, pairs []domain.LanguagePair, active domain.LanguagePair) (string, *tele.ReplyMarkup) {
+	markup := &tele.ReplyMarkup{}
+
+	// A freshly created pair has no words yet but must still be listed
+	found := false
+	for _, p := range pairs {
+		if p == active {
+			found = true
+			break
+		}
+	}
+	if !found {
+		pairs = append([]domain.LanguagePair{active}, pairs...)
+	}
+
+	rows := make([]tele.Row, 0, len(pairs)+2)
+	for _, p := range pairs {
+		label := p.Label()
+		if p == active {
+			label = "✅ " + label
+		}
+		rows = append(rows, markup.Row(markup.Data(label, "pair_set_"+p.String())))
+	}
+	rows = append(rows,
+		markup.Row(markup.Data(tr.T("btn.pair_new"), "pair_new")),
+		markup.Row(localBtn(tr, btnMainMenu)),
+	)
+	markup.Inline(rows...)
+
+	return tr.T("pair.prompt"), markup
+}
+
+// sourceLanguageMenu asks for the language of words of a new pair
+func sourceLanguageMenu(tr *i18n.Localizer) (string, *tele.ReplyMarkup) {
+	markup := &tele.ReplyMarkup{}
+
+	buttons := make([]tele.Btn, 0, len(domain.StudyLanguages))
+	for _, l := range domain.StudyLanguages {
+		buttons = append(buttons, markup.Data(domain.LanguageLabel(l.Code), "pair_src_"+l.Code))
+	}
+	rows := markup.Split(3, buttons)
+	rows = append(rows, markup.Row(localBtn(tr, btnPairs)))
+	markup.Inline(rows...)
+
+	return tr.T("pair.source_prompt"), markup
+}
+
+// targetLanguageMenu asks for the translation language of a new pair
+func targetLanguageMenu(tr *i18n.Localizer, source string) (string, *tele.ReplyMarkup) {
+	markup := &tele.ReplyMarkup{}
+
+	buttons := make([]tele.Btn, 0, len(domain.StudyLanguages)-1)
+	for _, l := range domain.StudyLanguages {
+		if l.Code == source {
+			continue
+		}
+		pair := domain.LanguagePair{Source: source, Target: l.Code}
+		buttons = append(buttons, markup.Data(domain.LanguageLabel(l.Code), "pair_set_"+pair.String()))
+	}
+	rows := markup.Split(3, buttons)
+	rows = append(rows, markup.Row(markup.Data(tr.T("btn.back"), "pair_new")))
+	markup.Inline(rows...)
+
+	return tr.T("pair.target_prompt", domain.LanguageLabel(source)), markup
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"testing"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPairsMenu(t *testing.T) {
+	german := domain.LanguagePair{Source: "de", Target: "ru"}
+	spanish := domain.LanguagePair{Source: "es", Target: "en"}
+
+	tests := []struct {
+		name     string
+		pairs    []domain.LanguagePair
+		active   domain.LanguagePair
+		expected []string
+	}{
+		{
+			name:     "active pair has words",
+			pairs:    []domain.LanguagePair{domain.DefaultLanguagePair, german},
+			active:   german,
+			expected: []string{"🇬🇧 EN → 🇷🇺 RU", "✅ 🇩🇪 DE → 🇷🇺 RU"},
+		},
+		{
+			name:     "new active pair goes first",
+			pairs:    []domain.LanguagePair{domain.DefaultLanguagePair},
+			active:   spanish,
+			expected: []string{"✅ 🇪🇸 ES → 🇬🇧 EN", "🇬🇧 EN → 🇷🇺 RU"},
+		},
+		{
+			name:     "no words yet",
+			active:   domain.DefaultLanguagePair,
+			expected: []string{"✅ 🇬🇧 EN → 🇷🇺 RU"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, markup := pairsMenu(i18n.For(i18n.Russian), tt.pairs, tt.active)
+
+			require.Len(t, markup.InlineKeyboard, len(tt.expected)+2)
+			for i, label := range tt.expected {
+				assert.Equal(t, label, markup.InlineKeyboard[i][0].Text)
+			}
+			assert.Equal(t, "pair_new", markup.InlineKeyboard[len(tt.expected)][0].Unique)
+		})
+	}
+}
+
+func TestTargetLanguageMenu(t *testing.T) {
+	text, markup := targetLanguageMenu(i18n.For(i18n.English), "de")
+
+	assert.Contains(t, text, "🇩🇪 DE")
+
+	var uniques []string
+	for _, row := range markup.InlineKeyboard[:len(markup.InlineKeyboard)-1] {
+		for _, btn := range row {
+			uniques = append(uniques, btn.Unique)
+		}
+	}
+	assert.Len(t, uniques, len(domain.StudyLanguages)-1)
+	assert.Contains(t, uniques, "pair_set_de-en")
+	assert.NotContains(t, uniques, "pair_set_de-de")
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+	"languager/internal/service"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+// Reminder menu weekdays, Monday first
+var reminderWeekdays = []time.Weekday{
+	time.Monday,
+	time.Tuesday,
+	time.Wednesday,
+	time.Thursday,
+	time.Friday,
+	time.Saturday,
+	time.Sunday,
+}
+
+// btnStartReview opens a random pair from a reminder
+var btnStartReview = tele.Btn{
+	Unique: "random_pair",
+	Text:   "btn.start_review",
+}
+
+// SendReminder implements service.ReminderSender
+func (h *Handler) SendReminder(ctx context.Context, userID int64, dueWords int) error {
+	tr := h.userLocalizer(ctx, userID)
+	text := tr.T("reminder.due", tr.N("n.words", dueWords))
+
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(localBtn(tr, btnStartReview)))
+
+	_, err := h.bot.Send(tele.ChatID(userID), text, markup)
+	return recipientError(err)
+}
+
+// recipientError wraps errors meaning the user can't receive messages anymore
+// with service.ErrRecipientUnavailable
+func recipientError(err error) error {
+	if errors.Is(err, tele.ErrBlockedByUser) ||
+		errors.Is(err, tele.ErrUserIsDeactivated) ||
+		errors.Is(err, tele.ErrChatNotFound) {
+		return fmt.Errorf("%w: %v", service.ErrRecipientUnavailable, err)
+	}
+	return err
+}
+
+// handleReminders shows reminder settings (/reminders)
+func (h *Handler) handleReminders(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	if !h.requireAuth(c) {
+		return nil
+	}
+
+	tr := h.tr(c)
+	settings, err := h.reminderService.GetSettings(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get reminder settings", zap.Error(err))
+		return c.Send(tr.T("error.generic"))
+	}
+	loc, err := h.reminderService.UserLocation(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get user time zone", zap.Error(err))
+		return c.Send(tr.T("error.generic"))
+	}
+
+	h.ResetState(userID)
+	text, markup := h.reminderMenu(tr, settings, loc)
+	return c.Send(text, markup)
+}
+
+// handleReminderCallback handles buttons of the reminder menu (rem_*)
+func (h *Handler) handleReminderCallback(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if c.Callback() != nil {
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+	}
+
+	tr := h.tr(c)
+	action := strings.TrimPrefix(strings.TrimSpace(data), "rem_")
+
+	var settings *domain.ReminderSettings
+	var err error
+
+	switch {
+	case action == "add":
+		// Wait for the time as a text message
+		h.SetState(userID, &domain.StateData{State: domain.StateWaitingReminderTime})
+
+		markup := &tele.ReplyMarkup{}
+		markup.Inline(markup.Row(localBtn(tr, btnCancel)))
+		if err := c.Edit(tr.T("reminder.ask_time"), markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+
+	case action == "pause":
+		settings, err = h.reminderService.TogglePause(ctx, userID)
+
+	case strings.HasPrefix(action, "del_"):
+		minutes, convErr := strconv.Atoi(strings.TrimPrefix(action, "del_"))
+		if convErr != nil {
+			h.logger.Error("Failed to parse reminder time", zap.Error(convErr), zap.String("data", data))
+			return nil // Callback уже подтверждён
+		}
+		settings, err = h.reminderService.RemoveTime(ctx, userID, minutes)
+
+	case strings.HasPrefix(action, "day_"):
+		day, convErr := strconv.Atoi(strings.TrimPrefix(action, "day_"))
+		if convErr != nil || day < 0 || day > 6 {
+			h.logger.Error("Failed to parse weekday", zap.String("data", data))
+			return nil // Callback уже подтверждён
+		}
+		settings, err = h.reminderService.ToggleDay(ctx, userID, time.Weekday(day))
+
+	default:
+		h.logger.Warn("Unknown reminder action", zap.String("data", data))
+		return nil
+	}
+
+	if err != nil {
+		h.logger.Error("Failed to update reminder settings", zap.Error(err), zap.Int64("user_id", userID))
+		return nil // Callback уже подтверждён
+	}
+	loc, err := h.reminderService.UserLocation(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get user time zone", zap.Error(err), zap.Int64("user_id", userID))
+		return nil // Callback уже подтверждён
+	}
+
+	text, markup := h.reminderMenu(tr, settings, loc)
+	if err := c.Edit(text, markup); err != nil {
+		h.handleEditError(err, c, userID)
+	}
+	return nil
+}
+
+// handleReminderTimeInput saves a reminder time typed by the user
+func (h *Handler) handleReminderTimeInput(c tele.Context, text string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	tr := h.tr(c)
+	settings, err := h.reminderService.AddTime(ctx, userID, text)
+	switch {
+	case errors.Is(err, service.ErrInvalidReminderTime):
+		// Keep waiting for a valid time
+		markup := &tele.ReplyMarkup{}
+		markup.Inline(markup.Row(localBtn(tr, btnCancel)))
+		return c.Send(tr.T("reminder.bad_time"), markup)
+	case errors.Is(err, service.ErrTooManyReminderTimes):
+		h.ResetState(userID)
+		return c.Send(tr.T("reminder.too_many", service.MaxReminderTimes))
+	case err != nil:
+		h.logger.Error("Failed to add reminder time", zap.Error(err), zap.Int64("user_id", userID))
+		h.ResetState(userID)
+		return c.Send(tr.T("error.generic"))
+	}
+
+	h.ResetState(userID)
+	loc, err := h.reminderService.UserLocation(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get user time zone", zap.Error(err), zap.Int64("user_id", userID))
+		return c.Send(tr.T("error.generic"))
+	}
+	menuText, markup := h.reminderMenu(tr, settings, loc)
+	return c.Send(menuText, markup)
+}
+
+// reminderMenu renders reminder settings and their keyboard.
+// Times are shown in loc.
+func (h *Handler) reminderMenu(tr *i18n.Localizer, settings *domain.ReminderSettings, loc *time.Location) (string, *tele.ReplyMarkup) {
+	times := tr.T("reminder.no_times")
+	if len(settings.Times) > 0 {
+		clocks := make([]string, len(settings.Times))
+		for i, t := range settings.Times {
+			clocks[i] = domain.FormatClock(t)
+		}
+		times = strings.Join(clocks, ", ")
+	}
+
+	var days []string
+	for _, wd := range reminderWeekdays {
+		if settings.HasDay(wd) {
+			days = append(days, tr.Weekday(wd))
+		}
+	}
+	daysText := strings.Join(days, ", ")
+	switch len(days) {
+	case 0:
+		daysText = tr.T("reminder.no_days")
+	case len(reminderWeekdays):
+		daysText = tr.T("reminder.every_day")
+	}
+
+	status := tr.T("reminder.enabled")
+	if settings.Paused {
+		status = tr.T("reminder.paused")
+	}
+
+	text := tr.T("reminder.menu", times, daysText, loc, status)
+
+	markup := &tele.ReplyMarkup{}
+	rows := []tele.Row{}
+
+	if len(settings.Times) > 0 {
+		timeRow := tele.Row{}
+		for _, t := range settings.Times {
+			timeRow = append(timeRow, markup.Data("🗑 "+domain.FormatClock(t), fmt.Sprintf("rem_del_%d", t)))
+		}
+		rows = append(rows, timeRow)
+	}
+	if len(settings.Times) < service.MaxReminderTimes {
+		rows = append(rows, markup.Row(markup.Data(tr.T("reminder.add"), "rem_add")))
+	}
+
+	dayRow := tele.Row{}
+	for _, wd := range reminderWeekdays {
+		mark := "❌"
+		if settings.HasDay(wd) {
+			mark = "✅"
+		}
+		dayRow = append(dayRow, markup.Data(mark+tr.Weekday(wd), fmt.Sprintf("rem_day_%d", wd)))
+	}
+	rows = append(rows, dayRow)
+
+	pauseText := tr.T("reminder.pause")
+	if settings.Paused {
+		pauseText = tr.T("reminder.resume")
+	}
+	rows = append(rows, markup.Row(markup.Data(pauseText, "rem_pause")))
+	rows = append(rows, markup.Row(localBtn(tr, btnMainMenu)))
+
+	markup.Inline(rows...)
+	return text, markup
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderMenu(t *testing.T) {
+	h := &Handler{}
+
+	settings := &domain.ReminderSettings{
+		Times: []int{9 * 60, 20*60 + 30},
+		Days:  domain.AllWeekdays &^ (1 << uint(time.Sunday)),
+	}
+
+	text, markup := h.reminderMenu(i18n.For(i18n.Russian), settings, time.UTC)
+
+	assert.Contains(t, text, "Время: 09:00, 20:30")
+	assert.Contains(t, text, "Дни: Пн, Вт, Ср, Чт, Пт, Сб")
+	assert.Contains(t, text, "✅ включены")
+
+	// times, add, weekdays, pause, main menu
+	assert.Len(t, markup.InlineKeyboard, 5)
+	assert.Equal(t, "rem_del_540", markup.InlineKeyboard[0][0].Unique)
+	assert.Equal(t, "❌Вс", markup.InlineKeyboard[2][6].Text)
+	assert.Equal(t, "rem_day_0", markup.InlineKeyboard[2][6].Unique)
+
+	text, _ = h.reminderMenu(i18n.For(i18n.English), settings, time.UTC)
+	assert.Contains(t, text, "Days: Mo, Tu, We, Th, Fr, Sa")
+}
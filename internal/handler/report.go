@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+// masteredListLimit is how many mastered words a report lists by name
+const masteredListLimit = 10
+
+var (
+	btnReviewProblems = tele.Btn{
+		Unique: "review_problems",
+		Text:   "btn.review_problems",
+	}
+	btnMoreProblems = tele.Btn{
+		Unique: "review_problems",
+		Text:   "btn.more",
+	}
+)
+
+// SendWeeklyReport implements service.ReportSender
+func (h *Handler) SendWeeklyReport(ctx context.Context, userID int64, report *domain.WeeklyReport) error {
+	tr := h.userLocalizer(ctx, userID)
+	_, err := h.bot.Send(tele.ChatID(userID), formatWeeklyReport(tr, report), weeklyReportMarkup(tr, report), tele.ModeHTML)
+	return recipientError(err)
+}
+
+// handleReport shows weekly report settings (/report)
+func (h *Handler) handleReport(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	if !h.requireAuth(c) {
+		return nil
+	}
+
+	tr := h.tr(c)
+	subscribed, err := h.reportService.IsSubscribed(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get report subscription", zap.Error(err))
+		return c.Send(tr.T("error.generic"))
+	}
+
+	text, markup := reportMenu(tr, subscribed)
+	return c.Send(text, markup)
+}
+
+// handleReportCallback handles buttons of the report menu (report_*)
+func (h *Handler) handleReportCallback(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	tr := h.tr(c)
+	switch action := strings.TrimPrefix(strings.TrimSpace(data), "report_"); action {
+	case "toggle":
+		subscribed, err := h.reportService.ToggleSubscription(ctx, userID)
+		if err != nil {
+			h.logger.Error("Failed to toggle report subscription", zap.Error(err), zap.Int64("user_id", userID))
+			return nil // Callback уже подтверждён
+		}
+
+		text, markup := reportMenu(tr, subscribed)
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+
+	case "show":
+		report, err := h.reportService.LastWeekReport(ctx, userID)
+		if err != nil {
+			h.logger.Error("Failed to build weekly report", zap.Error(err), zap.Int64("user_id", userID))
+			return nil // Callback уже подтверждён
+		}
+		return c.Send(formatWeeklyReport(tr, report), weeklyReportMarkup(tr, report), tele.ModeHTML)
+
+	default:
+		h.logger.Warn("Unknown report action", zap.String("data", data))
+		return nil
+	}
+}
+
+// handleReviewProblems starts reviewing words the user failed last time
+func (h *Handler) handleReviewProblems(c tele.Context) error {
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ, до блокировки
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback immediately", zap.Error(err))
+	}
+
+	return h.showPair(c, reviewProblems)
+}
+
+// reportMenu renders weekly report settings and their keyboard
+func reportMenu(tr *i18n.Localizer, subscribed bool) (string, *tele.ReplyMarkup) {
+	text := tr.T("report.menu") + "\n\n"
+	toggle := tr.T("report.enable")
+	if subscribed {
+		text += tr.T("report.subscribed")
+		toggle = tr.T("report.disable")
+	} else {
+		text += tr.T("report.unsubscribed")
+	}
+
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(
+		markup.Row(markup.Data(toggle, "report_toggle")),
+		markup.Row(markup.Data(tr.T("report.show"), "report_show")),
+		markup.Row(localBtn(tr, btnMainMenu)),
+	)
+	return text, markup
+}
+
+// weeklyReportMarkup offers to review problem words if there are any
+func weeklyReportMarkup(tr *i18n.Localizer, report *domain.WeeklyReport) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{}
+	if len(report.Failing) > 0 {
+		markup.Inline(markup.Row(localBtn(tr, btnReviewProblems)), markup.Row(localBtn(tr, btnMainMenu)))
+	} else {
+		markup.Inline(markup.Row(localBtn(tr, btnMainMenu)))
+	}
+	return markup
+}
+
+// formatWeeklyReport renders a weekly report as an HTML message
+func formatWeeklyReport(tr *i18n.Localizer, report *domain.WeeklyReport) string {
+	var b strings.Builder
+
+	weekEnd := report.WeekStart.AddDate(0, 0, 6)
+	b.WriteString(tr.T("report.title", report.WeekStart.Format("02.01"), weekEnd.Format("02.01")) + "\n\n")
+
+	if report.Empty() {
+		b.WriteString(tr.T("report.empty") + "\n")
+	} else {
+		b.WriteString(tr.T("report.added", tr.N("n.words", report.WordsAdded)) + "\n")
+		b.WriteString(tr.T("review.accuracy", report.Reviews, report.Accuracy()) + "\n")
+	}
+
+	if n := len(report.Mastered); n > 0 {
+		words := make([]string, 0, masteredListLimit)
+		for i, w := range report.Mastered {
+			if i == masteredListLimit {
+				words = append(words, "…")
+				break
+			}
+			words = append(words, html.EscapeString(w.Word))
+		}
+		b.WriteString(tr.T("report.mastered", n, strings.Join(words, ", ")) + "\n")
+	}
+
+	if report.Streak > 0 {
+		b.WriteString(tr.T("report.streak", tr.N("n.days", report.Streak), report.BestStreak) + "\n")
+	}
+
+	if len(report.Failing) > 0 {
+		b.WriteString("\n" + tr.T("report.failing") + "\n")
+		writeHardWords(&b, tr, report.Failing)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
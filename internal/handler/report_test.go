@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+func TestFormatWeeklyReport(t *testing.T) {
+	report := &domain.WeeklyReport{
+		WeekStart:      time.Date(2024, 12, 9, 0, 0, 0, 0, time.UTC),
+		WordsAdded:     12,
+		Reviews:        40,
+		CorrectReviews: 30,
+		Mastered:       []domain.Word{{Word: "hello"}, {Word: "a<b"}},
+		Failing:        []domain.HardWord{{Word: "through", Translation: "через", Failures: 3, Reviews: 4}},
+		Streak:         4,
+		BestStreak:     9,
+	}
+
+	text := formatWeeklyReport(i18n.For(i18n.Russian), report)
+
+	assert.Contains(t, text, "Итоги недели 09.12–15.12")
+	assert.Contains(t, text, "Добавлено: 12 слов")
+	assert.Contains(t, text, "точность: 75%")
+	assert.Contains(t, text, "Освоено: 2 — hello, a&lt;b")
+	assert.Contains(t, text, "Серия: 4 дня · рекорд: 9")
+	assert.Contains(t, text, "1. through — через (❌ 3 из 4)")
+
+	text = formatWeeklyReport(i18n.For(i18n.English), report)
+
+	assert.Contains(t, text, "Added: 12 words")
+	assert.Contains(t, text, "Streak: 4 days · best: 9")
+}
+
+func TestFormatWeeklyReport_Empty(t *testing.T) {
+	report := &domain.WeeklyReport{WeekStart: time.Date(2024, 12, 9, 0, 0, 0, 0, time.UTC)}
+
+	text := formatWeeklyReport(i18n.For(i18n.Russian), report)
+
+	assert.Contains(t, text, "занятий не было")
+	assert.NotContains(t, text, "Серия")
+	assert.NotContains(t, text, "Не даются")
+}
+
+func TestWeeklyReportMarkup(t *testing.T) {
+	markup := weeklyReportMarkup(i18n.For(i18n.Russian), &domain.WeeklyReport{Failing: []domain.HardWord{{Word: "through"}}})
+	require.Len(t, markup.InlineKeyboard, 2)
+	assert.Equal(t, "review_problems", markup.InlineKeyboard[0][0].Unique)
+
+	markup = weeklyReportMarkup(i18n.For(i18n.Russian), &domain.WeeklyReport{})
+	require.Len(t, markup.InlineKeyboard, 1)
+	assert.Equal(t, "main_menu", markup.InlineKeyboard[0][0].Unique)
+}
+
+func TestReportMenu(t *testing.T) {
+	text, markup := reportMenu(i18n.For(i18n.Russian), true)
+	assert.Contains(t, text, "включено")
+	assert.Equal(t, "🔕 Выключить", markup.InlineKeyboard[0][0].Text)
+	assert.Equal(t, "report_toggle", markup.InlineKeyboard[0][0].Unique)
+
+	text, markup = reportMenu(i18n.For(i18n.Russian), false)
+	assert.Contains(t, text, "выключено")
+	assert.Equal(t, "🔔 Включить", markup.InlineKeyboard[0][0].Text)
+}
+
+func TestRecipientError(t *testing.T) {
+	assert.ErrorIs(t, recipientError(tele.ErrBlockedByUser), service.ErrRecipientUnavailable)
+	assert.ErrorIs(t, recipientError(tele.ErrChatNotFound), service.ErrRecipientUnavailable)
+
+	other := errors.New("network")
+	assert.Equal(t, other, recipientError(other))
+	assert.NoError(t, recipientError(nil))
+}
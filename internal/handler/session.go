@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+var btnSession = tele.Btn{
+	Unique: "session",
+	Text:   "btn.session",
+}
+
+// handleSessionMenu offers session sizes
+func (h *Handler) handleSessionMenu(c tele.Context) error {
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	text, markup := sessionMenu(h.tr(c))
+	if err := c.Edit(text, markup); err != nil {
+		h.handleEditError(err, c, c.Sender().ID)
+	}
+	return nil
+}
+
+// handleSessionCallback handles buttons of a review session (sess_*).
+// The session itself lives in the user's state; answer buttons carry only
+// the card position to ignore taps on outdated cards.
+func (h *Handler) handleSessionCallback(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+	action := strings.TrimPrefix(strings.TrimSpace(data), "sess_")
+
+	// Grades are answered after saving, to show mastery; the rest right away
+	grading := strings.HasPrefix(action, "ok_") || strings.HasPrefix(action, "fail_")
+	if !grading {
+		// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+	}
+
+	// Блокируем обработку для этого пользователя
+	lock := h.userLock(userID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	state := h.GetState(userID)
+	session := state.Session
+	if state.State != domain.StateReviewSession {
+		session = nil
+	}
+
+	switch {
+	case strings.HasPrefix(action, "size_"):
+		size, err := strconv.Atoi(strings.TrimPrefix(action, "size_"))
+		if err != nil {
+			h.logger.Error("Failed to parse session size", zap.Error(err), zap.String("data", data))
+			return nil // Callback уже подтверждён
+		}
+		session, err = h.wordService.StartSession(ctx, userID, h.userSettings(ctx, userID).Pair, size)
+		if err != nil {
+			h.logger.Error("Failed to start session", zap.Error(err), zap.Int64("user_id", userID))
+			return nil // Callback уже подтверждён
+		}
+		if session == nil {
+			tr := h.tr(c)
+			markup := &tele.ReplyMarkup{}
+			markup.Inline(markup.Row(localBtn(tr, btnMainMenu)))
+			if err := c.Edit(tr.T("session.empty"), markup); err != nil {
+				h.handleEditError(err, c, userID)
+			}
+			return nil
+		}
+
+	case action == "repeat":
+		if session != nil {
+			session = session.RepeatMissed()
+		}
+		if session == nil {
+			return h.handleSessionMenuEdit(c)
+		}
+
+	case action == "end":
+		if session == nil {
+			return h.handleSessionMenuEdit(c)
+		}
+		session.Finish()
+
+	case grading || strings.HasPrefix(action, "skip_"):
+		verb, posStr, _ := strings.Cut(action, "_")
+		pos, err := strconv.Atoi(posStr)
+		if err != nil {
+			h.logger.Error("Failed to parse session position", zap.Error(err), zap.String("data", data))
+			return c.Respond()
+		}
+		if session == nil || pos != session.Pos {
+			// Outdated card, e.g. a double tap
+			return c.Respond()
+		}
+
+		if verb == "skip" {
+			session.Skip()
+			break
+		}
+
+		wordID, _ := session.Current()
+		correct := verb == "ok"
+		mastered, err := h.wordService.GradeReview(ctx, userID, wordID, correct, session.Direction)
+		if err != nil {
+			h.logger.Error("Failed to record review", zap.Error(err), zap.Int("word_id", wordID))
+			return c.Respond()
+		}
+		session.Answer(correct)
+		defer h.trackActivity(c, domain.Activity{Reviews: 1})
+
+		response := &tele.CallbackResponse{}
+		if mastered {
+			response.Text = h.tr(c).T("review.mastered")
+		}
+		if err := c.Respond(response); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+
+	default:
+		h.logger.Warn("Unknown session action", zap.String("data", data))
+		return nil
+	}
+
+	h.SetState(userID, &domain.StateData{State: domain.StateReviewSession, Session: session})
+	return h.showSession(c, session)
+}
+
+// handleSessionMenuEdit shows the size menu when there is no session to continue
+func (h *Handler) handleSessionMenuEdit(c tele.Context) error {
+	text, markup := sessionMenu(h.tr(c))
+	if err := c.Edit(text, markup); err != nil {
+		h.handleEditError(err, c, c.Sender().ID)
+	}
+	return nil
+}
+
+// showSession shows the current card or the summary once the session is over
+func (h *Handler) showSession(c tele.Context, session *domain.ReviewSession) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+
+	var word *domain.Word
+	for word == nil && !session.Done() {
+		wordID, _ := session.Current()
+		words, err := h.wordService.GetWordsByIDs(ctx, userID, []int{wordID})
+		if err != nil {
+			h.logger.Error("Failed to get session word", zap.Error(err), zap.Int("word_id", wordID))
+			return nil // Callback уже подтверждён
+		}
+		if len(words) == 0 {
+			// Deleted since the session started
+			session.Discard()
+			continue
+		}
+		word = &words[0]
+	}
+
+	if word != nil {
+		session.Direction = pickDirection(settings.Direction)
+		text, markup := sessionCard(tr, session, word)
+		h.editHTML(c, text, markup)
+		return nil
+	}
+
+	missed, err := h.wordService.GetWordsByIDs(ctx, userID, session.Missed())
+	if err != nil {
+		h.logger.Error("Failed to get missed words", zap.Error(err), zap.Int64("user_id", userID))
+		return nil // Callback уже подтверждён
+	}
+
+	text, markup := sessionSummary(tr, session, missed)
+	h.editHTML(c, text, markup)
+	h.wordService.FinishSession(ctx, userID, session)
+	return nil
+}
+
+// editHTML edits the callback message as HTML
+func (h *Handler) editHTML(c tele.Context, text string, markup *tele.ReplyMarkup) {
+	opts := &tele.SendOptions{ParseMode: tele.ModeHTML, ReplyMarkup: markup}
+	if _, err := h.bot.Edit(c.Callback().Message, text, opts); err != nil {
+		h.handleEditError(err, c, c.Sender().ID)
+	}
+}
+
+// sessionMenu renders session size options
+func sessionMenu(tr *i18n.Localizer) (string, *tele.ReplyMarkup) {
+	markup := &tele.ReplyMarkup{}
+
+	sizes := make([]tele.Btn, 0, len(domain.SessionSizes))
+	for _, size := range domain.SessionSizes {
+		label := strconv.Itoa(size)
+		if size == 0 {
+			label = tr.T("session.all")
+		}
+		sizes = append(sizes, markup.Data(label, fmt.Sprintf("sess_size_%d", size)))
+	}
+
+	markup.Inline(markup.Row(sizes...), markup.Row(localBtn(tr, btnMainMenu)))
+	return tr.T("session.menu"), markup
+}
+
+// sessionCard renders the current word of a session with progress
+func sessionCard(tr *i18n.Localizer, session *domain.ReviewSession, word *domain.Word) (string, *tele.ReplyMarkup) {
+	text := tr.T("session.card", session.Pos+1, session.Total()) + "\n\n" + formatPair(word, session.Direction)
+
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(
+		markup.Row(
+			markup.Data(tr.T("btn.remember"), fmt.Sprintf("sess_ok_%d", session.Pos)),
+			markup.Data(tr.T("btn.forgot"), fmt.Sprintf("sess_fail_%d", session.Pos)),
+		),
+		markup.Row(markup.Data(tr.T("session.skip"), fmt.Sprintf("sess_skip_%d", session.Pos))),
+		markup.Row(markup.Data(tr.T("session.finish"), "sess_end")),
+	)
+	return text, markup
+}
+
+// sessionSummary renders session results and lists missed words
+func sessionSummary(tr *i18n.Localizer, session *domain.ReviewSession, missed []domain.Word) (string, *tele.ReplyMarkup) {
+	var b strings.Builder
+
+	b.WriteString(tr.T("session.finished") + "\n\n")
+	b.WriteString(tr.T("session.totals",
+		session.Total(), session.Correct, len(session.Failed), len(session.Skipped)) + "\n")
+
+	failed := make(map[int]bool, len(session.Failed))
+	for _, id := range session.Failed {
+		failed[id] = true
+	}
+
+	if len(missed) > 0 {
+		b.WriteString("\n" + tr.T("session.missed") + "\n")
+		for _, w := range missed {
+			mark := "⏭"
+			if failed[w.ID] {
+				mark = "❌"
+			}
+			fmt.Fprintf(&b, "%s %s — %s\n", mark, html.EscapeString(w.Word), html.EscapeString(w.Translation))
+		}
+	} else if session.Total() > 0 {
+		b.WriteString("\n" + tr.T("session.perfect") + "\n")
+	}
+
+	markup := &tele.ReplyMarkup{}
+	var rows []tele.Row
+	if len(missed) > 0 {
+		rows = append(rows, markup.Row(markup.Data(tr.T("session.repeat", len(missed)), "sess_repeat")))
+	}
+	rows = append(rows, markup.Row(localBtn(tr, btnSession)), markup.Row(localBtn(tr, btnMainMenu)))
+	markup.Inline(rows...)
+
+	return strings.TrimRight(b.String(), "\n"), markup
+}
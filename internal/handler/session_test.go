@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"testing"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionMenu(t *testing.T) {
+	_, markup := sessionMenu(i18n.For(i18n.Russian))
+
+	require.Len(t, markup.InlineKeyboard, 2)
+	sizes := markup.InlineKeyboard[0]
+	require.Len(t, sizes, len(domain.SessionSizes))
+	assert.Equal(t, "10", sizes[0].Text)
+	assert.Equal(t, "sess_size_10", sizes[0].Unique)
+	assert.Equal(t, "Все", sizes[3].Text)
+	assert.Equal(t, "sess_size_0", sizes[3].Unique)
+}
+
+func TestSessionCard(t *testing.T) {
+	session := domain.NewReviewSession([]int{1, 2, 3})
+	session.Answer(true)
+	session.Skip()
+
+	text, markup := sessionCard(i18n.For(i18n.Russian), session, &domain.Word{ID: 3, Word: "hello", Translation: "привет"})
+
+	assert.Contains(t, text, "3/3")
+	assert.Contains(t, text, "<tg-spoiler>")
+	assert.Equal(t, "sess_ok_2", markup.InlineKeyboard[0][0].Unique)
+	assert.Equal(t, "sess_fail_2", markup.InlineKeyboard[0][1].Unique)
+	assert.Equal(t, "sess_skip_2", markup.InlineKeyboard[1][0].Unique)
+	assert.Equal(t, "sess_end", markup.InlineKeyboard[2][0].Unique)
+}
+
+func TestSessionSummary(t *testing.T) {
+	session := domain.NewReviewSession([]int{1, 2, 3})
+	session.Answer(true)
+	session.Answer(false)
+	session.Skip()
+
+	missed := []domain.Word{
+		{ID: 2, Word: "a<b", Translation: "два"},
+		{ID: 3, Word: "three", Translation: "три"},
+	}
+	text, markup := sessionSummary(i18n.For(i18n.Russian), session, missed)
+
+	assert.Contains(t, text, "Слов: 3 · ✅ 1 · ❌ 1 · ⏭ 1")
+	assert.Contains(t, text, "❌ a&lt;b — два")
+	assert.Contains(t, text, "⏭ three — три")
+	require.Len(t, markup.InlineKeyboard, 3)
+	assert.Equal(t, "sess_repeat", markup.InlineKeyboard[0][0].Unique)
+	assert.Equal(t, "🔁 Повторить пропущенные (2)", markup.InlineKeyboard[0][0].Text)
+}
+
+func TestSessionSummary_Perfect(t *testing.T) {
+	session := domain.NewReviewSession([]int{1})
+	session.Answer(true)
+
+	text, markup := sessionSummary(i18n.For(i18n.Russian), session, nil)
+
+	assert.Contains(t, text, "Всё без ошибок")
+	require.Len(t, markup.InlineKeyboard, 2)
+	assert.Equal(t, "session", markup.InlineKeyboard[0][0].Unique)
+}
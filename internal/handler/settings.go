@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+var btnSettings = tele.Btn{
+	Unique: "settings",
+	Text:   "btn.settings",
+}
+
+// settingOption is one editable row of the /settings menu.
+// Its label and hint are the catalogue messages "settings.<key>"
+// and "settings.<key>.hint".
+type settingOption struct {
+	key     string
+	choices []string
+	perRow  int
+	format  func(tr *i18n.Localizer, value string) string
+}
+
+// settingOptions lists editable settings in menu order.
+// A new preference only needs a key in domain.Settings and a row here.
+var settingOptions = []settingOption{
+	{
+		key:     domain.SettingPageSize,
+		choices: []string{"5", "7", "10", "15"},
+		perRow:  4,
+		format:  formatPlainValue,
+	},
+	{
+		key:     domain.SettingHistoryDays,
+		choices: []string{"30", "60", "90", "180", "365"},
+		perRow:  3,
+		format:  formatDaysValue,
+	},
+	{
+		key:     domain.SettingHideDays,
+		choices: []string{"5", "7", "10", "14", "21", "60", "90", "180", "365"},
+		perRow:  3,
+		format:  formatDaysValue,
+	},
+	{
+		key: domain.SettingTimezone,
+		choices: []string{
+			"Europe/Kaliningrad", "Europe/Moscow", "Europe/Samara", "Asia/Yekaterinburg",
+			"Asia/Novosibirsk", "Asia/Vladivostok", "Europe/London", "Europe/Berlin",
+			"America/New_York", "UTC",
+		},
+		perRow: 2,
+		format: formatPlainValue,
+	},
+	{
+		key:     domain.SettingDirection,
+		choices: []string{string(domain.DirectionForward), string(domain.DirectionReverse), string(domain.DirectionMixed)},
+		perRow:  1,
+		format: func(tr *i18n.Localizer, v string) string {
+			return directionLabel(tr, domain.ReviewDirection(v))
+		},
+	},
+	{
+		key:     domain.SettingLanguage,
+		choices: []string{domain.LanguageAuto, string(i18n.Russian), string(i18n.English)},
+		perRow:  1,
+		format:  func(tr *i18n.Localizer, v string) string { return tr.T("language." + v) },
+	},
+	{
+		key:     domain.SettingAutoSwap,
+		choices: []string{"true", "false"},
+		perRow:  2,
+		format:  func(tr *i18n.Localizer, v string) string { return tr.T("switch." + v) },
+	},
+}
+
+func formatPlainValue(_ *i18n.Localizer, v string) string {
+	return v
+}
+
+func formatDaysValue(tr *i18n.Localizer, v string) string {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return v
+	}
+	return tr.N("n.days", n)
+}
+
+func findSettingOption(key string) (settingOption, bool) {
+	for _, opt := range settingOptions {
+		if opt.key == key {
+			return opt, true
+		}
+	}
+	return settingOption{}, false
+}
+
+// userSettings returns user's settings, falling back to defaults on errors
+func (h *Handler) userSettings(ctx context.Context, userID int64) domain.Settings {
+	settings, err := h.settingsService.Get(ctx, userID)
+	if err != nil {
+		h.logger.Warn("Failed to get user settings", zap.Error(err), zap.Int64("user_id", userID))
+		return h.settingsService.Defaults()
+	}
+	return settings
+}
+
+// handleSettings shows the settings menu (/settings or the menu button)
+func (h *Handler) handleSettings(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	if c.Callback() != nil {
+		// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+	} else if !h.requireAuth(c) {
+		return nil
+	}
+
+	settings, err := h.settingsService.Get(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get user settings", zap.Error(err))
+		if c.Callback() != nil {
+			return nil // Callback уже подтверждён
+		}
+		return c.Send(h.tr(c).T("error.generic"))
+	}
+
+	h.ResetState(userID)
+	text, markup := settingsMenu(localizer(c, settings), settings)
+	if c.Callback() != nil {
+		if err := c.Edit(text, markup); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+	}
+	return c.Send(text, markup)
+}
+
+// handleSettingsCallback opens an option (set_<key>) or stores a value (setv_<key>:<value>)
+func (h *Handler) handleSettingsCallback(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	data = strings.TrimSpace(data)
+
+	var text string
+	var markup *tele.ReplyMarkup
+
+	if strings.HasPrefix(data, "setv_") {
+		key, value, ok := strings.Cut(strings.TrimPrefix(data, "setv_"), ":")
+		if !ok {
+			h.logger.Warn("Malformed setting value", zap.String("data", data))
+			return nil
+		}
+
+		settings, err := h.settingsService.Set(ctx, userID, key, value)
+		if err != nil {
+			h.logger.Error("Failed to save setting",
+				zap.Error(err),
+				zap.Int64("user_id", userID),
+				zap.String("key", key),
+			)
+			return nil // Callback уже подтверждён
+		}
+		// A new language applies to the menu right away
+		text, markup = settingsMenu(localizer(c, settings), settings)
+	} else {
+		opt, ok := findSettingOption(strings.TrimPrefix(data, "set_"))
+		if !ok {
+			h.logger.Warn("Unknown setting", zap.String("data", data))
+			return nil
+		}
+
+		settings, err := h.settingsService.Get(ctx, userID)
+		if err != nil {
+			h.logger.Error("Failed to get user settings", zap.Error(err))
+			return nil // Callback уже подтверждён
+		}
+		text, markup = settingOptionMenu(localizer(c, settings), opt, settings.Get(opt.key))
+	}
+
+	if err := c.Edit(text, markup); err != nil {
+		h.handleEditError(err, c, userID)
+	}
+	return nil
+}
+
+// settingsMenu lists all options with their current values
+func settingsMenu(tr *i18n.Localizer, settings domain.Settings) (string, *tele.ReplyMarkup) {
+	markup := &tele.ReplyMarkup{}
+
+	rows := make([]tele.Row, 0, len(settingOptions)+1)
+	for _, opt := range settingOptions {
+		label := fmt.Sprintf("%s: %s", tr.T("settings."+opt.key), opt.format(tr, settings.Get(opt.key)))
+		rows = append(rows, markup.Row(markup.Data(label, "set_"+opt.key)))
+	}
+	rows = append(rows, markup.Row(localBtn(tr, btnMainMenu)))
+	markup.Inline(rows...)
+
+	return tr.T("settings.title"), markup
+}
+
+// settingOptionMenu offers choices of one option, marking the current value
+func settingOptionMenu(tr *i18n.Localizer, opt settingOption, current string) (string, *tele.ReplyMarkup) {
+	markup := &tele.ReplyMarkup{}
+
+	var rows []tele.Row
+	var row tele.Row
+	for _, choice := range opt.choices {
+		label := opt.format(tr, choice)
+		if choice == current {
+			label = "✅ " + label
+		}
+		row = append(row, markup.Data(label, "setv_"+opt.key+":"+choice))
+		if len(row) == opt.perRow {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, markup.Row(markup.Data(tr.T("btn.settings_back"), btnSettings.Unique)))
+	markup.Inline(rows...)
+
+	text := tr.T("settings."+opt.key) + "\n\n" + tr.T("settings."+opt.key+".hint") + "\n" +
+		tr.T("settings.current", opt.format(tr, current))
+	return text, markup
+}
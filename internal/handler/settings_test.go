@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsMenu(t *testing.T) {
+	settings := domain.Settings{
+		PageSize:    7,
+		HistoryDays: 60,
+		HideDays:    1,
+		Location:    time.UTC,
+		Direction:   domain.DirectionReverse,
+		Language:    domain.LanguageAuto,
+		AutoSwap:    true,
+	}
+
+	_, markup := settingsMenu(i18n.For(i18n.Russian), settings)
+
+	require.Len(t, markup.InlineKeyboard, len(settingOptions)+1)
+	assert.Equal(t, "set_page_size", markup.InlineKeyboard[0][0].Unique)
+	assert.Equal(t, "📄 Дней на странице: 7", markup.InlineKeyboard[0][0].Text)
+	assert.Equal(t, "🗓 Хранить слова: 60 дней", markup.InlineKeyboard[1][0].Text)
+	assert.Equal(t, "💤 Своя пауза: 1 день", markup.InlineKeyboard[2][0].Text)
+	assert.Equal(t, "🌍 Часовой пояс: UTC", markup.InlineKeyboard[3][0].Text)
+	assert.Equal(t, "🔀 Направление: 🔄 Перевод → слово", markup.InlineKeyboard[4][0].Text)
+	assert.Equal(t, "🌐 Язык: 🌐 Как в Telegram", markup.InlineKeyboard[5][0].Text)
+	assert.Equal(t, "🔁 Автозамена порядка: ✅ Вкл", markup.InlineKeyboard[6][0].Text)
+	assert.Equal(t, "main_menu", markup.InlineKeyboard[7][0].Unique)
+
+	_, markup = settingsMenu(i18n.For(i18n.English), settings)
+	assert.Equal(t, "🗓 Keep words: 60 days", markup.InlineKeyboard[1][0].Text)
+	assert.Equal(t, "🏠 Main menu", markup.InlineKeyboard[7][0].Text)
+}
+
+func TestSettingOptionMenu(t *testing.T) {
+	opt, ok := findSettingOption(domain.SettingHideDays)
+	require.True(t, ok)
+
+	text, markup := settingOptionMenu(i18n.For(i18n.Russian), opt, "21")
+
+	assert.Contains(t, text, "Сейчас: 21 день")
+	// 9 choices, 3 per row, then the back button
+	require.Len(t, markup.InlineKeyboard, 4)
+	assert.Len(t, markup.InlineKeyboard[0], 3)
+	assert.Equal(t, "setv_hide_days:5", markup.InlineKeyboard[0][0].Unique)
+	assert.Equal(t, "✅ 21 день", markup.InlineKeyboard[1][1].Text)
+	assert.Equal(t, "settings", markup.InlineKeyboard[3][0].Unique)
+}
+
+func TestSettingOptions_ChoicesAreValid(t *testing.T) {
+	for _, opt := range settingOptions {
+		for _, choice := range opt.choices {
+			var s domain.Settings
+			assert.NoError(t, s.Set(opt.key, choice), "%s=%s", opt.key, choice)
+			// Callback data is limited to 64 bytes
+			assert.LessOrEqual(t, len("setv_"+opt.key+":"+choice), 64)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"languager/internal/domain"
+	"languager/internal/middleware"
+
 	"go.uber.org/zap"
 	tele "gopkg.in/telebot.v3"
 )
@@ -8,6 +11,7 @@ import (
 // handleStart handles /start command
 func (h *Handler) handleStart(c tele.Context) error {
 	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
 
 	h.logger.Info("User started bot",
 		zap.Int64("user_id", userID),
@@ -15,28 +19,42 @@ func (h *Handler) handleStart(c tele.Context) error {
 	)
 
 	// Ensure user exists in database
-	if err := h.authService.EnsureUserExists(userID); err != nil {
+	if err := h.authService.EnsureUserExists(ctx, userID); err != nil {
 		h.logger.Error("Failed to ensure user exists", zap.Error(err))
-		return c.Send("Произошла ошибка. Попробуйте позже.")
+		return c.Send(h.tr(c).T("error.generic"))
 	}
 
+	settings := h.rememberClientLanguage(c)
+	tr := localizer(c, settings)
+
 	// Check if authorized
-	authorized, err := h.authService.IsAuthorized(userID)
+	authorized, err := h.authService.IsAuthorized(ctx, userID)
 	if err != nil {
 		h.logger.Error("Failed to check authorization", zap.Error(err))
-		return c.Send("Произошла ошибка. Попробуйте позже.")
+		return c.Send(tr.T("error.generic"))
+	}
+
+	// Deep link to a shared deck: /start deck_<code>
+	var deckCode string
+	if c.Callback() == nil {
+		deckCode, _ = domain.ParseDeckCode(c.Message().Payload)
 	}
 
 	if !authorized {
-		// Request password
+		// Request password, the deck is shown after logging in
+		h.SetState(userID, &domain.StateData{State: domain.StateIdle, DeckCode: deckCode})
+		return c.Send(tr.T("auth.prompt"))
+	}
+
+	if deckCode != "" {
 		h.ResetState(userID)
-		return c.Send("Привет! Если ты не знаешь пароль, поздравляю - ты пукал, а коль знаешь - вводи:")
+		return h.showDeck(c, deckCode, "")
 	}
 
 	// Show main menu
 	h.ResetState(userID)
-	text := "🏠 Главное меню\n\nВыберите действие:"
-	markup := mainMenuMarkup()
+	text := h.mainMenuText(ctx, tr, userID)
+	markup := mainMenuMarkup(tr)
 
 	// Edit message if callback, send new if command
 	if c.Callback() != nil {
@@ -51,3 +69,22 @@ func (h *Handler) handleStart(c tele.Context) error {
 	return c.Send(text, markup)
 }
 
+// rememberClientLanguage stores the Telegram client's language_code, so that
+// reminders and reports use it too, and returns user's settings
+func (h *Handler) rememberClientLanguage(c tele.Context) domain.Settings {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	settings := h.userSettings(ctx, userID)
+	code := c.Sender().LanguageCode
+	if code == "" || code == settings.ClientLang {
+		return settings
+	}
+
+	updated, err := h.settingsService.Set(ctx, userID, domain.SettingClientLanguage, code)
+	if err != nil {
+		h.logger.Warn("Failed to store client language", zap.Error(err), zap.Int64("user_id", userID))
+		return settings
+	}
+	return updated
+}
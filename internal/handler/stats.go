@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"languager/internal/chart"
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+// statsBarWidth is the length of text bars in /stats
+const statsBarWidth = 10
+
+var btnStatsCharts = tele.Btn{
+	Unique: "stats_charts",
+	Text:   "btn.stats_charts",
+}
+
+// handleStats shows user's statistics (/stats and the menu button)
+func (h *Handler) handleStats(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	if c.Callback() != nil {
+		// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+	} else if !h.requireAuth(c) {
+		return nil
+	}
+
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+	stats, err := h.statsService.GetUserStats(ctx, userID, settings.Pair)
+	if err != nil {
+		h.logger.Error("Failed to get user stats", zap.Error(err), zap.Int64("user_id", userID))
+		if c.Callback() != nil {
+			return nil // Callback уже подтверждён
+		}
+		return c.Send(tr.T("error.generic"))
+	}
+
+	text := formatStats(tr, stats)
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(localBtn(tr, btnStatsCharts)), markup.Row(localBtn(tr, btnMainMenu)))
+	opts := &tele.SendOptions{ParseMode: tele.ModeHTML, ReplyMarkup: markup}
+
+	if c.Callback() != nil {
+		if _, err := h.bot.Edit(c.Callback().Message, text, opts); err != nil {
+			h.handleEditError(err, c, userID)
+		}
+		return nil
+	}
+	return c.Send(text, opts)
+}
+
+// handleStatsCharts sends progress charts as PNG images
+func (h *Handler) handleStatsCharts(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	settings := h.userSettings(ctx, userID)
+	tr := localizer(c, settings)
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(&tele.CallbackResponse{Text: tr.T("stats.drawing")}); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	stats, err := h.statsService.GetUserStats(ctx, userID, settings.Pair)
+	if err != nil {
+		h.logger.Error("Failed to get user stats", zap.Error(err), zap.Int64("user_id", userID))
+		return nil
+	}
+
+	start, activity, err := h.statsService.GetActivityCalendar(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get activity calendar", zap.Error(err), zap.Int64("user_id", userID))
+		return nil
+	}
+
+	added := make([]int, len(stats.AddedPerDay))
+	addedLabels := make([]string, len(stats.AddedPerDay))
+	for i, d := range stats.AddedPerDay {
+		added[i] = d.Count
+		addedLabels[i] = d.Day.Format("02.01")
+	}
+
+	accuracyLabels, accuracy := accuracyTrend(stats.ReviewsPerDay)
+
+	charts := []struct {
+		caption string
+		render  func() []byte
+	}{
+		{tr.T("stats.chart_added"), func() []byte { return h.encodeChart(chart.Bars(addedLabels, added)) }},
+		{tr.T("stats.chart_accuracy"), func() []byte { return h.encodeChart(chart.Line(accuracyLabels, accuracy, 100, "%")) }},
+		{tr.T("stats.chart_activity"), func() []byte { return h.encodeChart(chart.Heatmap(start, activity)) }},
+	}
+
+	for _, ch := range charts {
+		data := ch.render()
+		if data == nil {
+			continue
+		}
+		photo := &tele.Photo{File: tele.FromReader(bytes.NewReader(data)), Caption: ch.caption}
+		if err := c.Send(photo); err != nil {
+			h.logger.Error("Failed to send chart", zap.Error(err), zap.Int64("user_id", userID))
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// encodeChart encodes a chart as PNG, returns nil on failure
+func (h *Handler) encodeChart(img *image.RGBA) []byte {
+	data, err := chart.EncodePNG(img)
+	if err != nil {
+		h.logger.Error("Failed to encode chart", zap.Error(err))
+		return nil
+	}
+	return data
+}
+
+// accuracyTrend returns daily accuracy in percent, NaN for days without reviews.
+// Every 7th day is labeled.
+func accuracyTrend(days []domain.DailyReviews) ([]string, []float64) {
+	labels := make([]string, len(days))
+	values := make([]float64, len(days))
+	for i, d := range days {
+		if i%7 == 0 {
+			labels[i] = d.Day.Format("02.01")
+		}
+		values[i] = math.NaN()
+		if d.Total > 0 {
+			values[i] = float64(d.Correct) * 100 / float64(d.Total)
+		}
+	}
+	return labels, values
+}
+
+// formatStats renders statistics as an HTML message with text bar charts
+func formatStats(tr *i18n.Localizer, stats *domain.UserStats) string {
+	var b strings.Builder
+
+	b.WriteString(tr.T("stats.title") + "\n\n")
+	b.WriteString(tr.T("stats.words", stats.Total, stats.Mastered) + "\n")
+	b.WriteString(tr.T("stats.hidden", stats.HiddenTemporarily, stats.HiddenForever) + "\n")
+	b.WriteString(tr.T("review.accuracy", stats.Reviews, stats.Accuracy()) + "\n")
+	for _, d := range stats.ByDirection {
+		b.WriteString("   " + tr.T("stats.direction", directionLabel(tr, d.Direction), d.Accuracy(), d.Total) + "\n")
+	}
+	if stats.Mastered > 0 {
+		b.WriteString(tr.T("stats.mastery_time", formatSpan(tr, stats.AvgTimeToMastery)) + "\n")
+	}
+
+	b.WriteString("\n" + tr.T("stats.added_per_day") + "\n")
+	labels := make([]string, len(stats.AddedPerDay))
+	for i, d := range stats.AddedPerDay {
+		labels[i] = tr.Weekday(d.Day.Weekday()) + " " + d.Day.Format("02.01")
+	}
+	b.WriteString(barChart(labels, stats.AddedPerDay))
+
+	b.WriteString("\n" + tr.T("stats.added_per_week") + "\n")
+	labels = make([]string, len(stats.AddedPerWeek))
+	for i, w := range stats.AddedPerWeek {
+		labels[i] = w.Day.Format("02.01") + "–" + w.Day.AddDate(0, 0, 6).Format("02.01")
+	}
+	b.WriteString(barChart(labels, stats.AddedPerWeek))
+
+	if len(stats.Hardest) > 0 {
+		b.WriteString("\n" + tr.T("stats.hardest") + "\n")
+		writeHardWords(&b, tr, stats.Hardest)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// writeHardWords writes a numbered list of words with their failures
+func writeHardWords(b *strings.Builder, tr *i18n.Localizer, words []domain.HardWord) {
+	for i, w := range words {
+		b.WriteString(tr.T("word.hard_line",
+			i+1, html.EscapeString(w.Word), html.EscapeString(w.Translation), w.Failures, w.Reviews) + "\n")
+	}
+}
+
+// barChart renders labeled counts as a monospace bar chart
+func barChart(labels []string, counts []domain.DailyCount) string {
+	top := 0
+	for _, c := range counts {
+		if c.Count > top {
+			top = c.Count
+		}
+	}
+	digits := len(strconv.Itoa(top))
+
+	var b strings.Builder
+	b.WriteString("<pre>")
+	for i, c := range counts {
+		fmt.Fprintf(&b, "%s %s %*d\n", labels[i], textBar(c.Count, top, statsBarWidth), digits, c.Count)
+	}
+	b.WriteString("</pre>")
+	return b.String()
+}
+
+// textBar returns a bar of width cells filled proportionally to value/total
+func textBar(value, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = (value*width + total - 1) / total
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// formatSpan formats a duration in hours or days
+func formatSpan(tr *i18n.Localizer, d time.Duration) string {
+	if d < 24*time.Hour {
+		hours := int(d.Round(time.Hour) / time.Hour)
+		if hours < 1 {
+			hours = 1
+		}
+		return tr.T("stats.span_hours", hours)
+	}
+	days := strconv.FormatFloat(d.Hours()/24, 'f', 1, 64)
+	return tr.T("stats.span_days", strings.Replace(days, ".", tr.T("stats.decimal_separator"), 1))
+}
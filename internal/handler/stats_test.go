@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextBar(t *testing.T) {
+	assert.Equal(t, "░░░░░", textBar(0, 0, 5))
+	assert.Equal(t, "█████", textBar(10, 10, 5))
+	assert.Equal(t, "███░░", textBar(5, 10, 5))
+	// Any non-zero value gets at least one cell
+	assert.Equal(t, "█░░░░", textBar(1, 100, 5))
+}
+
+func TestFormatSpan(t *testing.T) {
+	ru := i18n.For(i18n.Russian)
+	assert.Equal(t, "1 ч", formatSpan(ru, 10*time.Minute))
+	assert.Equal(t, "5 ч", formatSpan(ru, 5*time.Hour))
+	assert.Equal(t, "1,5 дн.", formatSpan(ru, 36*time.Hour))
+	assert.Equal(t, "1.5 d", formatSpan(i18n.For(i18n.English), 36*time.Hour))
+}
+
+func TestFormatStats(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 12, d, 0, 0, 0, 0, time.UTC) }
+
+	stats := &domain.UserStats{
+		WordTotals: domain.WordTotals{
+			Total:            40,
+			HiddenForever:    2,
+			Mastered:         10,
+			AvgTimeToMastery: 48 * time.Hour,
+		},
+		Reviews:        20,
+		CorrectReviews: 15,
+		AddedPerDay: []domain.DailyCount{
+			{Day: day(15), Count: 2},
+			{Day: day(16), Count: 12},
+		},
+		AddedPerWeek: []domain.DailyCount{
+			{Day: day(10), Count: 14},
+		},
+		Hardest: []domain.HardWord{
+			{Word: "<b>", Translation: "жирный", Failures: 3, Reviews: 4},
+		},
+		ByDirection: []domain.DirectionReviews{
+			{Direction: domain.DirectionForward, Total: 12, Correct: 9},
+			{Direction: domain.DirectionReverse, Total: 8, Correct: 6},
+		},
+	}
+
+	text := formatStats(i18n.For(i18n.Russian), stats)
+
+	assert.Contains(t, text, "📚 Слов: 40 · освоено: 10")
+	assert.Contains(t, text, "точность: 75%")
+	assert.Contains(t, text, "📝 Слово → перевод: 75% из 12")
+	assert.Contains(t, text, "🔄 Перевод → слово: 75% из 8")
+	assert.Contains(t, text, "⏱ Освоение слова в среднем: 2,0 дн.")
+	assert.Contains(t, text, "Вс 15.12 ██░░░░░░░░  2\nПн 16.12 ██████████ 12")
+	assert.Contains(t, text, "10.12–16.12 ██████████ 14")
+	assert.Contains(t, text, "1. &lt;b&gt; — жирный (❌ 3 из 4)")
+}
+
+func TestAccuracyTrend(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 12, d, 0, 0, 0, 0, time.UTC) }
+
+	days := make([]domain.DailyReviews, 8)
+	for i := range days {
+		days[i].Day = day(i + 1)
+	}
+	days[0].Total, days[0].Correct = 4, 3
+	days[7].Total, days[7].Correct = 2, 2
+
+	labels, values := accuracyTrend(days)
+
+	assert.Equal(t, []string{"01.12", "", "", "", "", "", "", "08.12"}, labels)
+	assert.Equal(t, 75.0, values[0])
+	assert.True(t, math.IsNaN(values[1]))
+	assert.Equal(t, 100.0, values[7])
+}
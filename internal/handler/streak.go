@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/middleware"
+
+	"go.uber.org/zap"
+	tele "gopkg.in/telebot.v3"
+)
+
+// mainMenuText returns the main menu header with user's streak
+func (h *Handler) mainMenuText(ctx context.Context, tr *i18n.Localizer, userID int64) string {
+	title := tr.T("menu.title") + "\n" + tr.T("menu.pair", h.userSettings(ctx, userID).Pair.Label())
+	prompt := tr.T("menu.prompt")
+
+	status, err := h.streakService.Status(ctx, userID)
+	if err != nil {
+		h.logger.Warn("Failed to get streak status", zap.Error(err), zap.Int64("user_id", userID))
+		return title + "\n\n" + prompt
+	}
+
+	streak := tr.T("menu.streak",
+		tr.N("n.days", status.Current),
+		status.Best,
+		status.Today.Total(), status.DailyGoal,
+	)
+	return title + "\n\n" + streak + "\n\n" + prompt
+}
+
+// trackActivity records added or reviewed words and congratulates the user
+// when the daily goal is reached
+func (h *Handler) trackActivity(c tele.Context, delta domain.Activity) {
+	userID := c.Sender().ID
+
+	result, err := h.streakService.RecordActivity(middleware.Ctx(c), userID, delta)
+	switch {
+	case err != nil:
+		h.logger.Error("Failed to record activity", zap.Error(err), zap.Int64("user_id", userID))
+	case result.Reached:
+		if err := c.Send(goalReachedText(h.tr(c), result.Streak, result.UsedFreeze)); err != nil {
+			h.logger.Warn("Failed to send goal notification", zap.Error(err), zap.Int64("user_id", userID))
+		}
+	}
+}
+
+// goalReachedText returns the "goal reached" notification
+func goalReachedText(tr *i18n.Localizer, streak int, usedFreeze bool) string {
+	text := tr.T("goal.reached", tr.N("n.days", streak))
+	if usedFreeze {
+		text += "\n" + tr.T("goal.freeze_used")
+	}
+	return text
+}
+
+// handleGoal shows daily goal settings (/goal)
+func (h *Handler) handleGoal(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	if !h.requireAuth(c) {
+		return nil
+	}
+
+	tr := h.tr(c)
+	text, markup, err := h.goalMenu(ctx, tr, userID)
+	if err != nil {
+		h.logger.Error("Failed to get streak status", zap.Error(err))
+		return c.Send(tr.T("error.generic"))
+	}
+	return c.Send(text, markup)
+}
+
+// handleGoalCallback handles buttons of the goal menu (goal_*)
+func (h *Handler) handleGoalCallback(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if c.Callback() != nil {
+		if err := c.Respond(); err != nil {
+			h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+		}
+	}
+
+	tr := h.tr(c)
+	action := strings.TrimPrefix(strings.TrimSpace(data), "goal_")
+	if action == "freeze" {
+		if err := h.streakService.ToggleFreeze(ctx, userID); err != nil {
+			h.logger.Error("Failed to toggle streak freeze", zap.Error(err), zap.Int64("user_id", userID))
+			return nil // Callback уже подтверждён
+		}
+	} else {
+		goal, err := strconv.Atoi(action)
+		if err != nil {
+			h.logger.Error("Failed to parse daily goal", zap.Error(err), zap.String("data", data))
+			return nil // Callback уже подтверждён
+		}
+
+		result, err := h.streakService.SetDailyGoal(ctx, userID, goal)
+		if err != nil {
+			h.logger.Error("Failed to set daily goal", zap.Error(err), zap.Int64("user_id", userID))
+			return nil // Callback уже подтверждён
+		}
+		if result.Reached {
+			defer func() {
+				if err := c.Send(goalReachedText(tr, result.Streak, result.UsedFreeze)); err != nil {
+					h.logger.Warn("Failed to send goal notification", zap.Error(err))
+				}
+			}()
+		}
+	}
+
+	text, markup, err := h.goalMenu(ctx, tr, userID)
+	if err != nil {
+		h.logger.Error("Failed to get streak status", zap.Error(err))
+		return nil // Callback уже подтверждён
+	}
+	if err := c.Edit(text, markup); err != nil {
+		h.handleEditError(err, c, userID)
+	}
+	return nil
+}
+
+// goalMenu renders daily goal settings and their keyboard
+func (h *Handler) goalMenu(ctx context.Context, tr *i18n.Localizer, userID int64) (string, *tele.ReplyMarkup, error) {
+	status, err := h.streakService.Status(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	freeze := tr.T("goal.off")
+	if status.FreezeEnabled {
+		freeze = tr.T("goal.on")
+	}
+
+	text := tr.T("goal.menu",
+		tr.N("n.words", status.DailyGoal),
+		status.Today.Total(), status.DailyGoal,
+		status.Current, status.Best,
+		freeze,
+	)
+
+	markup := &tele.ReplyMarkup{}
+	presetRow := tele.Row{}
+	for _, goal := range domain.DailyGoalPresets {
+		label := strconv.Itoa(goal)
+		if goal == status.DailyGoal {
+			label = "✅ " + label
+		}
+		presetRow = append(presetRow, markup.Data(label, fmt.Sprintf("goal_%d", goal)))
+	}
+
+	markup.Inline(
+		presetRow,
+		markup.Row(markup.Data(tr.T("goal.freeze", freeze), "goal_freeze")),
+		markup.Row(localBtn(tr, btnMainMenu)),
+	)
+	return text, markup, nil
+}
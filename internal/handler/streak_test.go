@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"testing"
+
+	"languager/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoalReachedText(t *testing.T) {
+	ru := i18n.For(i18n.Russian)
+
+	assert.Equal(t, "🎉 Цель на сегодня выполнена!\n\n🔥 Серия: 3 дня", goalReachedText(ru, 3, false))
+	assert.Equal(t, "🎉 Цель на сегодня выполнена!\n\n🔥 Серия: 5 дней\n🧊 Заморозка сохранила серию за вчера", goalReachedText(ru, 5, true))
+}
@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/langdetect"
+	"languager/internal/middleware"
 
 	"go.uber.org/zap"
 	tele "gopkg.in/telebot.v3"
@@ -12,6 +17,7 @@ import (
 // handleText handles all text messages based on state
 func (h *Handler) handleText(c tele.Context) error {
 	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
 	text := strings.TrimSpace(c.Text())
 
 	// Ignore commands (starting with /)
@@ -20,90 +26,221 @@ func (h *Handler) handleText(c tele.Context) error {
 	}
 
 	// Ensure user exists
-	if err := h.authService.EnsureUserExists(userID); err != nil {
+	if err := h.authService.EnsureUserExists(ctx, userID); err != nil {
 		h.logger.Error("Failed to ensure user exists", zap.Error(err))
 		return nil
 	}
+	tr := h.tr(c)
 
 	// Check authorization first
-	authorized, err := h.authService.IsAuthorized(userID)
+	authorized, err := h.authService.IsAuthorized(ctx, userID)
 	if err != nil {
 		h.logger.Error("Failed to check authorization", zap.Error(err))
-		return c.Send("Произошла ошибка. Попробуйте позже.")
+		return c.Send(tr.T("error.generic"))
 	}
 
 	// If not authorized, check password
 	if !authorized {
 		if h.authService.CheckPassword(text) {
 			// Correct password
-			if err := h.authService.AuthorizeUser(userID); err != nil {
+			if err := h.authService.AuthorizeUser(ctx, userID); err != nil {
 				h.logger.Error("Failed to authorize user", zap.Error(err))
-				return c.Send("Произошла ошибка. Попробуйте позже.")
+				return c.Send(tr.T("error.generic"))
 			}
 
 			h.logger.Info("User authorized", zap.Int64("user_id", userID))
+			h.audit(c, domain.AuditAuthGranted, domain.AuditUser(userID), senderMetadata(c.Sender()))
+
+			// Came by a deck link, show the deck instead of the menu
+			if code := h.GetState(userID).DeckCode; code != "" {
+				h.ResetState(userID)
+				return h.showDeck(c, code, tr.T("auth.granted"))
+			}
+
 			h.ResetState(userID)
 			return c.Send(
-				"✅ Доступ разрешён!\n\n🏠 Главное меню\n\nВыберите действие:",
-				mainMenuMarkup(),
+				tr.T("auth.granted")+"\n\n"+h.mainMenuText(ctx, tr, userID),
+				mainMenuMarkup(tr),
 			)
 		}
 
 		// Wrong password
-		return c.Send("Непральна")
+		h.audit(c, domain.AuditAuthFailed, domain.AuditUser(userID), senderMetadata(c.Sender()))
+		return c.Send(tr.T("auth.wrong_password"))
 	}
 
 	// User is authorized, handle based on state
 	state := h.GetState(userID)
 
 	switch state.State {
+	case domain.StateWaitingReminderTime:
+		return h.handleReminderTimeInput(c, text)
+
 	case domain.StateWaitingWord:
 		// User sent a word, now wait for translation
-		cancelMarkup := &tele.ReplyMarkup{}
-		cancelMarkup.Inline(cancelMarkup.Row(btnCancel))
-
-		h.SetState(userID, &domain.StateData{
-			State:       domain.StateWaitingTranslation,
-			CurrentWord: text,
-		})
-
-		return c.Send("Жду перевод", cancelMarkup)
+		return h.askTranslation(c, tr, text)
 
 	case domain.StateWaitingTranslation:
 		// User sent translation, save the pair
-		word := state.CurrentWord
-		translation := text
+		return h.saveWord(c, tr, state.CurrentWord, text)
 
-		if err := h.wordService.SaveWordPair(userID, word, translation); err != nil {
-			h.logger.Error("Failed to save word pair",
-				zap.Error(err),
-				zap.Int64("user_id", userID),
-			)
-			return c.Send("Не удалось сохранить слово. Попробуйте ещё раз.")
-		}
+	case domain.StateWaitingExample:
+		return h.saveExample(c, tr, state, text)
+
+	case domain.StateWaitingCloze:
+		return h.checkCloze(c, text)
+
+	case domain.StateWaitingDeckName:
+		return h.publishDeck(c, tr, text)
+
+	default:
+		// Idle state - start word input flow
+		return h.askTranslation(c, tr, text)
+	}
+}
 
-		h.logger.Info("Word pair saved",
+// askTranslation waits for the translation of word, offering dictionary
+// suggestions as buttons. Suggestions are kept in the state and buttons
+// carry only their index, as translations may not fit into callback data.
+func (h *Handler) askTranslation(c tele.Context, tr *i18n.Localizer, word string) error {
+	userID := c.Sender().ID
+	pair := h.userSettings(middleware.Ctx(c), userID).Pair
+	suggestions := h.dictionaryService.Suggest(pair, word)
+
+	h.SetState(userID, &domain.StateData{
+		State:       domain.StateWaitingTranslation,
+		CurrentWord: word,
+		Suggestions: suggestions,
+	})
+
+	markup := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0, len(suggestions)+1)
+	for i, s := range suggestions {
+		rows = append(rows, markup.Row(markup.Data(s, fmt.Sprintf("sugg_%d", i))))
+	}
+	rows = append(rows, markup.Row(localBtn(tr, btnCancel)))
+	markup.Inline(rows...)
+
+	text := tr.T("word.waiting_translation")
+	if len(suggestions) > 0 {
+		text = tr.T("word.suggestions")
+	}
+	return c.Send(text, markup)
+}
+
+// saveWord saves the pair in the active language pair and asks for the next word
+func (h *Handler) saveWord(c tele.Context, tr *i18n.Localizer, word, translation string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+	settings := h.userSettings(ctx, userID)
+	pair := settings.Pair
+
+	// People often type the translation first
+	reversed := langdetect.Reversed(word, translation, pair.Source, pair.Target)
+	swapped := reversed && settings.AutoSwap
+	if swapped {
+		word, translation = translation, word
+	}
+
+	wordID, err := h.wordService.SaveWordPair(ctx, userID, pair, word, translation)
+	if err != nil {
+		h.logger.Error("Failed to save word pair",
+			zap.Error(err),
 			zap.Int64("user_id", userID),
-			zap.String("word", word),
-			zap.String("translation", translation),
 		)
+		return c.Send(tr.T("word.save_failed"))
+	}
 
-		// Reset to waiting for next word
-		h.SetState(userID, &domain.StateData{State: domain.StateWaitingWord})
+	h.logger.Info("Word pair saved",
+		zap.Int64("user_id", userID),
+		zap.String("word", word),
+		zap.String("translation", translation),
+		zap.Stringer("pair", pair),
+		zap.Bool("swapped", swapped),
+	)
+
+	// Reset to waiting for next word
+	h.SetState(userID, &domain.StateData{State: domain.StateWaitingWord})
+
+	reply, markup := tr.T("word.saved"), &tele.ReplyMarkup{}
+	rows := []tele.Row{exampleButton(tr, markup, wordID)}
+	switch {
+	case swapped:
+		reply = tr.T("word.swapped", word, translation) + "\n\n" + reply
+	case reversed:
+		// Not sure enough to swap on our own, let the user decide
+		rows = append(rows, markup.Row(markup.Data(tr.T("btn.swap"), fmt.Sprintf("swap_%d", wordID))))
+		reply += "\n\n" + tr.T("word.reversed")
+	}
+	markup.Inline(rows...)
+	if err := c.Send(reply, markup); err != nil {
+		return err
+	}
+	h.trackActivity(c, domain.Activity{WordsAdded: 1})
+	return nil
+}
 
-		return c.Send("✅ Сохранено!\n\nМожешь отправить следующее слово или вернуться в /start")
+// handleSuggestion saves the word with the tapped dictionary suggestion (sugg_<index>)
+func (h *Handler) handleSuggestion(c tele.Context, data string) error {
+	userID := c.Sender().ID
 
-	default:
-		// Idle state - start word input flow
-		cancelMarkup := &tele.ReplyMarkup{}
-		cancelMarkup.Inline(cancelMarkup.Row(btnCancel))
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
 
-		h.SetState(userID, &domain.StateData{
-			State:       domain.StateWaitingTranslation,
-			CurrentWord: text,
-		})
+	// Serialize with other taps, so that a double tap saves the word once
+	lock := h.userLock(userID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	index, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(data), "sugg_"))
+	if err != nil {
+		h.logger.Error("Failed to parse suggestion index", zap.Error(err), zap.String("data", data))
+		return nil // Callback уже подтверждён
+	}
+
+	// Buttons of an older prompt point to suggestions that are gone
+	state := h.GetState(userID)
+	if state.State != domain.StateWaitingTranslation || index < 0 || index >= len(state.Suggestions) {
+		h.logger.Info("Outdated suggestion", zap.Int64("user_id", userID), zap.String("data", data))
+		return nil // Callback уже подтверждён
+	}
 
-		return c.Send("Жду перевод", cancelMarkup)
+	// Drop the suggestion buttons, the pair is saved below
+	if err := c.Edit(c.Message().Text); err != nil {
+		h.handleEditError(err, c, userID)
 	}
+	return h.saveWord(c, h.tr(c), state.CurrentWord, state.Suggestions[index])
 }
 
+// handleSwap swaps the word and the translation of a just saved word (swap_<id>)
+func (h *Handler) handleSwap(c tele.Context, data string) error {
+	userID := c.Sender().ID
+	ctx := middleware.Ctx(c)
+
+	// КРИТИЧЕСКИ ВАЖНО: Отвечаем на callback СРАЗУ
+	if err := c.Respond(); err != nil {
+		h.logger.Warn("Failed to acknowledge callback", zap.Error(err))
+	}
+
+	wordID, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(data), "swap_"))
+	if err != nil {
+		h.logger.Error("Failed to parse word ID", zap.Error(err), zap.String("data", data))
+		return nil // Callback уже подтверждён
+	}
+
+	word, err := h.wordService.SwapWord(ctx, userID, wordID)
+	if err != nil {
+		h.logger.Error("Failed to swap word", zap.Error(err), zap.Int("word_id", wordID))
+		return nil // Callback уже подтверждён
+	}
+
+	tr := h.tr(c)
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(exampleButton(tr, markup, word.ID))
+	if err := c.Edit(tr.T("word.swapped", word.Word, word.Translation)+"\n\n"+tr.T("word.saved"), markup); err != nil {
+		h.handleEditError(err, c, userID)
+	}
+	return nil
+}
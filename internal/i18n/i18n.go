@@ -0,0 +1,172 @@
+// Package i18n provides message catalogues and plural rules for bot texts.
+// Catalogues are JSON files in locales/ embedded into the binary.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Lang is a supported interface language
+type Lang string
+
+// Supported languages
+const (
+	Russian Lang = "ru"
+	English Lang = "en"
+
+	// Default is used when the user's language isn't supported
+	Default = Russian
+)
+
+// Languages lists supported languages
+var Languages = []Lang{Russian, English}
+
+// message is a plain text or a set of plural forms
+type message struct {
+	text  string
+	forms map[string]string
+}
+
+// UnmarshalJSON accepts either a string or an object of plural forms
+func (m *message) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.text); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &m.forms)
+}
+
+type catalogue map[string]message
+
+var catalogues = mustLoadCatalogues()
+
+func mustLoadCatalogues() map[Lang]catalogue {
+	result := make(map[Lang]catalogue, len(Languages))
+	for _, lang := range Languages {
+		data, err := localeFiles.ReadFile(path.Join("locales", string(lang)+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: read %s catalogue: %v", lang, err))
+		}
+		var cat catalogue
+		if err := json.Unmarshal(data, &cat); err != nil {
+			panic(fmt.Sprintf("i18n: parse %s catalogue: %v", lang, err))
+		}
+		result[lang] = cat
+	}
+	return result
+}
+
+// Match returns the supported language of a language code
+// such as Telegram's language_code ("en", "en-US", "pt-br")
+func Match(code string) (Lang, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	for _, lang := range Languages {
+		if code == string(lang) {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Detect returns the supported language of a language code, or Default
+func Detect(code string) Lang {
+	if lang, ok := Match(code); ok {
+		return lang
+	}
+	return Default
+}
+
+// Has reports whether the catalogue of lang has key, without falling back to Default
+func Has(lang Lang, key string) bool {
+	_, ok := catalogues[lang][key]
+	return ok
+}
+
+// Resolve picks the interface language: the chosen one, or else the language
+// of the Telegram client. chosen is the code from the user's settings or "auto",
+// clientCode is the language_code of the current update, empty if there is none,
+// and lastClientCode the one stored from an earlier update.
+func Resolve(chosen, clientCode, lastClientCode string) Lang {
+	if lang, ok := Match(chosen); ok {
+		return lang
+	}
+	if lang, ok := Match(clientCode); ok {
+		return lang
+	}
+	return Detect(lastClientCode)
+}
+
+// Localizer renders messages of one language
+type Localizer struct {
+	lang Lang
+}
+
+// For returns the localizer of lang; unsupported languages get Default
+func For(lang Lang) *Localizer {
+	if _, ok := catalogues[lang]; !ok {
+		lang = Default
+	}
+	return &Localizer{lang: lang}
+}
+
+// Lang returns the localizer's language
+func (l *Localizer) Lang() Lang {
+	return l.lang
+}
+
+// T returns the message of key formatted with args.
+// Missing messages fall back to Default, then to the key itself.
+// Plural messages must be rendered with N.
+func (l *Localizer) T(key string, args ...any) string {
+	msg, _, ok := l.lookup(key)
+	if !ok || msg.forms != nil {
+		return key
+	}
+	if len(args) == 0 {
+		return msg.text
+	}
+	return fmt.Sprintf(msg.text, args...)
+}
+
+// N returns the plural form of key that agrees with n, formatted with n
+func (l *Localizer) N(key string, n int) string {
+	msg, lang, ok := l.lookup(key)
+	if !ok || msg.forms == nil {
+		return key
+	}
+	form, ok := msg.forms[pluralRules[lang](n)]
+	if !ok {
+		return key
+	}
+	return fmt.Sprintf(form, n)
+}
+
+// Month returns the short name of a month
+func (l *Localizer) Month(m time.Month) string {
+	return l.T(fmt.Sprintf("month.%d", m))
+}
+
+// Weekday returns the short name of a weekday
+func (l *Localizer) Weekday(d time.Weekday) string {
+	return l.T(fmt.Sprintf("weekday.%d", d))
+}
+
+// lookup finds key in the localizer's catalogue or in Default's,
+// returning the language the message is in
+func (l *Localizer) lookup(key string) (message, Lang, bool) {
+	if msg, ok := catalogues[l.lang][key]; ok {
+		return msg, l.lang, true
+	}
+	msg, ok := catalogues[Default][key]
+	return msg, Default, ok
+}
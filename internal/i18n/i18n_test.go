@@ -0,0 +1,156 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected Lang
+		ok       bool
+	}{
+		{"ru", Russian, true},
+		{"en", English, true},
+		{"en-US", English, true},
+		{"EN_gb", English, true},
+		{" ru ", Russian, true},
+		{"de", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		lang, ok := Match(tt.code)
+		assert.Equal(t, tt.expected, lang, tt.code)
+		assert.Equal(t, tt.ok, ok, tt.code)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	assert.Equal(t, English, Detect("en-US"))
+	assert.Equal(t, Default, Detect("de"))
+	assert.Equal(t, Default, Detect(""))
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name           string
+		chosen         string
+		clientCode     string
+		lastClientCode string
+		expected       Lang
+	}{
+		{name: "chosen language wins", chosen: "ru", clientCode: "en", expected: Russian},
+		{name: "auto follows the client", chosen: "auto", clientCode: "en-US", expected: English},
+		{name: "stored client code", chosen: "auto", lastClientCode: "en", expected: English},
+		{name: "unsupported client", chosen: "auto", clientCode: "de", expected: Default},
+		{name: "nothing known", expected: Default},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Resolve(tt.chosen, tt.clientCode, tt.lastClientCode))
+		})
+	}
+}
+
+func TestPluralRussian(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{1, "1 слово"},
+		{2, "2 слова"},
+		{5, "5 слов"},
+		{11, "11 слов"},
+		{12, "12 слов"},
+		{21, "21 слово"},
+		{24, "24 слова"},
+		{111, "111 слов"},
+		{0, "0 слов"},
+	}
+
+	ru := For(Russian)
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ru.N("n.words", tt.n), tt.n)
+	}
+}
+
+func TestPluralEnglish(t *testing.T) {
+	en := For(English)
+
+	assert.Equal(t, "1 day", en.N("n.days", 1))
+	assert.Equal(t, "0 days", en.N("n.days", 0))
+	assert.Equal(t, "21 days", en.N("n.days", 21))
+}
+
+func TestLocalizer(t *testing.T) {
+	en := For(English)
+
+	assert.Equal(t, English, en.Lang())
+	assert.Equal(t, "Today", en.T("day.today"))
+	assert.Equal(t, "🔁 Reviews: 4 · accuracy: 75%", en.T("review.accuracy", 4, 75))
+	assert.Equal(t, "Jun", en.Month(time.June))
+	assert.Equal(t, "Mo", en.Weekday(time.Monday))
+	assert.Equal(t, "мая", For(Russian).Month(time.May))
+
+	// Unknown languages and keys
+	assert.Equal(t, Default, For("de").Lang())
+	assert.Equal(t, "no.such.key", en.T("no.such.key"))
+	assert.Equal(t, "n.days", en.T("n.days"), "plural messages need N")
+}
+
+func TestHas(t *testing.T) {
+	assert.True(t, Has(English, "day.today"))
+	assert.False(t, Has(English, "no.such.key"))
+	assert.False(t, Has("de", "day.today"), "no fallback to Default")
+}
+
+// Catalogues must have the same keys, complete plural forms
+// and the same format verbs, or some users get broken texts
+func TestCatalogues_Consistent(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0-9.*]*[a-zA-Z%]`)
+	signature := func(msg message) []string {
+		text := msg.text
+		for _, form := range msg.forms {
+			text = form
+			break
+		}
+		return verbs.FindAllString(text, -1)
+	}
+
+	reference := catalogues[Default]
+	for _, lang := range Languages {
+		cat := catalogues[lang]
+		assert.Equal(t, sortedKeys(reference), sortedKeys(cat), "keys of %s", lang)
+
+		for key, msg := range cat {
+			if msg.forms != nil {
+				for _, form := range pluralForms[lang] {
+					assert.Contains(t, msg.forms, form, "%s %s", lang, key)
+				}
+				for form, text := range msg.forms {
+					assert.Equal(t, []string{"%d"}, verbs.FindAllString(text, -1), "%s %s.%s", lang, key, form)
+				}
+			}
+			if ref, ok := reference[key]; ok {
+				assert.Equal(t, ref.forms != nil, msg.forms != nil, "%s %s plural", lang, key)
+				assert.Equal(t, signature(ref), signature(msg), "%s %s verbs", lang, key)
+			}
+		}
+	}
+}
+
+func sortedKeys(cat catalogue) []string {
+	keys := make([]string, 0, len(cat))
+	for key := range cat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
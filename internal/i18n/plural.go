@@ -0,0 +1,48 @@
+package i18n
+
+// Plural form names as used in catalogues (CLDR categories)
+const (
+	pluralOne   = "one"
+	pluralFew   = "few"
+	pluralMany  = "many"
+	pluralOther = "other"
+)
+
+// pluralRules pick the plural form of n for each language
+var pluralRules = map[Lang]func(n int) string{
+	Russian: pluralRussian,
+	English: pluralEnglish,
+}
+
+// pluralForms lists the forms a plural message must have in each language
+var pluralForms = map[Lang][]string{
+	Russian: {pluralOne, pluralFew, pluralMany},
+	English: {pluralOne, pluralOther},
+}
+
+// pluralRussian: 1 слово, 2 слова, 5 слов, 11 слов, 21 слово
+func pluralRussian(n int) string {
+	if n < 0 {
+		n = -n
+	}
+	n %= 100
+	if n >= 11 && n <= 14 {
+		return pluralMany
+	}
+	switch n % 10 {
+	case 1:
+		return pluralOne
+	case 2, 3, 4:
+		return pluralFew
+	default:
+		return pluralMany
+	}
+}
+
+// pluralEnglish: 1 word, 2 words, 0 words
+func pluralEnglish(n int) string {
+	if n == 1 {
+		return pluralOne
+	}
+	return pluralOther
+}
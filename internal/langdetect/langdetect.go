@@ -0,0 +1,137 @@
+// Package langdetect guesses the language of short texts offline.
+// Cyrillic text is Russian; Latin text is told apart by letters
+// unique to a language and by its most frequent character n-grams.
+// Inputs are single words or short phrases, so the detector only
+// answers when one language clearly wins.
+package langdetect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// profile describes how a language looks in writing
+type profile struct {
+	script  *unicode.RangeTable
+	letters string   // letters that point to this language among Latin ones
+	grams   []string // frequent character n-grams, "_" marks a word boundary
+}
+
+var profiles = map[string]profile{
+	"ru": {script: unicode.Cyrillic},
+	"en": {
+		script: unicode.Latin,
+		grams: []string{
+			"_th", "the", "he_", "ing", "ng_", "_an", "and", "nd_", "ion", "tio",
+			"_to", "_of", "of_", "er_", "ed_", "ly_", "_wh", "ght", "ou", "ay_",
+			"_be", "ve_", "ee", "oo", "ck_", "sh", "_yo", "all", "ess", "ss_",
+		},
+	},
+	"de": {
+		script:  unicode.Latin,
+		letters: "äöüß",
+		grams: []string{
+			"sch", "ch_", "ich", "ein", "_ei", "en_", "cht", "_de", "der", "die",
+			"und", "_un", "ung", "ng_", "_ge", "gen", "ie_", "ei", "_zu", "tz",
+			"_be", "er_", "_st", "st_", "_au", "eit", "ke", "_ve", "hen", "lich",
+		},
+	},
+	"es": {
+		script:  unicode.Latin,
+		letters: "ñ¿¡",
+		grams: []string{
+			"_de", "de_", "os_", "as_", "_la", "la_", "_el", "el_", "que", "ue_",
+			"ión", "ció", "_es", "ar_", "_co", "ado", "do_", "ida", "_qu", "ent",
+			"ll", "_ha", "_y_", "rr", "_en", "o_", "a_", "ue", "ci", "za",
+		},
+	},
+	"fr": {
+		script:  unicode.Latin,
+		letters: "çœæèêëîïûù",
+		grams: []string{
+			"_le", "le_", "es_", "_de", "de_", "ent", "nt_", "_la", "que", "ou",
+			"_qu", "ai", "eau", "aux", "oi", "_l'", "_d'", "tion", "ez_", "eux",
+			"_un", "une", "re_", "_pa", "ét", "é_", "_ce", "ie_", "ns_", "ille",
+		},
+	},
+	"it": {
+		script:  unicode.Latin,
+		letters: "ìò",
+		grams: []string{
+			"_di", "di_", "che", "he_", "_il", "il_", "_la", "la_", "zio", "ion",
+			"one", "ne_", "_co", "to_", "re_", "gli", "_de", "ell", "lla", "tt",
+			"i_", "o_", "_pe", "per", "_un", "ment", "cc", "zz", "gn", "sc",
+		},
+	},
+}
+
+// Detect returns the most likely language of text among candidates,
+// or "" if none matches the text's script or two candidates tie
+func Detect(text string, candidates ...string) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return ""
+	}
+
+	best, bestScore, tie := "", 0, false
+	for _, lang := range candidates {
+		score := Score(text, lang)
+		switch {
+		case score > bestScore:
+			best, bestScore, tie = lang, score, false
+		case score == bestScore && score > 0:
+			tie = true
+		}
+	}
+	if tie {
+		return ""
+	}
+	return best
+}
+
+// Score rates how much text looks like lang; 0 means it can't be lang.
+// The script decides first, letters and n-grams break ties between
+// languages sharing a script.
+func Score(text, lang string) int {
+	p, ok := profiles[lang]
+	if !ok {
+		return 0
+	}
+	text = strings.ToLower(text)
+
+	inScript, letters := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(p.script, r) {
+			inScript++
+		}
+	}
+	// Mostly foreign letters: a mistyped translation, not this language
+	if letters == 0 || inScript*2 <= letters {
+		return 0
+	}
+
+	score := 1
+	for _, r := range text {
+		if strings.ContainsRune(p.letters, r) {
+			score += 3
+		}
+	}
+
+	padded := "_" + strings.Join(strings.Fields(text), "_") + "_"
+	for _, g := range p.grams {
+		score += strings.Count(padded, g)
+	}
+	return score
+}
+
+// Reversed reports whether word and translation look swapped for a pair
+// of source and target languages: the word reads as the target language
+// and the translation as the source one
+func Reversed(word, translation, source, target string) bool {
+	return Detect(word, source, target) == target &&
+		Detect(translation, source, target) == source
+}
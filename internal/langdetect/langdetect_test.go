@@ -0,0 +1,66 @@
+package langdetect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		text       string
+		candidates []string
+		expected   string
+	}{
+		{"привет", []string{"en", "ru"}, "ru"},
+		{"hello", []string{"en", "ru"}, "en"},
+		{"Hello, world!", []string{"ru", "en"}, "en"},
+		{"Straße", []string{"en", "de"}, "de"},
+		{"thinking", []string{"en", "de"}, "en"},
+		{"mañana", []string{"en", "es"}, "es"},
+		{"garçon", []string{"fr", "it"}, "fr"},
+		{"la stazione", []string{"fr", "it"}, "it"},
+		{"das Mädchen", []string{"de", "en", "es"}, "de"},
+		// Nothing tells these apart, so no answer
+		{"Haus", []string{"en", "de"}, ""},
+		{"привет", []string{"en", "de"}, ""},
+		{"123", []string{"en", "ru"}, ""},
+		{"", []string{"en", "ru"}, ""},
+		{"hello", []string{"xx"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Detect(tt.text, tt.candidates...))
+		})
+	}
+}
+
+func TestScore_MixedScripts(t *testing.T) {
+	// A word with one Latin letter mistyped in Cyrillic is still Russian
+	assert.Greater(t, Score("пpивет", "ru"), 0)
+	assert.Equal(t, 0, Score("пpивет", "en"))
+}
+
+func TestReversed(t *testing.T) {
+	tests := []struct {
+		name        string
+		word        string
+		translation string
+		source      string
+		target      string
+		expected    bool
+	}{
+		{"right order", "hello", "привет", "en", "ru", false},
+		{"swapped", "привет", "hello", "en", "ru", true},
+		{"both russian", "привет", "здравствуй", "en", "ru", false},
+		{"swapped latin pair", "thinking", "Straße", "de", "en", true},
+		{"unsure side", "Haus", "house", "de", "en", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Reversed(tt.word, tt.translation, tt.source, tt.target))
+		})
+	}
+}
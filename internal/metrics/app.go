@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var factory = promauto.With(Default)
+
+// Application metrics
+var (
+	Updates = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "languager_updates_total",
+		Help: "Telegram updates received, by update type.",
+	}, []string{"type"})
+	CallbackDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "languager_callback_duration_seconds",
+		Help:    "Time spent handling callback queries, by handler.",
+		Buckets: DefaultBuckets,
+	}, []string{"handler"})
+	DBQueryDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "languager_db_query_duration_seconds",
+		Help:    "Database query duration, by repository method.",
+		Buckets: DefaultBuckets,
+	}, []string{"query"})
+	WordsSaved = factory.NewCounter(prometheus.CounterOpts{
+		Name: "languager_words_saved_total",
+		Help: "Word pairs saved by users.",
+	})
+	WordsHidden = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "languager_words_hidden_total",
+		Help: "Words hidden from reviews, by kind.",
+	}, []string{"kind"})
+	ReviewsGraded = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "languager_reviews_graded_total",
+		Help: "Review answers recorded, by result.",
+	}, []string{"result"})
+	EventErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "languager_event_errors_total",
+		Help: "Event subscribers that failed or panicked, by event and subscriber.",
+	}, []string{"event", "subscriber"})
+	ReviewsDone = factory.NewCounter(prometheus.CounterOpts{
+		Name: "languager_reviews_total",
+		Help: "Word cards graded in reviews, sessions and cloze.",
+	})
+	CleanupRuns = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "languager_cleanup_runs_total",
+		Help: "Cleanup job runs, by result.",
+	}, []string{"result"})
+	RemindersSent = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "languager_reminders_total",
+		Help: "Review reminders processed, by result.",
+	}, []string{"result"})
+	WeeklyReportsSent = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "languager_weekly_reports_total",
+		Help: "Weekly reports processed, by result.",
+	}, []string{"result"})
+)
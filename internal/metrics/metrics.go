@@ -0,0 +1,28 @@
+// Package metrics defines the application's Prometheus metrics
+// and serves them on /metrics.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultBuckets are histogram buckets in seconds suitable for bot handlers and queries
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Default is the registry exposed on /metrics
+var Default = prometheus.NewRegistry()
+
+// Handler serves the Default registry on a /metrics endpoint
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Default, promhttp.HandlerOpts{})
+}
+
+// ObserveSince records the time elapsed since start in seconds.
+// Typical use: defer metrics.ObserveSince(metrics.X.WithLabelValues(...), time.Now())
+func ObserveSince(o prometheus.Observer, start time.Time) {
+	o.Observe(time.Since(start).Seconds())
+}
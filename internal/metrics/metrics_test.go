@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveSince(t *testing.T) {
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_duration_seconds", Buckets: DefaultBuckets})
+
+	ObserveSince(h, time.Now().Add(-time.Second))
+
+	var m dto.Metric
+	require.NoError(t, h.Write(&m))
+	assert.Equal(t, uint64(1), m.GetHistogram().GetSampleCount())
+	assert.GreaterOrEqual(t, m.GetHistogram().GetSampleSum(), 1.0, "seconds, not nanoseconds")
+}
+
+func TestHandler(t *testing.T) {
+	Updates.WithLabelValues("message").Inc()
+	WordsSaved.Inc()
+	ObserveSince(CallbackDuration.WithLabelValues("view_days"), time.Now())
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(rec.Body)
+	require.NoError(t, err)
+	assert.Contains(t, families, "languager_updates_total")
+	assert.Contains(t, families, "languager_words_saved_total")
+	assert.Equal(t, uint64(1), families["languager_callback_duration_seconds"].GetMetric()[0].GetHistogram().GetSampleCount())
+}
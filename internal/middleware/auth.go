@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"languager/internal/i18n"
 	"languager/internal/service"
 
 	"go.uber.org/zap"
@@ -12,23 +13,25 @@ func AuthMiddleware(authService *service.AuthService, logger *zap.Logger) tele.M
 	return func(next tele.HandlerFunc) tele.HandlerFunc {
 		return func(c tele.Context) error {
 			userID := c.Sender().ID
+			ctx := Ctx(c)
+			tr := i18n.For(i18n.Detect(c.Sender().LanguageCode))
 
 			// Ensure user exists
-			if err := authService.EnsureUserExists(userID); err != nil {
+			if err := authService.EnsureUserExists(ctx, userID); err != nil {
 				logger.Error("Failed to ensure user exists in middleware", zap.Error(err))
-				return c.Send("Произошла ошибка. Попробуйте позже.")
+				return c.Send(tr.T("error.generic"))
 			}
 
 			// Check authorization
-			authorized, err := authService.IsAuthorized(userID)
+			authorized, err := authService.IsAuthorized(ctx, userID)
 			if err != nil {
 				logger.Error("Failed to check authorization in middleware", zap.Error(err))
-				return c.Send("Произошла ошибка. Попробуйте позже.")
+				return c.Send(tr.T("error.generic"))
 			}
 
 			// If not authorized and not /start command, prompt for password
 			if !authorized && c.Text() != "/start" {
-				return c.Send("Привет! Если ты не знаешь пароль, поздравляю - ты пукал, а коль знаешь - вводи:")
+				return c.Send(tr.T("auth.prompt"))
 			}
 
 			// User is authorized or using /start, continue
@@ -36,4 +39,3 @@ func AuthMiddleware(authService *service.AuthService, logger *zap.Logger) tele.M
 		}
 	}
 }
-
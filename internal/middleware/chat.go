@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"languager/internal/i18n"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+// IsGroup reports whether the chat is a group or a supergroup
+func IsGroup(chat *tele.Chat) bool {
+	return chat != nil && (chat.Type == tele.ChatGroup || chat.Type == tele.ChatSuperGroup)
+}
+
+// PrivateOnly creates middleware that ignores updates from groups,
+// so that group messages never reach users' words and states
+func PrivateOnly() tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			if IsGroup(c.Chat()) {
+				return nil
+			}
+			return next(c)
+		}
+	}
+}
+
+// GroupOnly creates middleware that answers group commands sent elsewhere with a hint
+func GroupOnly() tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			if !IsGroup(c.Chat()) {
+				tr := i18n.For(i18n.Detect(c.Sender().LanguageCode))
+				return c.Send(tr.T("group.only"))
+			}
+			return next(c)
+		}
+	}
+}
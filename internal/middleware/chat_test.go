@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tele "gopkg.in/telebot.v3"
+)
+
+func TestIsGroup(t *testing.T) {
+	assert.True(t, IsGroup(&tele.Chat{Type: tele.ChatGroup}))
+	assert.True(t, IsGroup(&tele.Chat{Type: tele.ChatSuperGroup}))
+	assert.False(t, IsGroup(&tele.Chat{Type: tele.ChatPrivate}))
+	assert.False(t, IsGroup(&tele.Chat{Type: tele.ChatChannel}))
+	assert.False(t, IsGroup(nil))
+}
+
+func TestPrivateOnly(t *testing.T) {
+	bot, err := tele.NewBot(tele.Settings{Offline: true})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		chat     *tele.Chat
+		expected bool
+	}{
+		{name: "private chat", chat: &tele.Chat{Type: tele.ChatPrivate}, expected: true},
+		{name: "group", chat: &tele.Chat{Type: tele.ChatGroup}, expected: false},
+		{name: "supergroup", chat: &tele.Chat{Type: tele.ChatSuperGroup}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := PrivateOnly()(func(c tele.Context) error {
+				called = true
+				return nil
+			})
+
+			c := bot.NewContext(tele.Update{Message: &tele.Message{Text: "hello", Chat: tt.chat}})
+			assert.NoError(t, handler(c))
+			assert.Equal(t, tt.expected, called)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+const requestContextKey = "request_ctx"
+
+// RequestContext creates middleware that gives every update its own context.
+// The context is cancelled when the handler returns, when the timeout expires
+// or when base is cancelled on shutdown.
+func RequestContext(base context.Context, timeout time.Duration) tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			ctx, cancel := context.WithTimeout(base, timeout)
+			defer cancel()
+
+			c.Set(requestContextKey, ctx)
+			return next(c)
+		}
+	}
+}
+
+// Ctx returns the context of the current update.
+// Falls back to context.Background() when RequestContext middleware isn't installed.
+func Ctx(c tele.Context) context.Context {
+	if ctx, ok := c.Get(requestContextKey).(context.Context); ok {
+		return ctx
+	}
+	return context.Background()
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	tele "gopkg.in/telebot.v3"
+)
+
+func newTestContext(t *testing.T) tele.Context {
+	bot, err := tele.NewBot(tele.Settings{Offline: true})
+	assert.NoError(t, err)
+	return bot.NewContext(tele.Update{Message: &tele.Message{Text: "hello"}})
+}
+
+func TestRequestContext_SetsDeadline(t *testing.T) {
+	var got context.Context
+
+	handler := RequestContext(context.Background(), time.Minute)(func(c tele.Context) error {
+		got = Ctx(c)
+		_, hasDeadline := got.Deadline()
+		assert.True(t, hasDeadline)
+		assert.NoError(t, got.Err())
+		return nil
+	})
+
+	assert.NoError(t, handler(newTestContext(t)))
+
+	// Context is released once the handler returns
+	assert.ErrorIs(t, got.Err(), context.Canceled)
+}
+
+func TestRequestContext_CancelledWithBase(t *testing.T) {
+	base, cancel := context.WithCancel(context.Background())
+
+	handler := RequestContext(base, time.Minute)(func(c tele.Context) error {
+		cancel()
+		return Ctx(c).Err()
+	})
+
+	assert.ErrorIs(t, handler(newTestContext(t)), context.Canceled)
+}
+
+func TestCtx_WithoutMiddleware(t *testing.T) {
+	ctx := Ctx(newTestContext(t))
+	assert.NotNil(t, ctx)
+	assert.NoError(t, ctx.Err())
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"strings"
+
+	"languager/internal/shutdown"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+// InFlight creates middleware that registers every running handler
+// with the shutdown coordinator, so shutdown can wait for it
+func InFlight(coordinator *shutdown.Coordinator) tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			done := coordinator.Track(updateName(c))
+			defer done()
+
+			return next(c)
+		}
+	}
+}
+
+// updateName describes an update for the shutdown report
+func updateName(c tele.Context) string {
+	if callback := c.Callback(); callback != nil {
+		if callback.Unique != "" {
+			return "callback " + callback.Unique
+		}
+		return "callback " + strings.TrimSpace(callback.Data)
+	}
+
+	text := c.Text()
+	if strings.HasPrefix(text, "/") {
+		return "command " + strings.Fields(text)[0]
+	}
+	if c.Message() != nil {
+		return "message"
+	}
+	return "update"
+}
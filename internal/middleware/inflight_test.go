@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"languager/internal/shutdown"
+
+	"github.com/stretchr/testify/assert"
+	tele "gopkg.in/telebot.v3"
+)
+
+func TestInFlight_TracksHandler(t *testing.T) {
+	coordinator := shutdown.NewCoordinator()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := InFlight(coordinator)(func(c tele.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	go handler(newTestContext(t))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	report := coordinator.Wait(ctx)
+	assert.Equal(t, []string{"message"}, report.AbandonedHandlers)
+
+	close(release)
+
+	report = coordinator.Wait(context.Background())
+	assert.True(t, report.Clean())
+}
+
+func TestUpdateName(t *testing.T) {
+	bot, err := tele.NewBot(tele.Settings{Offline: true})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		update   tele.Update
+		expected string
+	}{
+		{
+			name:     "callback with unique",
+			update:   tele.Update{Callback: &tele.Callback{Unique: "random_pair"}},
+			expected: "callback random_pair",
+		},
+		{
+			name:     "callback with data",
+			update:   tele.Update{Callback: &tele.Callback{Data: " page_2 "}},
+			expected: "callback page_2",
+		},
+		{
+			name:     "command",
+			update:   tele.Update{Message: &tele.Message{Text: "/start deck_abc"}},
+			expected: "command /start",
+		},
+		{
+			name:     "text",
+			update:   tele.Update{Message: &tele.Message{Text: "hello"}},
+			expected: "message",
+		},
+		{
+			name:     "other",
+			update:   tele.Update{},
+			expected: "update",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, updateName(bot.NewContext(tt.update)))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package cached
+
+import (
+	"sync"
+	"time"
+
+	"languager/internal/cache"
+)
+
+// guardedLRU is a per-user cache that doesn't let a stale database read
+// undo an invalidation. Callers take a generation before reading the
+// database and store the result with it; the result is dropped if an
+// invalidation happened in between. Invalidations are rare, so one counter
+// for all users is enough.
+type guardedLRU[V any] struct {
+	entries *cache.LRU[int64, V]
+
+	mu         sync.Mutex
+	generation uint64
+}
+
+func newGuardedLRU[V any](size int, ttl time.Duration) *guardedLRU[V] {
+	return &guardedLRU[V]{entries: cache.NewLRU[int64, V](size, ttl)}
+}
+
+// begin returns the generation to store a database read with
+func (c *guardedLRU[V]) begin() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+func (c *guardedLRU[V]) get(userID int64) (V, bool) {
+	return c.entries.Get(userID)
+}
+
+// store caches value unless an invalidation happened after gen was taken
+func (c *guardedLRU[V]) store(userID int64, value V, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation == gen {
+		c.entries.Set(userID, value)
+	}
+}
+
+// invalidate drops the user's entry and the results of reads in flight
+func (c *guardedLRU[V]) invalidate(userID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries.Delete(userID)
+}
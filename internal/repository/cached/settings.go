@@ -0,0 +1,47 @@
+package cached
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"languager/internal/repository"
+)
+
+// SettingsRepo is a caching decorator for repository.SettingsRepository.
+// Settings are read by several handlers and services during one update,
+// and change only through SetSetting, so reads rarely hit the database.
+type SettingsRepo struct {
+	next    repository.SettingsRepository
+	entries *guardedLRU[map[string]string]
+}
+
+// NewSettingsRepo wraps a settings repository with an in-process TTL/LRU cache
+func NewSettingsRepo(next repository.SettingsRepository, size int, ttl time.Duration) *SettingsRepo {
+	return &SettingsRepo{
+		next:    next,
+		entries: newGuardedLRU[map[string]string](size, ttl),
+	}
+}
+
+// GetSettings returns all stored preferences of the user, using the cache when possible
+func (r *SettingsRepo) GetSettings(ctx context.Context, userID int64) (map[string]string, error) {
+	gen := r.entries.begin()
+	if settings, ok := r.entries.get(userID); ok {
+		return maps.Clone(settings), nil
+	}
+
+	settings, err := r.next.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.entries.store(userID, maps.Clone(settings), gen)
+	return settings, nil
+}
+
+// SetSetting stores a preference and invalidates the cached settings
+func (r *SettingsRepo) SetSetting(ctx context.Context, userID int64, key, value string) error {
+	defer r.entries.invalidate(userID)
+	return r.next.SetSetting(ctx, userID, key, value)
+}
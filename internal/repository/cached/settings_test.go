@@ -0,0 +1,70 @@
+package cached
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsRepo_GetSettings_CachesResult(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(testutil.MockSettingsRepository)
+	mockRepo.On("GetSettings", mock.Anything, int64(123)).Return(map[string]string{"page_size": "10"}, nil).Once()
+
+	repo := NewSettingsRepo(mockRepo, 10, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		settings, err := repo.GetSettings(ctx, 123)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"page_size": "10"}, settings)
+
+		// Callers can't change what is cached
+		settings["page_size"] = "20"
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSettingsRepo_GetSettings_ErrorNotCached(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(testutil.MockSettingsRepository)
+	mockRepo.On("GetSettings", mock.Anything, int64(123)).Return(nil, fmt.Errorf("db error")).Once()
+	mockRepo.On("GetSettings", mock.Anything, int64(123)).Return(map[string]string{}, nil).Once()
+
+	repo := NewSettingsRepo(mockRepo, 10, time.Minute)
+
+	_, err := repo.GetSettings(ctx, 123)
+	assert.Error(t, err)
+
+	_, err = repo.GetSettings(ctx, 123)
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSettingsRepo_SetSetting_Invalidates(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(testutil.MockSettingsRepository)
+	mockRepo.On("GetSettings", mock.Anything, int64(123)).Return(map[string]string{}, nil).Once()
+	mockRepo.On("SetSetting", mock.Anything, int64(123), "page_size", "10").Return(nil).Once()
+	mockRepo.On("GetSettings", mock.Anything, int64(123)).Return(map[string]string{"page_size": "10"}, nil).Once()
+
+	repo := NewSettingsRepo(mockRepo, 10, time.Minute)
+
+	settings, _ := repo.GetSettings(ctx, 123)
+	assert.Empty(t, settings)
+
+	require.NoError(t, repo.SetSetting(ctx, 123, "page_size", "10"))
+
+	settings, _ = repo.GetSettings(ctx, 123)
+	assert.Equal(t, map[string]string{"page_size": "10"}, settings)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,81 @@
+package cached
+
+import (
+	"context"
+	"time"
+
+	"languager/internal/repository"
+)
+
+// userEntry is what we know about a user without asking the database
+type userEntry struct {
+	exists     bool
+	authKnown  bool
+	authorized bool
+}
+
+// UserRepo is a caching decorator for repository.UserRepository.
+// It remembers that a user exists and whether they are authorized,
+// so regular updates don't hit the database at all.
+type UserRepo struct {
+	next    repository.UserRepository
+	entries *guardedLRU[userEntry]
+}
+
+// NewUserRepo wraps a user repository with an in-process TTL/LRU cache
+func NewUserRepo(next repository.UserRepository, size int, ttl time.Duration) *UserRepo {
+	return &UserRepo{
+		next:    next,
+		entries: newGuardedLRU[userEntry](size, ttl),
+	}
+}
+
+// IsAuthorized checks if user is authorized, using the cache when possible
+func (r *UserRepo) IsAuthorized(ctx context.Context, userID int64) (bool, error) {
+	gen := r.entries.begin()
+	entry, ok := r.entries.get(userID)
+	if ok && entry.authKnown {
+		return entry.authorized, nil
+	}
+
+	authorized, err := r.next.IsAuthorized(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	entry.authKnown = true
+	entry.authorized = authorized
+	r.entries.store(userID, entry, gen)
+
+	return authorized, nil
+}
+
+// AuthorizeUser marks user as authorized and invalidates the cached entry
+func (r *UserRepo) AuthorizeUser(ctx context.Context, userID int64) error {
+	defer r.entries.invalidate(userID)
+	return r.next.AuthorizeUser(ctx, userID)
+}
+
+// RevokeUser removes user's authorization and invalidates the cached entry
+func (r *UserRepo) RevokeUser(ctx context.Context, userID int64) error {
+	defer r.entries.invalidate(userID)
+	return r.next.RevokeUser(ctx, userID)
+}
+
+// EnsureUserExists creates user if not exists, skipping users already seen
+func (r *UserRepo) EnsureUserExists(ctx context.Context, userID int64) error {
+	gen := r.entries.begin()
+	entry, ok := r.entries.get(userID)
+	if ok && entry.exists {
+		return nil
+	}
+
+	if err := r.next.EnsureUserExists(ctx, userID); err != nil {
+		return err
+	}
+
+	entry.exists = true
+	r.entries.store(userID, entry, gen)
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package cached
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserRepo_IsAuthorized_CachesResult(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(testutil.MockUserRepository)
+	mockRepo.On("IsAuthorized", mock.Anything, int64(123)).Return(true, nil).Once()
+
+	repo := NewUserRepo(mockRepo, 10, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		authorized, err := repo.IsAuthorized(ctx, 123)
+		assert.NoError(t, err)
+		assert.True(t, authorized)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserRepo_IsAuthorized_ErrorNotCached(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(testutil.MockUserRepository)
+	mockRepo.On("IsAuthorized", mock.Anything, int64(123)).Return(false, fmt.Errorf("db error")).Once()
+	mockRepo.On("IsAuthorized", mock.Anything, int64(123)).Return(true, nil).Once()
+
+	repo := NewUserRepo(mockRepo, 10, time.Minute)
+
+	_, err := repo.IsAuthorized(ctx, 123)
+	assert.Error(t, err)
+
+	authorized, err := repo.IsAuthorized(ctx, 123)
+	assert.NoError(t, err)
+	assert.True(t, authorized)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserRepo_EnsureUserExists_CachesResult(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(testutil.MockUserRepository)
+	mockRepo.On("EnsureUserExists", mock.Anything, int64(123)).Return(nil).Once()
+	mockRepo.On("IsAuthorized", mock.Anything, int64(123)).Return(false, nil).Once()
+
+	repo := NewUserRepo(mockRepo, 10, time.Minute)
+
+	assert.NoError(t, repo.EnsureUserExists(ctx, 123))
+	assert.NoError(t, repo.EnsureUserExists(ctx, 123))
+
+	// Knowing the user exists doesn't tell us whether they are authorized
+	authorized, err := repo.IsAuthorized(ctx, 123)
+	assert.NoError(t, err)
+	assert.False(t, authorized)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserRepo_AuthorizeUser_Invalidates(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(testutil.MockUserRepository)
+	mockRepo.On("IsAuthorized", mock.Anything, int64(123)).Return(false, nil).Once()
+	mockRepo.On("AuthorizeUser", mock.Anything, int64(123)).Return(nil).Once()
+	mockRepo.On("IsAuthorized", mock.Anything, int64(123)).Return(true, nil).Once()
+
+	repo := NewUserRepo(mockRepo, 10, time.Minute)
+
+	authorized, _ := repo.IsAuthorized(ctx, 123)
+	assert.False(t, authorized)
+
+	assert.NoError(t, repo.AuthorizeUser(ctx, 123))
+
+	authorized, _ = repo.IsAuthorized(ctx, 123)
+	assert.True(t, authorized)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserRepo_RevokeUser_Invalidates(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(testutil.MockUserRepository)
+	mockRepo.On("IsAuthorized", mock.Anything, int64(123)).Return(true, nil).Once()
+	mockRepo.On("RevokeUser", mock.Anything, int64(123)).Return(nil).Once()
+	mockRepo.On("IsAuthorized", mock.Anything, int64(123)).Return(false, nil).Once()
+
+	repo := NewUserRepo(mockRepo, 10, time.Minute)
+
+	authorized, _ := repo.IsAuthorized(ctx, 123)
+	assert.True(t, authorized)
+
+	assert.NoError(t, repo.RevokeUser(ctx, 123))
+
+	authorized, _ = repo.IsAuthorized(ctx, 123)
+	assert.False(t, authorized)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserRepo_RevokeDuringLoad(t *testing.T) {
+	ctx := context.Background()
+	loading := make(chan struct{})
+	revoked := make(chan struct{})
+
+	mockRepo := new(testutil.MockUserRepository)
+	// The first read sees the user before the revoke is committed
+	mockRepo.On("IsAuthorized", mock.Anything, int64(123)).Return(true, nil).Once().Run(func(mock.Arguments) {
+		close(loading)
+		<-revoked
+	})
+	mockRepo.On("RevokeUser", mock.Anything, int64(123)).Return(nil).Once()
+	mockRepo.On("IsAuthorized", mock.Anything, int64(123)).Return(false, nil).Once()
+
+	repo := NewUserRepo(mockRepo, 10, time.Minute)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		authorized, err := repo.IsAuthorized(ctx, 123)
+		assert.NoError(t, err)
+		assert.True(t, authorized)
+	}()
+
+	<-loading
+	assert.NoError(t, repo.RevokeUser(ctx, 123))
+	close(revoked)
+	wg.Wait()
+
+	// The stale read must not have been cached over the invalidation
+	authorized, err := repo.IsAuthorized(ctx, 123)
+	assert.NoError(t, err)
+	assert.False(t, authorized)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserRepo_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(testutil.MockUserRepository)
+	mockRepo.On("IsAuthorized", mock.Anything, mock.Anything).Return(true, nil)
+	mockRepo.On("EnsureUserExists", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("AuthorizeUser", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("RevokeUser", mock.Anything, mock.Anything).Return(nil)
+
+	repo := NewUserRepo(mockRepo, 10, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				userID := int64(j % 3)
+				switch (i + j) % 4 {
+				case 0:
+					assert.NoError(t, repo.AuthorizeUser(ctx, userID))
+				case 1:
+					assert.NoError(t, repo.RevokeUser(ctx, userID))
+				case 2:
+					assert.NoError(t, repo.EnsureUserExists(ctx, userID))
+				default:
+					_, err := repo.IsAuthorized(ctx, userID)
+					assert.NoError(t, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// countingUserRepo counts database round-trips made by the decorated repository
+type countingUserRepo struct {
+	queries int
+}
+
+func (r *countingUserRepo) IsAuthorized(ctx context.Context, userID int64) (bool, error) {
+	r.queries++
+	return true, nil
+}
+
+func (r *countingUserRepo) AuthorizeUser(ctx context.Context, userID int64) error {
+	r.queries++
+	return nil
+}
+
+func (r *countingUserRepo) RevokeUser(ctx context.Context, userID int64) error {
+	r.queries++
+	return nil
+}
+
+func (r *countingUserRepo) EnsureUserExists(ctx context.Context, userID int64) error {
+	r.queries++
+	return nil
+}
+
+// simulateUpdate performs the same user lookups a text message does
+func simulateUpdate(b *testing.B, repo interface {
+	EnsureUserExists(context.Context, int64) error
+	IsAuthorized(context.Context, int64) (bool, error)
+}, userID int64) {
+	ctx := context.Background()
+
+	if err := repo.EnsureUserExists(ctx, userID); err != nil {
+		b.Fatal(err)
+	}
+	if _, err := repo.IsAuthorized(ctx, userID); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkUpdate_Uncached(b *testing.B) {
+	db := &countingUserRepo{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		simulateUpdate(b, db, int64(i%100))
+	}
+
+	b.ReportMetric(float64(db.queries)/float64(b.N), "queries/op")
+}
+
+func BenchmarkUpdate_Cached(b *testing.B) {
+	db := &countingUserRepo{}
+	repo := NewUserRepo(db, 1000, time.Minute)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		simulateUpdate(b, repo, int64(i%100))
+	}
+
+	b.ReportMetric(float64(db.queries)/float64(b.N), "queries/op")
+}
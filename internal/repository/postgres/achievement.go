@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"languager/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// AchievementRepo implements repository.AchievementRepository
+type AchievementRepo struct {
+	db *sql.DB
+}
+
+// NewAchievementRepo creates a new achievement repository
+func NewAchievementRepo(db *sql.DB) *AchievementRepo {
+	return &AchievementRepo{db: db}
+}
+
+// GetProgress returns the user's stored metrics. Words added are counted by
+// activity, so that cleaned up words still count, or by words for ones added
+// before activity was tracked.
+func (r *AchievementRepo) GetProgress(ctx context.Context, userID int64) (domain.AchievementProgress, error) {
+	defer observeQuery("get_achievement_progress", time.Now())
+
+	query := `
+		SELECT
+			GREATEST(
+				COALESCE((SELECT SUM(words_added) FROM user_activity WHERE user_id = $1), 0),
+				(SELECT COUNT(*) FROM words WHERE user_id = $1)
+			),
+			(SELECT COUNT(*) FROM reviews WHERE user_id = $1 AND correct),
+			COALESCE((SELECT best_streak FROM user_streaks WHERE user_id = $1), 0)
+	`
+	var words, correct, streak int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&words, &correct, &streak); err != nil {
+		return nil, err
+	}
+
+	return domain.AchievementProgress{
+		domain.MetricWordsAdded:     words,
+		domain.MetricCorrectAnswers: correct,
+		domain.MetricBestStreak:     streak,
+	}, nil
+}
+
+// ListEarned returns when each earned achievement was earned, by ID
+func (r *AchievementRepo) ListEarned(ctx context.Context, userID int64) (map[string]time.Time, error) {
+	defer observeQuery("list_earned_achievements", time.Now())
+
+	query := `SELECT achievement, earned_at FROM user_achievements WHERE user_id = $1`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	earned := make(map[string]time.Time)
+	for rows.Next() {
+		var id string
+		var at time.Time
+		if err := rows.Scan(&id, &at); err != nil {
+			return nil, err
+		}
+		earned[id] = at
+	}
+	return earned, rows.Err()
+}
+
+// SaveEarned stores the achievements and returns IDs of the ones not earned before.
+// Concurrent updates announce each achievement once.
+func (r *AchievementRepo) SaveEarned(ctx context.Context, userID int64, ids []string) ([]string, error) {
+	defer observeQuery("save_earned_achievements", time.Now())
+
+	query := `
+		INSERT INTO user_achievements (user_id, achievement)
+		SELECT $1, UNNEST($2::TEXT[])
+		ON CONFLICT DO NOTHING
+		RETURNING achievement
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var saved []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		saved = append(saved, id)
+	}
+	return saved, rows.Err()
+}
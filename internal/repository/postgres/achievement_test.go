@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAchievementRepo_GetProgress(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAchievementRepo(db)
+
+	mock.ExpectQuery("SELECT GREATEST\\((.+)user_activity(.+)reviews(.+)user_streaks").
+		WithArgs(int64(123)).
+		WillReturnRows(sqlmock.NewRows([]string{"words", "correct", "streak"}).AddRow(120, 340, 9))
+
+	progress, err := repo.GetProgress(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AchievementProgress{
+		domain.MetricWordsAdded:     120,
+		domain.MetricCorrectAnswers: 340,
+		domain.MetricBestStreak:     9,
+	}, progress)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAchievementRepo_ListEarned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAchievementRepo(db)
+	earnedAt := time.Date(2024, 12, 12, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT achievement, earned_at FROM user_achievements WHERE user_id = \\$1").
+		WithArgs(int64(123)).
+		WillReturnRows(sqlmock.NewRows([]string{"achievement", "earned_at"}).AddRow("first_word", earnedAt))
+
+	earned, err := repo.ListEarned(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"first_word": earnedAt}, earned)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAchievementRepo_SaveEarned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAchievementRepo(db)
+
+	// streak_7 was saved by a concurrent update
+	mock.ExpectQuery("INSERT INTO user_achievements \\(user_id, achievement\\) SELECT \\$1, UNNEST\\(\\$2::TEXT\\[\\]\\) ON CONFLICT DO NOTHING RETURNING achievement").
+		WithArgs(int64(123), pq.Array([]string{"words_100", "streak_7"})).
+		WillReturnRows(sqlmock.NewRows([]string{"achievement"}).AddRow("words_100"))
+
+	saved, err := repo.SaveEarned(context.Background(), 123, []string{"words_100", "streak_7"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"words_100"}, saved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"languager/internal/domain"
+)
+
+// AuditRepo implements repository.AuditRepository
+type AuditRepo struct {
+	db *sql.DB
+}
+
+// NewAuditRepo creates a new audit log repository
+func NewAuditRepo(db *sql.DB) *AuditRepo {
+	return &AuditRepo{db: db}
+}
+
+// Save appends the entry to the log and sets its ID and time
+func (r *AuditRepo) Save(ctx context.Context, entry *domain.AuditEntry) error {
+	defer observeQuery("save_audit_entry", time.Now())
+
+	metadata := []byte("{}")
+	if len(entry.Metadata) > 0 {
+		var err error
+		if metadata, err = json.Marshal(entry.Metadata); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO audit_log (actor_id, action, target, metadata)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowContext(ctx, query, entry.ActorID, string(entry.Action), entry.Target, metadata).
+		Scan(&entry.ID, &entry.CreatedAt)
+}
+
+// List returns entries matching the filter, newest first. A user matches
+// entries they did and entries done to them.
+func (r *AuditRepo) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	defer observeQuery("list_audit_entries", time.Now())
+
+	query := `
+		SELECT id, actor_id, action, target, metadata, created_at
+		FROM audit_log
+		WHERE ($1::BIGINT = 0 OR actor_id = $1 OR target = $2)
+		  AND ($3::TEXT = '' OR action = $3)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`
+	rows, err := r.db.QueryContext(ctx, query,
+		filter.UserID, domain.AuditUser(filter.UserID), string(filter.Action), filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.AuditEntry
+	for rows.Next() {
+		var e domain.AuditEntry
+		var action string
+		var metadata []byte
+		if err := rows.Scan(&e.ID, &e.ActorID, &action, &e.Target, &metadata, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Action = domain.AuditAction(action)
+		if err := json.Unmarshal(metadata, &e.Metadata); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepo_Save(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAuditRepo(db)
+	createdAt := time.Date(2024, 12, 12, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("INSERT INTO audit_log \\(actor_id, action, target, metadata\\)").
+		WithArgs(int64(123), "deck_imported", "deck:abcd2345", []byte(`{"words":5}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, createdAt))
+
+	entry := &domain.AuditEntry{
+		ActorID:  123,
+		Action:   domain.AuditDeckImported,
+		Target:   domain.AuditDeck("abcd2345"),
+		Metadata: map[string]any{"words": 5},
+	}
+	err = repo.Save(context.Background(), entry)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), entry.ID)
+	assert.Equal(t, createdAt, entry.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditRepo_Save_NoMetadata(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAuditRepo(db)
+
+	mock.ExpectQuery("INSERT INTO audit_log").
+		WithArgs(int64(123), "auth_granted", "user:123", []byte("{}")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(8, time.Now()))
+
+	err = repo.Save(context.Background(), &domain.AuditEntry{
+		ActorID: 123,
+		Action:  domain.AuditAuthGranted,
+		Target:  domain.AuditUser(123),
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditRepo_List(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAuditRepo(db)
+	createdAt := time.Date(2024, 12, 12, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT id, actor_id, action, target, metadata, created_at FROM audit_log").
+		WithArgs(int64(123), "user:123", "auth_failed", 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor_id", "action", "target", "metadata", "created_at"}).
+			AddRow(9, 123, "auth_failed", "user:123", []byte(`{"username":"bob"}`), createdAt))
+
+	entries, err := repo.List(context.Background(), domain.AuditFilter{UserID: 123, Action: domain.AuditAuthFailed, Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AuditEntry{{
+		ID:        9,
+		ActorID:   123,
+		Action:    domain.AuditAuthFailed,
+		Target:    "user:123",
+		Metadata:  map[string]any{"username": "bob"},
+		CreatedAt: createdAt,
+	}}, entries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"languager/internal/domain"
+)
+
+// DeckRepo implements repository.DeckRepository
+type DeckRepo struct {
+	db *sql.DB
+}
+
+// NewDeckRepo creates a new deck repository
+func NewDeckRepo(db *sql.DB) *DeckRepo {
+	return &DeckRepo{db: db}
+}
+
+// deckColumns selects a deck with its word and subscriber counts
+const deckColumns = `
+	d.id, d.owner_id, d.code, d.name, d.source_lang, d.target_lang, d.created_at,
+	(SELECT COUNT(*) FROM deck_words dw WHERE dw.deck_id = d.id),
+	(SELECT COUNT(*) FROM deck_subscriptions s WHERE s.deck_id = d.id)
+`
+
+// CreateDeck stores the deck with the owner's current words of its pair
+func (r *DeckRepo) CreateDeck(ctx context.Context, deck *domain.Deck) error {
+	defer observeQuery("create_deck", time.Now())
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insert := `
+		INSERT INTO decks (owner_id, code, name, source_lang, target_lang)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRowContext(ctx, insert, deck.OwnerID, deck.Code, deck.Name, deck.Pair.Source, deck.Pair.Target).
+		Scan(&deck.ID, &deck.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	words := `
+		INSERT INTO deck_words (deck_id, word_id)
+		SELECT $1, id
+		FROM words
+		WHERE user_id = $2 AND source_lang = $3 AND target_lang = $4
+	`
+	res, err := tx.ExecContext(ctx, words, deck.ID, deck.OwnerID, deck.Pair.Source, deck.Pair.Target)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	deck.WordCount = int(n)
+
+	return tx.Commit()
+}
+
+// GetDeckByCode returns the deck with the share code, nil if there is none
+func (r *DeckRepo) GetDeckByCode(ctx context.Context, code string) (*domain.Deck, error) {
+	defer observeQuery("get_deck_by_code", time.Now())
+
+	query := `SELECT ` + deckColumns + ` FROM decks d WHERE d.code = $1`
+
+	deck, err := scanDeck(r.db.QueryRowContext(ctx, query, code))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return deck, nil
+}
+
+// ListOwnDecks returns decks published by the user, oldest first
+func (r *DeckRepo) ListOwnDecks(ctx context.Context, ownerID int64) ([]domain.Deck, error) {
+	defer observeQuery("list_own_decks", time.Now())
+
+	query := `SELECT ` + deckColumns + ` FROM decks d WHERE d.owner_id = $1 ORDER BY d.created_at, d.id`
+	return r.queryDecks(ctx, query, ownerID)
+}
+
+// ListSubscribedDecks returns decks the user is subscribed to, oldest subscription first
+func (r *DeckRepo) ListSubscribedDecks(ctx context.Context, userID int64) ([]domain.Deck, error) {
+	defer observeQuery("list_subscribed_decks", time.Now())
+
+	query := `
+		SELECT ` + deckColumns + `
+		FROM decks d
+		JOIN deck_subscriptions sub ON sub.deck_id = d.id
+		WHERE sub.user_id = $1
+		ORDER BY sub.created_at, d.id
+	`
+	return r.queryDecks(ctx, query, userID)
+}
+
+// DeleteDeck deletes the deck; copied words stay with subscribers
+func (r *DeckRepo) DeleteDeck(ctx context.Context, deckID int) error {
+	defer observeQuery("delete_deck", time.Now())
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM decks WHERE id = $1`, deckID)
+	return err
+}
+
+// GetSubscription returns the user's subscription to the deck, nil if there is none
+func (r *DeckRepo) GetSubscription(ctx context.Context, deckID int, userID int64) (*domain.DeckSubscription, error) {
+	defer observeQuery("get_deck_subscription", time.Now())
+
+	query := `SELECT follow FROM deck_subscriptions WHERE deck_id = $1 AND user_id = $2`
+
+	sub := domain.DeckSubscription{DeckID: deckID, UserID: userID}
+	err := r.db.QueryRowContext(ctx, query, deckID, userID).Scan(&sub.Follow)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// SaveSubscription subscribes the user or updates the subscription
+func (r *DeckRepo) SaveSubscription(ctx context.Context, sub domain.DeckSubscription) error {
+	defer observeQuery("save_deck_subscription", time.Now())
+
+	query := `
+		INSERT INTO deck_subscriptions (deck_id, user_id, follow)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (deck_id, user_id)
+		DO UPDATE SET follow = EXCLUDED.follow
+	`
+	_, err := r.db.ExecContext(ctx, query, sub.DeckID, sub.UserID, sub.Follow)
+	return err
+}
+
+// DeleteSubscription unsubscribes the user; copied words stay
+func (r *DeckRepo) DeleteSubscription(ctx context.Context, deckID int, userID int64) error {
+	defer observeQuery("delete_deck_subscription", time.Now())
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM deck_subscriptions WHERE deck_id = $1 AND user_id = $2`, deckID, userID)
+	return err
+}
+
+// CopyDeckWords copies deck words into the user's words of the deck's pair,
+// skipping words the user already has a copy of. Returns how many were copied.
+func (r *DeckRepo) CopyDeckWords(ctx context.Context, deckID int, userID int64) (int, error) {
+	defer observeQuery("copy_deck_words", time.Now())
+
+	query := `
+		INSERT INTO words (user_id, word, translation, source_lang, target_lang, copied_from)
+		SELECT $2, w.word, w.translation, d.source_lang, d.target_lang, w.id
+		FROM deck_words dw
+		JOIN decks d ON d.id = dw.deck_id
+		JOIN words w ON w.id = dw.word_id
+		WHERE dw.deck_id = $1
+			AND NOT EXISTS (SELECT 1 FROM words c WHERE c.user_id = $2 AND c.copied_from = w.id)
+		ORDER BY w.created_at, w.id
+	`
+	res, err := r.db.ExecContext(ctx, query, deckID, userID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// AddWordToDecks adds the owner's word to their decks of the pair and returns the deck IDs
+func (r *DeckRepo) AddWordToDecks(ctx context.Context, ownerID int64, pair domain.LanguagePair, wordID int) ([]int, error) {
+	defer observeQuery("add_word_to_decks", time.Now())
+
+	query := `
+		INSERT INTO deck_words (deck_id, word_id)
+		SELECT id, $4
+		FROM decks
+		WHERE owner_id = $1 AND source_lang = $2 AND target_lang = $3
+		ON CONFLICT DO NOTHING
+		RETURNING deck_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, ownerID, pair.Source, pair.Target, wordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// CopyWordToFollowers copies a deck word to every subscriber following the deck
+// who has no copy of it yet. Returns how many copies were made.
+func (r *DeckRepo) CopyWordToFollowers(ctx context.Context, deckID, wordID int) (int, error) {
+	defer observeQuery("copy_word_to_followers", time.Now())
+
+	query := `
+		INSERT INTO words (user_id, word, translation, source_lang, target_lang, copied_from)
+		SELECT s.user_id, w.word, w.translation, d.source_lang, d.target_lang, w.id
+		FROM deck_subscriptions s
+		JOIN decks d ON d.id = s.deck_id
+		JOIN words w ON w.id = $2
+		WHERE s.deck_id = $1 AND s.follow
+			AND NOT EXISTS (SELECT 1 FROM words c WHERE c.user_id = s.user_id AND c.copied_from = w.id)
+	`
+	res, err := r.db.ExecContext(ctx, query, deckID, wordID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *DeckRepo) queryDecks(ctx context.Context, query string, userID int64) ([]domain.Deck, error) {
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var decks []domain.Deck
+	for rows.Next() {
+		deck, err := scanDeck(rows)
+		if err != nil {
+			return nil, err
+		}
+		decks = append(decks, *deck)
+	}
+	return decks, rows.Err()
+}
+
+func scanDeck(row rowScanner) (*domain.Deck, error) {
+	var d domain.Deck
+	err := row.Scan(&d.ID, &d.OwnerID, &d.Code, &d.Name, &d.Pair.Source, &d.Pair.Target, &d.CreatedAt, &d.WordCount, &d.Subscribers)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
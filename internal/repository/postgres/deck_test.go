@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var deckRowColumns = []string{"id", "owner_id", "code", "name", "source_lang", "target_lang", "created_at", "words", "subscribers"}
+
+func TestDeckRepo_CreateDeck(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDeckRepo(db)
+	created := time.Date(2024, 12, 12, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO decks \\(owner_id, code, name, source_lang, target_lang\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5\\) RETURNING id, created_at").
+		WithArgs(int64(123), "abc234de", "Course", "en", "ru").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, created))
+	mock.ExpectExec("INSERT INTO deck_words \\(deck_id, word_id\\) SELECT \\$1, id FROM words WHERE user_id = \\$2 AND source_lang = \\$3 AND target_lang = \\$4").
+		WithArgs(5, int64(123), "en", "ru").
+		WillReturnResult(sqlmock.NewResult(0, 12))
+	mock.ExpectCommit()
+
+	deck := &domain.Deck{OwnerID: 123, Code: "abc234de", Name: "Course", Pair: domain.DefaultLanguagePair}
+	err = repo.CreateDeck(context.Background(), deck)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, deck.ID)
+	assert.Equal(t, created, deck.CreatedAt)
+	assert.Equal(t, 12, deck.WordCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeckRepo_CreateDeck_DuplicateRollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDeckRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO decks").WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	err = repo.CreateDeck(context.Background(), &domain.Deck{OwnerID: 123, Code: "abc234de", Pair: domain.DefaultLanguagePair})
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeckRepo_GetDeckByCode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDeckRepo(db)
+	created := time.Date(2024, 12, 12, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT (.+) FROM decks d WHERE d.code = \\$1").
+		WithArgs("abc234de").
+		WillReturnRows(sqlmock.NewRows(deckRowColumns).AddRow(5, 123, "abc234de", "Course", "de", "ru", created, 12, 3))
+
+	deck, err := repo.GetDeckByCode(context.Background(), "abc234de")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Deck{
+		ID: 5, OwnerID: 123, Code: "abc234de", Name: "Course",
+		Pair:      domain.LanguagePair{Source: "de", Target: "ru"},
+		WordCount: 12, Subscribers: 3, CreatedAt: created,
+	}, deck)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeckRepo_GetDeckByCode_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDeckRepo(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM decks d WHERE d.code = \\$1").
+		WithArgs("nope").
+		WillReturnError(sql.ErrNoRows)
+
+	deck, err := repo.GetDeckByCode(context.Background(), "nope")
+
+	assert.NoError(t, err)
+	assert.Nil(t, deck)
+}
+
+func TestDeckRepo_ListSubscribedDecks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDeckRepo(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM decks d JOIN deck_subscriptions sub ON sub.deck_id = d.id WHERE sub.user_id = \\$1").
+		WithArgs(int64(456)).
+		WillReturnRows(sqlmock.NewRows(deckRowColumns).
+			AddRow(5, 123, "abc234de", "Course", "en", "ru", time.Now(), 12, 3).
+			AddRow(6, 789, "xyz234de", "Verbs", "de", "ru", time.Now(), 4, 1))
+
+	decks, err := repo.ListSubscribedDecks(context.Background(), 456)
+
+	assert.NoError(t, err)
+	assert.Len(t, decks, 2)
+	assert.Equal(t, "Verbs", decks[1].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeckRepo_GetSubscription(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDeckRepo(db)
+
+	mock.ExpectQuery("SELECT follow FROM deck_subscriptions WHERE deck_id = \\$1 AND user_id = \\$2").
+		WithArgs(5, int64(456)).
+		WillReturnRows(sqlmock.NewRows([]string{"follow"}).AddRow(false))
+	mock.ExpectQuery("SELECT follow FROM deck_subscriptions").
+		WithArgs(6, int64(456)).
+		WillReturnError(sql.ErrNoRows)
+
+	sub, err := repo.GetSubscription(context.Background(), 5, 456)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.DeckSubscription{DeckID: 5, UserID: 456, Follow: false}, sub)
+
+	sub, err = repo.GetSubscription(context.Background(), 6, 456)
+	assert.NoError(t, err)
+	assert.Nil(t, sub)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeckRepo_SaveSubscription(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDeckRepo(db)
+
+	mock.ExpectExec("INSERT INTO deck_subscriptions \\(deck_id, user_id, follow\\) VALUES \\(\\$1, \\$2, \\$3\\) ON CONFLICT \\(deck_id, user_id\\) DO UPDATE SET follow = EXCLUDED.follow").
+		WithArgs(5, int64(456), true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SaveSubscription(context.Background(), domain.DeckSubscription{DeckID: 5, UserID: 456, Follow: true})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeckRepo_CopyDeckWords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDeckRepo(db)
+
+	mock.ExpectExec("INSERT INTO words \\(user_id, word, translation, source_lang, target_lang, copied_from\\) SELECT \\$2, (.+) FROM deck_words dw (.+) WHERE dw.deck_id = \\$1 AND NOT EXISTS \\(SELECT 1 FROM words c WHERE c.user_id = \\$2 AND c.copied_from = w.id\\)").
+		WithArgs(5, int64(456)).
+		WillReturnResult(sqlmock.NewResult(0, 7))
+
+	copied, err := repo.CopyDeckWords(context.Background(), 5, 456)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, copied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeckRepo_AddWordToDecks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDeckRepo(db)
+
+	mock.ExpectQuery("INSERT INTO deck_words \\(deck_id, word_id\\) SELECT id, \\$4 FROM decks WHERE owner_id = \\$1 AND source_lang = \\$2 AND target_lang = \\$3 ON CONFLICT DO NOTHING RETURNING deck_id").
+		WithArgs(int64(123), "en", "ru", 42).
+		WillReturnRows(sqlmock.NewRows([]string{"deck_id"}).AddRow(5))
+
+	ids, err := repo.AddWordToDecks(context.Background(), 123, domain.DefaultLanguagePair, 42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeckRepo_CopyWordToFollowers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDeckRepo(db)
+
+	mock.ExpectExec("INSERT INTO words (.+) FROM deck_subscriptions s (.+) JOIN words w ON w.id = \\$2 WHERE s.deck_id = \\$1 AND s.follow").
+		WithArgs(5, 42).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	copied, err := repo.CopyWordToFollowers(context.Background(), 5, 42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, copied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
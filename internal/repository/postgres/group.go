@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"languager/internal/domain"
+)
+
+// GroupRepo implements repository.GroupRepository
+type GroupRepo struct {
+	db *sql.DB
+}
+
+// NewGroupRepo creates a new group repository
+func NewGroupRepo(db *sql.DB) *GroupRepo {
+	return &GroupRepo{db: db}
+}
+
+// SaveGroup enables the bot in the chat or updates its title
+func (r *GroupRepo) SaveGroup(ctx context.Context, chatID int64, title string, enabledBy int64) error {
+	defer observeQuery("save_group", time.Now())
+
+	query := `
+		INSERT INTO group_chats (chat_id, title, enabled_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id) DO UPDATE SET title = EXCLUDED.title
+	`
+	_, err := r.db.ExecContext(ctx, query, chatID, title, enabledBy)
+	return err
+}
+
+// GroupExists reports whether the bot was enabled in the chat
+func (r *GroupRepo) GroupExists(ctx context.Context, chatID int64) (bool, error) {
+	defer observeQuery("group_exists", time.Now())
+
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM group_chats WHERE chat_id = $1)`
+	err := r.db.QueryRowContext(ctx, query, chatID).Scan(&exists)
+	return exists, err
+}
+
+// AddWord stores the word and sets its ID and CreatedAt; false if the group has it already
+func (r *GroupRepo) AddWord(ctx context.Context, word *domain.GroupWord) (bool, error) {
+	defer observeQuery("add_group_word", time.Now())
+
+	query := `
+		INSERT INTO group_words (chat_id, added_by, word, translation)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRowContext(ctx, query, word.ChatID, word.AddedBy, word.Word, word.Translation).
+		Scan(&word.ID, &word.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetRandomWords returns up to limit random words of the group
+func (r *GroupRepo) GetRandomWords(ctx context.Context, chatID int64, limit int) ([]domain.GroupWord, error) {
+	defer observeQuery("get_random_group_words", time.Now())
+
+	query := `
+		SELECT id, chat_id, added_by, word, translation, created_at
+		FROM group_words
+		WHERE chat_id = $1
+		ORDER BY RANDOM()
+		LIMIT $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, chatID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []domain.GroupWord
+	for rows.Next() {
+		var w domain.GroupWord
+		if err := rows.Scan(&w.ID, &w.ChatID, &w.AddedBy, &w.Word, &w.Translation, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		words = append(words, w)
+	}
+	return words, rows.Err()
+}
+
+// CreateQuiz stores a new quiz about the word and returns its ID
+func (r *GroupRepo) CreateQuiz(ctx context.Context, chatID int64, wordID int) (int, error) {
+	defer observeQuery("create_group_quiz", time.Now())
+
+	var id int
+	query := `INSERT INTO group_quizzes (chat_id, word_id) VALUES ($1, $2) RETURNING id`
+	err := r.db.QueryRowContext(ctx, query, chatID, wordID).Scan(&id)
+	return id, err
+}
+
+// GetQuiz returns the quiz with its word, nil if the group has no such quiz
+func (r *GroupRepo) GetQuiz(ctx context.Context, chatID int64, quizID int) (*domain.GroupQuiz, error) {
+	defer observeQuery("get_group_quiz", time.Now())
+
+	query := `
+		SELECT q.id, q.chat_id, COALESCE(q.winner_id, 0),
+			w.id, w.chat_id, w.added_by, w.word, w.translation, w.created_at
+		FROM group_quizzes q
+		JOIN group_words w ON w.id = q.word_id
+		WHERE q.id = $1 AND q.chat_id = $2
+	`
+	var q domain.GroupQuiz
+	err := r.db.QueryRowContext(ctx, query, quizID, chatID).Scan(
+		&q.ID, &q.ChatID, &q.WinnerID,
+		&q.Word.ID, &q.Word.ChatID, &q.Word.AddedBy, &q.Word.Word, &q.Word.Translation, &q.Word.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+// RecordAttempt marks the member's answer; false if they answered already
+func (r *GroupRepo) RecordAttempt(ctx context.Context, quizID int, userID int64) (bool, error) {
+	defer observeQuery("record_group_quiz_attempt", time.Now())
+
+	query := `
+		INSERT INTO group_quiz_attempts (quiz_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	res, err := r.db.ExecContext(ctx, query, quizID, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// ClaimWin makes the member the winner of an unsolved quiz and gives them a point.
+// Only one of concurrent claims updates the quiz, the others get false.
+func (r *GroupRepo) ClaimWin(ctx context.Context, chatID int64, quizID int, userID int64, name string) (bool, error) {
+	defer observeQuery("claim_group_quiz_win", time.Now())
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	claim := `
+		UPDATE group_quizzes
+		SET winner_id = $3, solved_at = NOW()
+		WHERE id = $1 AND chat_id = $2 AND winner_id IS NULL
+	`
+	res, err := tx.ExecContext(ctx, claim, quizID, chatID, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	score := `
+		INSERT INTO group_scores (chat_id, user_id, name, points)
+		VALUES ($1, $2, $3, 1)
+		ON CONFLICT (chat_id, user_id) DO UPDATE
+		SET points = group_scores.points + 1, name = EXCLUDED.name
+	`
+	if _, err := tx.ExecContext(ctx, score, chatID, userID, name); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// GetTopScores returns the group's leaderboard, best first
+func (r *GroupRepo) GetTopScores(ctx context.Context, chatID int64, limit int) ([]domain.GroupScore, error) {
+	defer observeQuery("get_group_top_scores", time.Now())
+
+	query := `
+		SELECT user_id, name, points
+		FROM group_scores
+		WHERE chat_id = $1 AND points > 0
+		ORDER BY points DESC, name
+		LIMIT $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, chatID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []domain.GroupScore
+	for rows.Next() {
+		var s domain.GroupScore
+		if err := rows.Scan(&s.UserID, &s.Name, &s.Points); err != nil {
+			return nil, err
+		}
+		scores = append(scores, s)
+	}
+	return scores, rows.Err()
+}
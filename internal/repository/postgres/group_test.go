@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupRepo_AddWord(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGroupRepo(db)
+	created := time.Date(2024, 12, 12, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("INSERT INTO group_words \\(chat_id, added_by, word, translation\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) ON CONFLICT DO NOTHING RETURNING id, created_at").
+		WithArgs(int64(-100), int64(123), "apple", "яблоко").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, created))
+
+	word := &domain.GroupWord{ChatID: -100, AddedBy: 123, Word: "apple", Translation: "яблоко"}
+	added, err := repo.AddWord(context.Background(), word)
+
+	assert.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, 7, word.ID)
+	assert.Equal(t, created, word.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGroupRepo_AddWord_Duplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGroupRepo(db)
+
+	mock.ExpectQuery("INSERT INTO group_words").
+		WillReturnError(sql.ErrNoRows)
+
+	added, err := repo.AddWord(context.Background(), &domain.GroupWord{ChatID: -100, Word: "apple", Translation: "яблоко"})
+
+	assert.NoError(t, err)
+	assert.False(t, added)
+}
+
+func TestGroupRepo_GetQuiz(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGroupRepo(db)
+	created := time.Date(2024, 12, 12, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT (.+) FROM group_quizzes q JOIN group_words w ON w.id = q.word_id WHERE q.id = \\$1 AND q.chat_id = \\$2").
+		WithArgs(3, int64(-100)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id", "winner_id", "word_id", "word_chat_id", "added_by", "word", "translation", "created_at"}).
+			AddRow(3, -100, 0, 7, -100, 123, "apple", "яблоко", created))
+
+	quiz, err := repo.GetQuiz(context.Background(), -100, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.GroupQuiz{
+		ID:     3,
+		ChatID: -100,
+		Word:   domain.GroupWord{ID: 7, ChatID: -100, AddedBy: 123, Word: "apple", Translation: "яблоко", CreatedAt: created},
+	}, quiz)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGroupRepo_ClaimWin(t *testing.T) {
+	tests := []struct {
+		name    string
+		updated int64
+		won     bool
+	}{
+		{name: "first correct answer wins", updated: 1, won: true},
+		{name: "quiz solved already", updated: 0, won: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewGroupRepo(db)
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE group_quizzes SET winner_id = \\$3, solved_at = NOW\\(\\) WHERE id = \\$1 AND chat_id = \\$2 AND winner_id IS NULL").
+				WithArgs(3, int64(-100), int64(123)).
+				WillReturnResult(sqlmock.NewResult(0, tt.updated))
+			if tt.won {
+				mock.ExpectExec("INSERT INTO group_scores (.+) ON CONFLICT \\(chat_id, user_id\\) DO UPDATE").
+					WithArgs(int64(-100), int64(123), "Alice").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			won, err := repo.ClaimWin(context.Background(), -100, 3, 123, "Alice")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.won, won)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGroupRepo_GetTopScores(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGroupRepo(db)
+
+	mock.ExpectQuery("SELECT user_id, name, points FROM group_scores WHERE chat_id = \\$1 AND points > 0 ORDER BY points DESC, name LIMIT \\$2").
+		WithArgs(int64(-100), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name", "points"}).
+			AddRow(123, "Alice", 5).
+			AddRow(456, "Bob", 2))
+
+	scores, err := repo.GetTopScores(context.Background(), -100, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.GroupScore{
+		{UserID: 123, Name: "Alice", Points: 5},
+		{UserID: 456, Name: "Bob", Points: 2},
+	}, scores)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
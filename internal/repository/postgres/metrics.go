@@ -0,0 +1,12 @@
+package postgres
+
+import (
+	"time"
+
+	"languager/internal/metrics"
+)
+
+// observeQuery records query duration; use as defer observeQuery("name", time.Now())
+func observeQuery(query string, start time.Time) {
+	metrics.ObserveSince(metrics.DBQueryDuration.WithLabelValues(query), start)
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"languager/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// ReminderRepo implements repository.ReminderRepository
+type ReminderRepo struct {
+	db *sql.DB
+}
+
+// NewReminderRepo creates a new reminder repository
+func NewReminderRepo(db *sql.DB) *ReminderRepo {
+	return &ReminderRepo{db: db}
+}
+
+// GetReminderSettings returns user's reminder schedule or nil if not configured
+func (r *ReminderRepo) GetReminderSettings(ctx context.Context, userID int64) (*domain.ReminderSettings, error) {
+	defer observeQuery("get_reminder_settings", time.Now())
+
+	query := `
+		SELECT user_id, times, days, paused
+		FROM reminder_settings
+		WHERE user_id = $1
+	`
+
+	s, err := scanReminderSettings(r.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SaveReminderSettings creates or replaces user's reminder schedule
+func (r *ReminderRepo) SaveReminderSettings(ctx context.Context, settings *domain.ReminderSettings) error {
+	defer observeQuery("save_reminder_settings", time.Now())
+
+	times := make([]int64, len(settings.Times))
+	for i, t := range settings.Times {
+		times[i] = int64(t)
+	}
+
+	query := `
+		INSERT INTO reminder_settings (user_id, times, days, paused, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id)
+		DO UPDATE SET times = EXCLUDED.times, days = EXCLUDED.days,
+			paused = EXCLUDED.paused, updated_at = NOW()
+	`
+	_, err := r.db.ExecContext(ctx, query, settings.UserID, pq.Array(times), settings.Days, settings.Paused)
+	return err
+}
+
+// ListActiveReminders returns unpaused schedules of authorized users
+func (r *ReminderRepo) ListActiveReminders(ctx context.Context) ([]domain.ReminderSettings, error) {
+	defer observeQuery("list_active_reminders", time.Now())
+
+	query := `
+		SELECT rs.user_id, rs.times, rs.days, rs.paused
+		FROM reminder_settings rs
+		JOIN users u ON u.user_id = rs.user_id
+		WHERE u.authorized = TRUE
+			AND rs.paused = FALSE
+			AND cardinality(rs.times) > 0
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.ReminderSettings
+	for rows.Next() {
+		s, err := scanReminderSettings(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *s)
+	}
+
+	return list, rows.Err()
+}
+
+// ClaimDelivery records a reminder slot as sent.
+// Returns false if the slot was already claimed, e.g. before a restart.
+func (r *ReminderRepo) ClaimDelivery(ctx context.Context, userID int64, scheduledFor time.Time) (bool, error) {
+	defer observeQuery("claim_reminder_delivery", time.Now())
+
+	query := `
+		INSERT INTO reminder_deliveries (user_id, scheduled_for)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	result, err := r.db.ExecContext(ctx, query, userID, scheduledFor)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// ReleaseDelivery removes a claim so the slot can be retried
+func (r *ReminderRepo) ReleaseDelivery(ctx context.Context, userID int64, scheduledFor time.Time) error {
+	defer observeQuery("release_reminder_delivery", time.Now())
+
+	query := `DELETE FROM reminder_deliveries WHERE user_id = $1 AND scheduled_for = $2`
+	_, err := r.db.ExecContext(ctx, query, userID, scheduledFor)
+	return err
+}
+
+// CleanOldDeliveries deletes delivery records older than before
+func (r *ReminderRepo) CleanOldDeliveries(ctx context.Context, before time.Time) error {
+	defer observeQuery("clean_old_reminder_deliveries", time.Now())
+
+	query := `DELETE FROM reminder_deliveries WHERE scheduled_for < $1`
+	_, err := r.db.ExecContext(ctx, query, before)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReminderSettings(row rowScanner) (*domain.ReminderSettings, error) {
+	var s domain.ReminderSettings
+	var times pq.Int64Array
+	var days int16
+	if err := row.Scan(&s.UserID, &times, &days, &s.Paused); err != nil {
+		return nil, err
+	}
+
+	s.Days = uint8(days)
+	s.Times = make([]int, len(times))
+	for i, t := range times {
+		s.Times[i] = int(t)
+	}
+	return &s, nil
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderRepo_GetReminderSettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReminderRepo(db)
+
+	rows := sqlmock.NewRows([]string{"user_id", "times", "days", "paused"}).
+		AddRow(123, []byte("{540,1200}"), 62, false)
+	mock.ExpectQuery("SELECT user_id, times, days, paused FROM reminder_settings WHERE user_id = \\$1").
+		WithArgs(int64(123)).
+		WillReturnRows(rows)
+
+	settings, err := repo.GetReminderSettings(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.ReminderSettings{UserID: 123, Times: []int{540, 1200}, Days: 62}, settings)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderRepo_GetReminderSettings_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReminderRepo(db)
+
+	mock.ExpectQuery("SELECT user_id, times, days, paused FROM reminder_settings").
+		WithArgs(int64(123)).
+		WillReturnError(sql.ErrNoRows)
+
+	settings, err := repo.GetReminderSettings(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.Nil(t, settings)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderRepo_SaveReminderSettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReminderRepo(db)
+
+	mock.ExpectExec("INSERT INTO reminder_settings").
+		WithArgs(int64(123), "{540,1200}", uint8(domain.AllWeekdays), true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SaveReminderSettings(context.Background(), &domain.ReminderSettings{
+		UserID: 123,
+		Times:  []int{540, 1200},
+		Days:   domain.AllWeekdays,
+		Paused: true,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderRepo_ListActiveReminders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReminderRepo(db)
+
+	rows := sqlmock.NewRows([]string{"user_id", "times", "days", "paused"}).
+		AddRow(123, []byte("{540}"), 127, false).
+		AddRow(456, []byte("{1200}"), 1, false)
+	mock.ExpectQuery("SELECT rs.user_id, rs.times, rs.days, rs.paused FROM reminder_settings rs JOIN users u").
+		WillReturnRows(rows)
+
+	list, err := repo.ListActiveReminders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, []int{1200}, list[1].Times)
+	assert.Equal(t, uint8(1), list[1].Days)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderRepo_ClaimDelivery(t *testing.T) {
+	slot := time.Date(2024, 12, 16, 6, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		affected int64
+		expected bool
+	}{
+		{name: "first claim", affected: 1, expected: true},
+		{name: "already claimed", affected: 0, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewReminderRepo(db)
+
+			mock.ExpectExec("INSERT INTO reminder_deliveries \\(user_id, scheduled_for\\) VALUES \\(\\$1, \\$2\\) ON CONFLICT DO NOTHING").
+				WithArgs(int64(123), slot).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			claimed, err := repo.ClaimDelivery(context.Background(), 123, slot)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, claimed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReminderRepo_ReleaseDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReminderRepo(db)
+	slot := time.Date(2024, 12, 16, 6, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("DELETE FROM reminder_deliveries WHERE user_id = \\$1 AND scheduled_for = \\$2").
+		WithArgs(int64(123), slot).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.ReleaseDelivery(context.Background(), 123, slot)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"languager/internal/domain"
+)
+
+// ReportRepo implements repository.ReportRepository
+type ReportRepo struct {
+	db *sql.DB
+}
+
+// NewReportRepo creates a new weekly report repository
+func NewReportRepo(db *sql.DB) *ReportRepo {
+	return &ReportRepo{db: db}
+}
+
+// IsSubscribed reports whether the user opted in to weekly reports
+func (r *ReportRepo) IsSubscribed(ctx context.Context, userID int64) (bool, error) {
+	defer observeQuery("is_report_subscribed", time.Now())
+
+	query := `SELECT EXISTS(SELECT 1 FROM weekly_report_subscriptions WHERE user_id = $1)`
+
+	var subscribed bool
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&subscribed)
+	return subscribed, err
+}
+
+// SetSubscribed opts the user in to or out of weekly reports
+func (r *ReportRepo) SetSubscribed(ctx context.Context, userID int64, subscribed bool) error {
+	defer observeQuery("set_report_subscribed", time.Now())
+
+	query := `DELETE FROM weekly_report_subscriptions WHERE user_id = $1`
+	if subscribed {
+		query = `
+			INSERT INTO weekly_report_subscriptions (user_id)
+			VALUES ($1)
+			ON CONFLICT DO NOTHING
+		`
+	}
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+// ListSubscribers returns subscribed authorized users
+func (r *ReportRepo) ListSubscribers(ctx context.Context) ([]int64, error) {
+	defer observeQuery("list_report_subscribers", time.Now())
+
+	query := `
+		SELECT s.user_id
+		FROM weekly_report_subscriptions s
+		JOIN users u ON u.user_id = s.user_id
+		WHERE u.authorized = TRUE
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		users = append(users, id)
+	}
+
+	return users, rows.Err()
+}
+
+// ClaimReport records a week's report as sent.
+// Returns false if it was already claimed, e.g. before a restart.
+func (r *ReportRepo) ClaimReport(ctx context.Context, userID int64, weekStart time.Time) (bool, error) {
+	defer observeQuery("claim_weekly_report", time.Now())
+
+	query := `
+		INSERT INTO weekly_report_deliveries (user_id, week_start)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	result, err := r.db.ExecContext(ctx, query, userID, weekStart)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// ReleaseReport removes a claim so the report can be retried
+func (r *ReportRepo) ReleaseReport(ctx context.Context, userID int64, weekStart time.Time) error {
+	defer observeQuery("release_weekly_report", time.Now())
+
+	query := `DELETE FROM weekly_report_deliveries WHERE user_id = $1 AND week_start = $2`
+	_, err := r.db.ExecContext(ctx, query, userID, weekStart)
+	return err
+}
+
+// CleanOldReports deletes delivery records for weeks before the given day
+func (r *ReportRepo) CleanOldReports(ctx context.Context, before time.Time) error {
+	defer observeQuery("clean_old_weekly_reports", time.Now())
+
+	query := `DELETE FROM weekly_report_deliveries WHERE week_start < $1`
+	_, err := r.db.ExecContext(ctx, query, before)
+	return err
+}
+
+// GetPeriodActivity returns words added and graded reviews in the period
+func (r *ReportRepo) GetPeriodActivity(ctx context.Context, userID int64, from, to time.Time) (int, int, int, error) {
+	defer observeQuery("get_period_activity", time.Now())
+
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM words WHERE user_id = $1 AND created_at >= $2 AND created_at < $3),
+			COUNT(*),
+			COUNT(*) FILTER (WHERE correct)
+		FROM reviews
+		WHERE user_id = $1 AND reviewed_at >= $2 AND reviewed_at < $3
+	`
+
+	var added, reviews, correct int
+	err := r.db.QueryRowContext(ctx, query, userID, from, to).Scan(&added, &reviews, &correct)
+	return added, reviews, correct, err
+}
+
+// GetMasteredWords returns words mastered in the period
+func (r *ReportRepo) GetMasteredWords(ctx context.Context, userID int64, from, to time.Time) ([]domain.Word, error) {
+	defer observeQuery("get_mastered_words", time.Now())
+
+	query := `
+		SELECT id, user_id, word, translation, created_at
+		FROM words
+		WHERE user_id = $1 AND mastered_at >= $2 AND mastered_at < $3
+		ORDER BY mastered_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []domain.Word
+	for rows.Next() {
+		var w domain.Word
+		if err := rows.Scan(&w.ID, &w.UserID, &w.Word, &w.Translation, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		words = append(words, w)
+	}
+
+	return words, rows.Err()
+}
+
+// GetFailingWords returns words failed in the period, most failures first
+func (r *ReportRepo) GetFailingWords(ctx context.Context, userID int64, from, to time.Time, limit int) ([]domain.HardWord, error) {
+	defer observeQuery("get_failing_words", time.Now())
+
+	query := `
+		SELECT w.word, w.translation,
+			COUNT(*) FILTER (WHERE NOT r.correct) AS failures,
+			COUNT(*) AS total
+		FROM reviews r
+		JOIN words w ON w.id = r.word_id
+		WHERE r.user_id = $1 AND r.reviewed_at >= $2 AND r.reviewed_at < $3
+		GROUP BY w.id, w.word, w.translation
+		HAVING COUNT(*) FILTER (WHERE NOT r.correct) > 0
+		ORDER BY failures DESC, total ASC
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []domain.HardWord
+	for rows.Next() {
+		var w domain.HardWord
+		if err := rows.Scan(&w.Word, &w.Translation, &w.Failures, &w.Reviews); err != nil {
+			return nil, err
+		}
+		words = append(words, w)
+	}
+
+	return words, rows.Err()
+}
@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportRepo_IsSubscribed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReportRepo(db)
+
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM weekly_report_subscriptions WHERE user_id = \\$1\\)").
+		WithArgs(int64(123)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	subscribed, err := repo.IsSubscribed(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.True(t, subscribed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepo_SetSubscribed(t *testing.T) {
+	tests := []struct {
+		name       string
+		subscribed bool
+		query      string
+	}{
+		{name: "opt in", subscribed: true, query: "INSERT INTO weekly_report_subscriptions \\(user_id\\) VALUES \\(\\$1\\) ON CONFLICT DO NOTHING"},
+		{name: "opt out", subscribed: false, query: "DELETE FROM weekly_report_subscriptions WHERE user_id = \\$1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewReportRepo(db)
+
+			mock.ExpectExec(tt.query).
+				WithArgs(int64(123)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			err = repo.SetSubscribed(context.Background(), 123, tt.subscribed)
+
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReportRepo_ListSubscribers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReportRepo(db)
+
+	mock.ExpectQuery("SELECT s.user_id FROM weekly_report_subscriptions s JOIN users u (.+) WHERE u.authorized = TRUE").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1).AddRow(2))
+
+	users, err := repo.ListSubscribers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepo_ClaimReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		expected bool
+	}{
+		{name: "first claim", affected: 1, expected: true},
+		{name: "already sent", affected: 0, expected: false},
+	}
+
+	weekStart := time.Date(2024, 12, 9, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewReportRepo(db)
+
+			mock.ExpectExec("INSERT INTO weekly_report_deliveries \\(user_id, week_start\\) VALUES \\(\\$1, \\$2\\) ON CONFLICT DO NOTHING").
+				WithArgs(int64(123), weekStart).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			claimed, err := repo.ClaimReport(context.Background(), 123, weekStart)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, claimed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReportRepo_GetPeriodActivity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReportRepo(db)
+	from := time.Date(2024, 12, 9, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	mock.ExpectQuery("SELECT \\(SELECT COUNT\\(\\*\\) FROM words (.+)\\), COUNT\\(\\*\\), COUNT\\(\\*\\) FILTER \\(WHERE correct\\) FROM reviews").
+		WithArgs(int64(123), from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"added", "reviews", "correct"}).AddRow(12, 40, 30))
+
+	added, reviews, correct, err := repo.GetPeriodActivity(context.Background(), 123, from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, added)
+	assert.Equal(t, 40, reviews)
+	assert.Equal(t, 30, correct)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepo_GetMasteredWords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReportRepo(db)
+	from := time.Date(2024, 12, 9, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	created := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT id, user_id, word, translation, created_at FROM words WHERE user_id = \\$1 AND mastered_at >= \\$2 AND mastered_at < \\$3").
+		WithArgs(int64(123), from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "word", "translation", "created_at"}).
+			AddRow(5, 123, "hello", "привет", created))
+
+	words, err := repo.GetMasteredWords(context.Background(), 123, from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Word{{ID: 5, UserID: 123, Word: "hello", Translation: "привет", CreatedAt: created}}, words)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepo_GetFailingWords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReportRepo(db)
+	from := time.Date(2024, 12, 9, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	mock.ExpectQuery("SELECT w.word, w.translation, (.+) FROM reviews r JOIN words w (.+) r.reviewed_at >= \\$2 AND r.reviewed_at < \\$3 (.+) LIMIT \\$4").
+		WithArgs(int64(123), from, to, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"word", "translation", "failures", "total"}).
+			AddRow("through", "через", 3, 4))
+
+	words, err := repo.GetFailingWords(context.Background(), 123, from, to, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.HardWord{{Word: "through", Translation: "через", Failures: 3, Reviews: 4}}, words)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
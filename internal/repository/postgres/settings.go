@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// SettingsRepo implements repository.SettingsRepository
+type SettingsRepo struct {
+	db *sql.DB
+}
+
+// NewSettingsRepo creates a new settings repository
+func NewSettingsRepo(db *sql.DB) *SettingsRepo {
+	return &SettingsRepo{db: db}
+}
+
+// GetSettings returns all stored preferences of the user
+func (r *SettingsRepo) GetSettings(ctx context.Context, userID int64) (map[string]string, error) {
+	defer observeQuery("get_settings", time.Now())
+
+	query := `SELECT key, value FROM user_settings WHERE user_id = $1`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		settings[key] = value
+	}
+
+	return settings, rows.Err()
+}
+
+// SetSetting stores a preference, replacing the previous value
+func (r *SettingsRepo) SetSetting(ctx context.Context, userID int64, key, value string) error {
+	defer observeQuery("set_setting", time.Now())
+
+	query := `
+		INSERT INTO user_settings (user_id, key, value)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, key)
+		DO UPDATE SET value = EXCLUDED.value, updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, key, value)
+	return err
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettingsRepo_GetSettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSettingsRepo(db)
+
+	rows := sqlmock.NewRows([]string{"key", "value"}).AddRow("review_direction", "reverse")
+	mock.ExpectQuery("SELECT key, value FROM user_settings WHERE user_id = \\$1").
+		WithArgs(int64(123)).
+		WillReturnRows(rows)
+
+	settings, err := repo.GetSettings(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"review_direction": "reverse"}, settings)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSettingsRepo_SetSetting(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSettingsRepo(db)
+
+	mock.ExpectExec("INSERT INTO user_settings \\(user_id, key, value\\) VALUES \\(\\$1, \\$2, \\$3\\) ON CONFLICT \\(user_id, key\\) DO UPDATE").
+		WithArgs(int64(123), "review_direction", "forward").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SetSetting(context.Background(), 123, "review_direction", "forward")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
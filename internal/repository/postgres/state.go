@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"languager/internal/domain"
+)
+
+// StateRepo implements repository.StateRepository
+type StateRepo struct {
+	db *sql.DB
+}
+
+// NewStateRepo creates a new state repository
+func NewStateRepo(db *sql.DB) *StateRepo {
+	return &StateRepo{db: db}
+}
+
+// LoadStates returns all persisted states
+func (r *StateRepo) LoadStates(ctx context.Context) (map[int64]*domain.StateData, error) {
+	defer observeQuery("load_states", time.Now())
+
+	query := `SELECT user_id, data FROM user_states`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	states := make(map[int64]*domain.StateData)
+	for rows.Next() {
+		var userID int64
+		var data []byte
+		if err := rows.Scan(&userID, &data); err != nil {
+			return nil, err
+		}
+
+		var state domain.StateData
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("failed to decode state of user %d: %w", userID, err)
+		}
+		states[userID] = &state
+	}
+
+	return states, rows.Err()
+}
+
+// SaveStates upserts states in a single transaction; idle states are deleted
+func (r *StateRepo) SaveStates(ctx context.Context, states map[int64]*domain.StateData) error {
+	defer observeQuery("save_states", time.Now())
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	upsert := `
+		INSERT INTO user_states (user_id, data, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id)
+		DO UPDATE SET data = EXCLUDED.data, updated_at = NOW()
+	`
+	remove := `DELETE FROM user_states WHERE user_id = $1`
+
+	for userID, state := range states {
+		if state == nil || state.State == domain.StateIdle {
+			if _, err := tx.ExecContext(ctx, remove, userID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, upsert, userID, data); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"languager/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateRepo_LoadStates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStateRepo(db)
+
+	rows := sqlmock.NewRows([]string{"user_id", "data"}).
+		AddRow(123, []byte(`{"state":"waiting_translation","current_word":"hello"}`)).
+		AddRow(456, []byte(`{"state":"waiting_word"}`))
+
+	mock.ExpectQuery("SELECT user_id, data FROM user_states").WillReturnRows(rows)
+
+	states, err := repo.LoadStates(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, states, 2)
+	assert.Equal(t, domain.StateWaitingTranslation, states[123].State)
+	assert.Equal(t, "hello", states[123].CurrentWord)
+	assert.Equal(t, domain.StateWaitingWord, states[456].State)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStateRepo_LoadStates_BadJSON(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStateRepo(db)
+
+	rows := sqlmock.NewRows([]string{"user_id", "data"}).AddRow(123, []byte(`{`))
+	mock.ExpectQuery("SELECT user_id, data FROM user_states").WillReturnRows(rows)
+
+	states, err := repo.LoadStates(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, states)
+}
+
+func TestStateRepo_SaveStates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStateRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO user_states").
+		WithArgs(int64(123), []byte(`{"state":"waiting_translation","current_word":"hello"}`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.SaveStates(context.Background(), map[int64]*domain.StateData{
+		123: {State: domain.StateWaitingTranslation, CurrentWord: "hello"},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStateRepo_SaveStates_DeletesIdle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStateRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM user_states WHERE user_id = \\$1").
+		WithArgs(int64(123)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.SaveStates(context.Background(), map[int64]*domain.StateData{
+		123: {State: domain.StateIdle},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStateRepo_SaveStates_RollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStateRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO user_states").WillReturnError(fmt.Errorf("db error"))
+	mock.ExpectRollback()
+
+	err = repo.SaveStates(context.Background(), map[int64]*domain.StateData{
+		123: {State: domain.StateWaitingWord},
+	})
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"languager/internal/domain"
+)
+
+// StatsRepo implements repository.StatsRepository
+type StatsRepo struct {
+	db *sql.DB
+}
+
+// NewStatsRepo creates a new stats repository
+func NewStatsRepo(db *sql.DB) *StatsRepo {
+	return &StatsRepo{db: db}
+}
+
+// GetWordTotals returns counters over user's words of the pair
+func (r *StatsRepo) GetWordTotals(ctx context.Context, userID int64, pair domain.LanguagePair) (domain.WordTotals, error) {
+	defer observeQuery("get_word_totals", time.Now())
+
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE hidden_forever = FALSE AND hidden_until > NOW()),
+			COUNT(*) FILTER (WHERE hidden_forever = TRUE),
+			COUNT(mastered_at),
+			COALESCE(EXTRACT(EPOCH FROM AVG(mastered_at - created_at)), 0)
+		FROM words
+		WHERE user_id = $1 AND source_lang = $2 AND target_lang = $3
+	`
+
+	var t domain.WordTotals
+	var avgSeconds float64
+	err := r.db.QueryRowContext(ctx, query, userID, pair.Source, pair.Target).Scan(
+		&t.Total, &t.HiddenTemporarily, &t.HiddenForever, &t.Mastered, &avgSeconds,
+	)
+	if err != nil {
+		return domain.WordTotals{}, err
+	}
+
+	t.AvgTimeToMastery = time.Duration(avgSeconds * float64(time.Second))
+	return t, nil
+}
+
+// GetReviewTotals returns the number of all and correct reviews of words of the pair
+func (r *StatsRepo) GetReviewTotals(ctx context.Context, userID int64, pair domain.LanguagePair) (int, int, error) {
+	defer observeQuery("get_review_totals", time.Now())
+
+	query := `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE r.correct)
+		FROM reviews r
+		JOIN words w ON w.id = r.word_id
+		WHERE r.user_id = $1 AND w.source_lang = $2 AND w.target_lang = $3
+	`
+
+	var total, correct int
+	err := r.db.QueryRowContext(ctx, query, userID, pair.Source, pair.Target).Scan(&total, &correct)
+	return total, correct, err
+}
+
+// GetAddedPerDay returns words of the pair added per day since from
+func (r *StatsRepo) GetAddedPerDay(ctx context.Context, userID int64, pair domain.LanguagePair, from time.Time, loc *time.Location) ([]domain.DailyCount, error) {
+	defer observeQuery("get_added_per_day", time.Now())
+
+	query := `
+		SELECT DATE(created_at AT TIME ZONE $3) AS day, COUNT(*)
+		FROM words
+		WHERE user_id = $1 AND created_at >= $2 AND source_lang = $4 AND target_lang = $5
+		GROUP BY day
+		ORDER BY day
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, loc.String(), pair.Source, pair.Target)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []domain.DailyCount
+	for rows.Next() {
+		var c domain.DailyCount
+		if err := rows.Scan(&c.Day, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+// GetReviewsPerDay returns graded reviews of words of the pair per day since from
+func (r *StatsRepo) GetReviewsPerDay(ctx context.Context, userID int64, pair domain.LanguagePair, from time.Time, loc *time.Location) ([]domain.DailyReviews, error) {
+	defer observeQuery("get_reviews_per_day", time.Now())
+
+	query := `
+		SELECT DATE(r.reviewed_at AT TIME ZONE $3) AS day, COUNT(*), COUNT(*) FILTER (WHERE r.correct)
+		FROM reviews r
+		JOIN words w ON w.id = r.word_id
+		WHERE r.user_id = $1 AND r.reviewed_at >= $2 AND w.source_lang = $4 AND w.target_lang = $5
+		GROUP BY day
+		ORDER BY day
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, loc.String(), pair.Source, pair.Target)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []domain.DailyReviews
+	for rows.Next() {
+		var d domain.DailyReviews
+		if err := rows.Scan(&d.Day, &d.Total, &d.Correct); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, d)
+	}
+
+	return reviews, rows.Err()
+}
+
+// GetActivityPerDay returns words added plus reviews per day since the civil day from
+func (r *StatsRepo) GetActivityPerDay(ctx context.Context, userID int64, from time.Time) ([]domain.DailyCount, error) {
+	defer observeQuery("get_activity_per_day", time.Now())
+
+	query := `
+		SELECT day, words_added + reviews
+		FROM user_activity
+		WHERE user_id = $1 AND day >= $2
+		ORDER BY day
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []domain.DailyCount
+	for rows.Next() {
+		var c domain.DailyCount
+		if err := rows.Scan(&c.Day, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+// GetHardestWords returns words of the pair with the most failed reviews
+func (r *StatsRepo) GetHardestWords(ctx context.Context, userID int64, pair domain.LanguagePair, limit int) ([]domain.HardWord, error) {
+	defer observeQuery("get_hardest_words", time.Now())
+
+	query := `
+		SELECT w.word, w.translation,
+			COUNT(*) FILTER (WHERE NOT r.correct) AS failures,
+			COUNT(*) AS total
+		FROM reviews r
+		JOIN words w ON w.id = r.word_id
+		WHERE r.user_id = $1 AND w.source_lang = $3 AND w.target_lang = $4
+		GROUP BY w.id, w.word, w.translation
+		HAVING COUNT(*) FILTER (WHERE NOT r.correct) > 0
+		ORDER BY failures DESC, total ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, pair.Source, pair.Target)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []domain.HardWord
+	for rows.Next() {
+		var w domain.HardWord
+		if err := rows.Scan(&w.Word, &w.Translation, &w.Failures, &w.Reviews); err != nil {
+			return nil, err
+		}
+		words = append(words, w)
+	}
+
+	return words, rows.Err()
+}
+
+// GetReviewsByDirection returns review totals of words of the pair per direction,
+// forward first and cloze last.
+// Reviews recorded before directions were tracked are left out.
+func (r *StatsRepo) GetReviewsByDirection(ctx context.Context, userID int64, pair domain.LanguagePair) ([]domain.DirectionReviews, error) {
+	defer observeQuery("get_reviews_by_direction", time.Now())
+
+	query := `
+		SELECT r.direction, COUNT(*), COUNT(*) FILTER (WHERE r.correct)
+		FROM reviews r
+		JOIN words w ON w.id = r.word_id
+		WHERE r.user_id = $1 AND r.direction IS NOT NULL AND w.source_lang = $2 AND w.target_lang = $3
+		GROUP BY r.direction
+		ORDER BY r.direction = 'cloze', r.direction
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pair.Source, pair.Target)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []domain.DirectionReviews
+	for rows.Next() {
+		var d domain.DirectionReviews
+		if err := rows.Scan(&d.Direction, &d.Total, &d.Correct); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, d)
+	}
+
+	return reviews, rows.Err()
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsRepo_GetWordTotals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStatsRepo(db)
+
+	rows := sqlmock.NewRows([]string{"total", "hidden_temp", "hidden_forever", "mastered", "avg"}).
+		AddRow(120, 4, 10, 35, 86400.0*1.5)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\), .* FROM words WHERE user_id = \\$1 AND source_lang = \\$2 AND target_lang = \\$3").
+		WithArgs(int64(123), "de", "ru").
+		WillReturnRows(rows)
+
+	totals, err := repo.GetWordTotals(context.Background(), 123, domain.LanguagePair{Source: "de", Target: "ru"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WordTotals{
+		Total:             120,
+		HiddenTemporarily: 4,
+		HiddenForever:     10,
+		Mastered:          35,
+		AvgTimeToMastery:  36 * time.Hour,
+	}, totals)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRepo_GetReviewTotals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStatsRepo(db)
+
+	rows := sqlmock.NewRows([]string{"total", "correct"}).AddRow(10, 7)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\), COUNT\\(\\*\\) FILTER \\(WHERE r.correct\\) FROM reviews r JOIN words w ON w.id = r.word_id WHERE r.user_id = \\$1 AND w.source_lang = \\$2 AND w.target_lang = \\$3").
+		WithArgs(int64(123), "en", "ru").
+		WillReturnRows(rows)
+
+	total, correct, err := repo.GetReviewTotals(context.Background(), 123, domain.DefaultLanguagePair)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, total)
+	assert.Equal(t, 7, correct)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRepo_GetAddedPerDay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStatsRepo(db)
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+	from := time.Date(2024, 12, 10, 0, 0, 0, 0, moscow)
+
+	day1 := time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, 12, 12, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"day", "count"}).AddRow(day1, 3).AddRow(day2, 5)
+	mock.ExpectQuery("SELECT DATE\\(created_at AT TIME ZONE \\$3\\) AS day, COUNT\\(\\*\\) FROM words").
+		WithArgs(int64(123), from, "Europe/Moscow", "en", "ru").
+		WillReturnRows(rows)
+
+	counts, err := repo.GetAddedPerDay(context.Background(), 123, domain.DefaultLanguagePair, from, moscow)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.DailyCount{{Day: day1, Count: 3}, {Day: day2, Count: 5}}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRepo_GetReviewsPerDay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStatsRepo(db)
+	from := time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC)
+
+	day := time.Date(2024, 12, 11, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"day", "total", "correct"}).AddRow(day, 8, 6)
+	mock.ExpectQuery("SELECT DATE\\(r.reviewed_at AT TIME ZONE \\$3\\) AS day, COUNT\\(\\*\\), COUNT\\(\\*\\) FILTER \\(WHERE r.correct\\) FROM reviews r JOIN words w").
+		WithArgs(int64(123), from, "UTC", "en", "ru").
+		WillReturnRows(rows)
+
+	reviews, err := repo.GetReviewsPerDay(context.Background(), 123, domain.DefaultLanguagePair, from, time.UTC)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.DailyReviews{{Day: day, Total: 8, Correct: 6}}, reviews)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRepo_GetActivityPerDay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStatsRepo(db)
+	from := time.Date(2024, 9, 23, 0, 0, 0, 0, time.UTC)
+
+	day := time.Date(2024, 12, 12, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"day", "total"}).AddRow(day, 8)
+	mock.ExpectQuery("SELECT day, words_added \\+ reviews FROM user_activity WHERE user_id = \\$1 AND day >= \\$2").
+		WithArgs(int64(123), from).
+		WillReturnRows(rows)
+
+	counts, err := repo.GetActivityPerDay(context.Background(), 123, from)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.DailyCount{{Day: day, Count: 8}}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRepo_GetHardestWords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStatsRepo(db)
+
+	rows := sqlmock.NewRows([]string{"word", "translation", "failures", "total"}).
+		AddRow("through", "через", 5, 7).
+		AddRow("thorough", "тщательный", 3, 3)
+	mock.ExpectQuery("SELECT w.word, w.translation, .* FROM reviews r JOIN words w ON w.id = r.word_id .* LIMIT \\$2").
+		WithArgs(int64(123), 5, "en", "ru").
+		WillReturnRows(rows)
+
+	words, err := repo.GetHardestWords(context.Background(), 123, domain.DefaultLanguagePair, 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, words, 2)
+	assert.Equal(t, domain.HardWord{Word: "through", Translation: "через", Failures: 5, Reviews: 7}, words[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatsRepo_GetReviewsByDirection(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStatsRepo(db)
+
+	rows := sqlmock.NewRows([]string{"direction", "total", "correct"}).
+		AddRow("forward", 10, 8).
+		AddRow("reverse", 6, 3).
+		AddRow("cloze", 4, 4)
+	mock.ExpectQuery("SELECT r.direction, COUNT\\(\\*\\), (.+) FROM reviews r JOIN words w ON w.id = r.word_id WHERE r.user_id = \\$1 AND r.direction IS NOT NULL (.+) GROUP BY r.direction ORDER BY r.direction = 'cloze', r.direction").
+		WithArgs(int64(123), "en", "ru").
+		WillReturnRows(rows)
+
+	reviews, err := repo.GetReviewsByDirection(context.Background(), 123, domain.DefaultLanguagePair)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.DirectionReviews{
+		{Direction: domain.DirectionForward, Total: 10, Correct: 8},
+		{Direction: domain.DirectionReverse, Total: 6, Correct: 3},
+		{Direction: domain.DirectionCloze, Total: 4, Correct: 4},
+	}, reviews)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
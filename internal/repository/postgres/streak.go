@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"languager/internal/domain"
+)
+
+// StreakRepo implements repository.StreakRepository
+type StreakRepo struct {
+	db *sql.DB
+}
+
+// NewStreakRepo creates a new streak repository
+func NewStreakRepo(db *sql.DB) *StreakRepo {
+	return &StreakRepo{db: db}
+}
+
+// AddActivity atomically increments the day's counters and returns the new totals
+func (r *StreakRepo) AddActivity(ctx context.Context, userID int64, day time.Time, delta domain.Activity) (domain.Activity, error) {
+	defer observeQuery("add_activity", time.Now())
+
+	query := `
+		INSERT INTO user_activity (user_id, day, words_added, reviews)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, day)
+		DO UPDATE SET words_added = user_activity.words_added + EXCLUDED.words_added,
+			reviews = user_activity.reviews + EXCLUDED.reviews
+		RETURNING words_added, reviews
+	`
+
+	var a domain.Activity
+	err := r.db.QueryRowContext(ctx, query, userID, day, delta.WordsAdded, delta.Reviews).
+		Scan(&a.WordsAdded, &a.Reviews)
+	return a, err
+}
+
+// GetActivity returns the day's counters, zero if nothing was done
+func (r *StreakRepo) GetActivity(ctx context.Context, userID int64, day time.Time) (domain.Activity, error) {
+	defer observeQuery("get_activity", time.Now())
+
+	query := `
+		SELECT words_added, reviews
+		FROM user_activity
+		WHERE user_id = $1 AND day = $2
+	`
+
+	var a domain.Activity
+	err := r.db.QueryRowContext(ctx, query, userID, day).Scan(&a.WordsAdded, &a.Reviews)
+	if err == sql.ErrNoRows {
+		return domain.Activity{}, nil
+	}
+	return a, err
+}
+
+// GetStreak returns user's streak or nil if there is none yet
+func (r *StreakRepo) GetStreak(ctx context.Context, userID int64) (*domain.Streak, error) {
+	defer observeQuery("get_streak", time.Now())
+
+	query := `
+		SELECT user_id, current_streak, best_streak, last_goal_day,
+			daily_goal, freeze_enabled, freeze_used_on
+		FROM user_streaks
+		WHERE user_id = $1
+	`
+
+	var s domain.Streak
+	var lastGoalDay, freezeUsedOn sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&s.UserID, &s.Current, &s.Best, &lastGoalDay,
+		&s.DailyGoal, &s.FreezeEnabled, &freezeUsedOn,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if lastGoalDay.Valid {
+		s.LastGoalDay = &lastGoalDay.Time
+	}
+	if freezeUsedOn.Valid {
+		s.FreezeUsedOn = &freezeUsedOn.Time
+	}
+	return &s, nil
+}
+
+// SaveStreak creates or replaces user's streak
+func (r *StreakRepo) SaveStreak(ctx context.Context, streak *domain.Streak) error {
+	defer observeQuery("save_streak", time.Now())
+
+	query := `
+		INSERT INTO user_streaks (user_id, current_streak, best_streak, last_goal_day,
+			daily_goal, freeze_enabled, freeze_used_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id)
+		DO UPDATE SET current_streak = EXCLUDED.current_streak,
+			best_streak = EXCLUDED.best_streak,
+			last_goal_day = EXCLUDED.last_goal_day,
+			daily_goal = EXCLUDED.daily_goal,
+			freeze_enabled = EXCLUDED.freeze_enabled,
+			freeze_used_on = EXCLUDED.freeze_used_on
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		streak.UserID, streak.Current, streak.Best, streak.LastGoalDay,
+		streak.DailyGoal, streak.FreezeEnabled, streak.FreezeUsedOn,
+	)
+	return err
+}
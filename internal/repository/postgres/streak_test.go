@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreakRepo_AddActivity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStreakRepo(db)
+	day := time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"words_added", "reviews"}).AddRow(2, 4)
+	mock.ExpectQuery("INSERT INTO user_activity .* ON CONFLICT \\(user_id, day\\) .* RETURNING words_added, reviews").
+		WithArgs(int64(123), day, 0, 1).
+		WillReturnRows(rows)
+
+	activity, err := repo.AddActivity(context.Background(), 123, day, domain.Activity{Reviews: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Activity{WordsAdded: 2, Reviews: 4}, activity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreakRepo_GetActivity_Empty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStreakRepo(db)
+	day := time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT words_added, reviews FROM user_activity WHERE user_id = \\$1 AND day = \\$2").
+		WithArgs(int64(123), day).
+		WillReturnError(sql.ErrNoRows)
+
+	activity, err := repo.GetActivity(context.Background(), 123, day)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Activity{}, activity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreakRepo_GetStreak(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStreakRepo(db)
+	lastGoalDay := time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{
+		"user_id", "current_streak", "best_streak", "last_goal_day",
+		"daily_goal", "freeze_enabled", "freeze_used_on",
+	}).AddRow(123, 3, 7, lastGoalDay, 10, true, nil)
+	mock.ExpectQuery("SELECT user_id, current_streak, best_streak, last_goal_day, daily_goal, freeze_enabled, freeze_used_on FROM user_streaks").
+		WithArgs(int64(123)).
+		WillReturnRows(rows)
+
+	streak, err := repo.GetStreak(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, streak.Current)
+	assert.Equal(t, 7, streak.Best)
+	assert.Equal(t, 10, streak.DailyGoal)
+	assert.True(t, streak.FreezeEnabled)
+	assert.Equal(t, lastGoalDay, *streak.LastGoalDay)
+	assert.Nil(t, streak.FreezeUsedOn)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreakRepo_GetStreak_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStreakRepo(db)
+
+	mock.ExpectQuery("SELECT user_id, current_streak").
+		WithArgs(int64(123)).
+		WillReturnError(sql.ErrNoRows)
+
+	streak, err := repo.GetStreak(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.Nil(t, streak)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreakRepo_SaveStreak(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewStreakRepo(db)
+	lastGoalDay := time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("INSERT INTO user_streaks").
+		WithArgs(int64(123), 3, 7, lastGoalDay, 5, false, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SaveStreak(context.Background(), &domain.Streak{
+		UserID:      123,
+		Current:     3,
+		Best:        7,
+		LastGoalDay: &lastGoalDay,
+		DailyGoal:   5,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
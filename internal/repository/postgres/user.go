@@ -1,7 +1,9 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"time"
 )
 
 // UserRepo implements repository.UserRepository
@@ -15,10 +17,12 @@ func NewUserRepo(db *sql.DB) *UserRepo {
 }
 
 // IsAuthorized checks if user is authorized
-func (r *UserRepo) IsAuthorized(userID int64) (bool, error) {
+func (r *UserRepo) IsAuthorized(ctx context.Context, userID int64) (bool, error) {
+	defer observeQuery("is_authorized", time.Now())
+
 	var authorized bool
 	query := `SELECT authorized FROM users WHERE user_id = $1`
-	err := r.db.QueryRow(query, userID).Scan(&authorized)
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&authorized)
 
 	if err == sql.ErrNoRows {
 		// User doesn't exist yet
@@ -32,25 +36,37 @@ func (r *UserRepo) IsAuthorized(userID int64) (bool, error) {
 }
 
 // AuthorizeUser marks user as authorized
-func (r *UserRepo) AuthorizeUser(userID int64) error {
+func (r *UserRepo) AuthorizeUser(ctx context.Context, userID int64) error {
+	defer observeQuery("authorize_user", time.Now())
+
 	query := `
 		INSERT INTO users (user_id, authorized)
 		VALUES ($1, TRUE)
 		ON CONFLICT (user_id)
 		DO UPDATE SET authorized = TRUE
 	`
-	_, err := r.db.Exec(query, userID)
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+// RevokeUser removes user's authorization
+func (r *UserRepo) RevokeUser(ctx context.Context, userID int64) error {
+	defer observeQuery("revoke_user", time.Now())
+
+	query := `UPDATE users SET authorized = FALSE WHERE user_id = $1`
+	_, err := r.db.ExecContext(ctx, query, userID)
 	return err
 }
 
 // EnsureUserExists creates user if not exists
-func (r *UserRepo) EnsureUserExists(userID int64) error {
+func (r *UserRepo) EnsureUserExists(ctx context.Context, userID int64) error {
+	defer observeQuery("ensure_user_exists", time.Now())
+
 	query := `
 		INSERT INTO users (user_id, authorized)
 		VALUES ($1, FALSE)
 		ON CONFLICT (user_id) DO NOTHING
 	`
-	_, err := r.db.Exec(query, userID)
+	_, err := r.db.ExecContext(ctx, query, userID)
 	return err
 }
-
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
@@ -59,7 +60,7 @@ func TestUserRepo_IsAuthorized(t *testing.T) {
 				mock.ExpectQuery(query).WithArgs(tt.userID).WillReturnRows(tt.mockRows)
 			}
 
-			authorized, err := repo.IsAuthorized(tt.userID)
+			authorized, err := repo.IsAuthorized(context.Background(), tt.userID)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -87,7 +88,26 @@ func TestUserRepo_AuthorizeUser(t *testing.T) {
 		WithArgs(userID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.AuthorizeUser(userID)
+	err = repo.AuthorizeUser(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepo_RevokeUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepo(db)
+
+	userID := int64(123)
+
+	mock.ExpectExec("UPDATE users SET authorized = FALSE WHERE user_id = \\$1").
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.RevokeUser(context.Background(), userID)
 
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -107,9 +127,8 @@ func TestUserRepo_EnsureUserExists(t *testing.T) {
 		WithArgs(userID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.EnsureUserExists(userID)
+	err = repo.EnsureUserExists(context.Background(), userID)
 
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
-
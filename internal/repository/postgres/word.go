@@ -1,10 +1,15 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"languager/internal/domain"
+
+	"github.com/lib/pq"
 )
 
 // WordRepo implements repository.WordRepository
@@ -17,31 +22,60 @@ func NewWordRepo(db *sql.DB) *WordRepo {
 	return &WordRepo{db: db}
 }
 
-// SaveWord saves a word-translation pair
-func (r *WordRepo) SaveWord(userID int64, word, translation string) error {
+// SaveWord saves a word-translation pair in the language pair and returns its ID
+func (r *WordRepo) SaveWord(ctx context.Context, userID int64, pair domain.LanguagePair, word, translation string) (int, error) {
+	defer observeQuery("save_word", time.Now())
+
 	query := `
-		INSERT INTO words (user_id, word, translation)
-		VALUES ($1, $2, $3)
+		INSERT INTO words (user_id, word, translation, source_lang, target_lang)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
 	`
-	_, err := r.db.Exec(query, userID, word, translation)
-	return err
+	var id int
+	err := r.db.QueryRowContext(ctx, query, userID, word, translation, pair.Source, pair.Target).Scan(&id)
+	return id, err
 }
 
-// GetRandomWord returns a random word for the user
+// SwapWord swaps the word and the translation of user's word.
+// Returns nil if the word doesn't exist.
+func (r *WordRepo) SwapWord(ctx context.Context, userID int64, wordID int) (*domain.Word, error) {
+	defer observeQuery("swap_word", time.Now())
+
+	// SET sees the old row, so the two columns trade values
+	query := `
+		UPDATE words
+		SET word = translation, translation = word
+		WHERE id = $1 AND user_id = $2
+		RETURNING id, user_id, word, translation, created_at
+	`
+	var w domain.Word
+	err := r.db.QueryRowContext(ctx, query, wordID, userID).Scan(&w.ID, &w.UserID, &w.Word, &w.Translation, &w.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// GetRandomWord returns a random word of the pair for the user
 // Excludes words that are hidden forever or hidden until a future date
-func (r *WordRepo) GetRandomWord(userID int64) (*domain.Word, error) {
+func (r *WordRepo) GetRandomWord(ctx context.Context, userID int64, pair domain.LanguagePair) (*domain.Word, error) {
+	defer observeQuery("get_random_word", time.Now())
+
 	var w domain.Word
 	var hiddenUntil sql.NullTime
 	query := `
 		SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever
 		FROM words
-		WHERE user_id = $1
+		WHERE user_id = $1 AND source_lang = $2 AND target_lang = $3
 			AND (hidden_forever = FALSE OR hidden_forever IS NULL)
 			AND (hidden_until IS NULL OR hidden_until <= NOW())
 		ORDER BY RANDOM()
 		LIMIT 1
 	`
-	err := r.db.QueryRow(query, userID).Scan(
+	err := r.db.QueryRowContext(ctx, query, userID, pair.Source, pair.Target).Scan(
 		&w.ID, &w.UserID, &w.Word, &w.Translation, &w.CreatedAt, &hiddenUntil, &w.HiddenForever,
 	)
 
@@ -59,20 +93,22 @@ func (r *WordRepo) GetRandomWord(userID int64) (*domain.Word, error) {
 	return &w, nil
 }
 
-// GetDaysWithWords returns days that have words with counts
-// Uses Moscow timezone for day calculation (day changes at 00:00 MSK)
-func (r *WordRepo) GetDaysWithWords(userID int64, limit, offset int) ([]domain.Day, error) {
+// GetDaysWithWords returns days of the last historyDays that have words of the pair, with counts.
+// Days are counted in loc.
+func (r *WordRepo) GetDaysWithWords(ctx context.Context, userID int64, pair domain.LanguagePair, historyDays, limit, offset int, loc *time.Location) ([]domain.Day, error) {
+	defer observeQuery("get_days_with_words", time.Now())
+
 	query := `
-		SELECT DATE(created_at AT TIME ZONE 'Europe/Moscow') as day, COUNT(*) as count
+		SELECT DATE(created_at AT TIME ZONE $5) as day, COUNT(*) as count
 		FROM words
-		WHERE user_id = $1 
-			AND created_at >= (NOW() AT TIME ZONE 'Europe/Moscow' - INTERVAL '60 days') AT TIME ZONE 'Europe/Moscow'
-		GROUP BY DATE(created_at AT TIME ZONE 'Europe/Moscow')
+		WHERE user_id = $1 AND source_lang = $6 AND target_lang = $7
+			AND created_at >= NOW() - INTERVAL '1 day' * $2
+		GROUP BY day
 		ORDER BY day DESC
-		LIMIT $2 OFFSET $3
+		LIMIT $3 OFFSET $4
 	`
 
-	rows, err := r.db.Query(query, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, userID, historyDays, limit, offset, loc.String(), pair.Source, pair.Target)
 	if err != nil {
 		return nil, err
 	}
@@ -90,42 +126,40 @@ func (r *WordRepo) GetDaysWithWords(userID int64, limit, offset int) ([]domain.D
 	return days, rows.Err()
 }
 
-// GetTotalDaysCount returns total number of days with words
-// Uses Moscow timezone for day calculation
-func (r *WordRepo) GetTotalDaysCount(userID int64) (int, error) {
+// GetTotalDaysCount returns number of days of the last historyDays that have words of the pair.
+// Days are counted in loc.
+func (r *WordRepo) GetTotalDaysCount(ctx context.Context, userID int64, pair domain.LanguagePair, historyDays int, loc *time.Location) (int, error) {
+	defer observeQuery("get_total_days_count", time.Now())
+
 	query := `
-		SELECT COUNT(DISTINCT DATE(created_at AT TIME ZONE 'Europe/Moscow'))
+		SELECT COUNT(DISTINCT DATE(created_at AT TIME ZONE $3))
 		FROM words
-		WHERE user_id = $1 
-			AND created_at >= (NOW() AT TIME ZONE 'Europe/Moscow' - INTERVAL '60 days') AT TIME ZONE 'Europe/Moscow'
+		WHERE user_id = $1 AND source_lang = $4 AND target_lang = $5
+			AND created_at >= NOW() - INTERVAL '1 day' * $2
 	`
 
 	var count int
-	err := r.db.QueryRow(query, userID).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, userID, historyDays, loc.String(), pair.Source, pair.Target).Scan(&count)
 	return count, err
 }
 
-// GetWordsByDate returns all words for a specific date
-// Uses Moscow timezone for day calculation
-func (r *WordRepo) GetWordsByDate(userID int64, date time.Time) ([]domain.Word, error) {
-	// Convert the date to start of day in Moscow timezone
-	moscowLocation, err := time.LoadLocation("Europe/Moscow")
-	if err != nil {
-		return nil, err
-	}
-	
-	// Get start and end of the day in Moscow timezone
-	dateStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, moscowLocation)
-	
+// GetWordsByDate returns all words of the pair added on the civil date in loc
+func (r *WordRepo) GetWordsByDate(ctx context.Context, userID int64, pair domain.LanguagePair, date time.Time, loc *time.Location) ([]domain.Word, error) {
+	defer observeQuery("get_words_by_date", time.Now())
+
+	// Start and end of the day in the user's time zone
+	dateStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	dateEnd := dateStart.AddDate(0, 0, 1)
+
 	query := `
 		SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever
 		FROM words
-		WHERE user_id = $1 
-			AND DATE(created_at AT TIME ZONE 'Europe/Moscow') = DATE($2 AT TIME ZONE 'Europe/Moscow')
+		WHERE user_id = $1 AND source_lang = $4 AND target_lang = $5
+			AND created_at >= $2 AND created_at < $3
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.Query(query, userID, dateStart)
+	rows, err := r.db.QueryContext(ctx, query, userID, dateStart, dateEnd, pair.Source, pair.Target)
 	if err != nil {
 		return nil, err
 	}
@@ -147,35 +181,374 @@ func (r *WordRepo) GetWordsByDate(userID int64, date time.Time) ([]domain.Word,
 	return words, rows.Err()
 }
 
-// CleanOldWords deletes words older than specified days
-func (r *WordRepo) CleanOldWords(days int) error {
+// CleanOldWords deletes words older than the user's history_days setting,
+// or defaultDays if the user hasn't changed it, and returns how many were deleted.
+// Words of decks someone subscribed to or copied from are kept, so that decks
+// in use don't shrink; decks nobody took words from are cleaned as usual.
+func (r *WordRepo) CleanOldWords(ctx context.Context, defaultDays int) (int64, error) {
+	defer observeQuery("clean_old_words", time.Now())
+
 	query := `
-		DELETE FROM words
-		WHERE created_at < NOW() - INTERVAL '1 day' * $1
+		DELETE FROM words w
+		WHERE w.created_at < NOW() - INTERVAL '1 day' * COALESCE(
+			(SELECT s.value::int FROM user_settings s WHERE s.user_id = w.user_id AND s.key = $2),
+			$1
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM deck_words dw
+			WHERE dw.word_id = w.id
+				AND (
+					EXISTS (SELECT 1 FROM deck_subscriptions ds WHERE ds.deck_id = dw.deck_id)
+					OR EXISTS (
+						SELECT 1 FROM deck_words dc
+						JOIN words c ON c.copied_from = dc.word_id
+						WHERE dc.deck_id = dw.deck_id
+					)
+				)
+		)
 	`
-	_, err := r.db.Exec(query, days)
-	return err
+	res, err := r.db.ExecContext(ctx, query, defaultDays, domain.SettingHistoryDays)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
 }
 
-// HideWordFor7Days hides a word from random pair for 7 days
-func (r *WordRepo) HideWordFor7Days(wordID int) error {
+// SnoozeWord hides user's word from random pair for the given duration
+func (r *WordRepo) SnoozeWord(ctx context.Context, userID int64, wordID int, duration time.Duration) error {
+	defer observeQuery("snooze_word", time.Now())
+
+	query := `
+		UPDATE words
+		SET hidden_until = NOW() + INTERVAL '1 second' * $3
+		WHERE id = $1 AND user_id = $2
+	`
+	res, err := r.db.ExecContext(ctx, query, wordID, userID, int64(duration/time.Second))
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("word %d of user %d not found", wordID, userID)
+	}
+	return nil
+}
+
+// SetExample stores an example sentence of user's word; empty removes it
+func (r *WordRepo) SetExample(ctx context.Context, userID int64, wordID int, example string) error {
+	defer observeQuery("set_example", time.Now())
+
 	query := `
 		UPDATE words
-		SET hidden_until = NOW() + INTERVAL '7 days'
-		WHERE id = $1
+		SET example = $3
+		WHERE id = $1 AND user_id = $2
+	`
+	res, err := r.db.ExecContext(ctx, query, wordID, userID, example)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("word %d of user %d not found", wordID, userID)
+	}
+	return nil
+}
+
+// GetRandomExampleWords returns up to limit random visible words of the pair that have an example.
+// Uses the same visibility rules as GetRandomWord.
+func (r *WordRepo) GetRandomExampleWords(ctx context.Context, userID int64, pair domain.LanguagePair, limit int) ([]domain.Word, error) {
+	defer observeQuery("get_random_example_words", time.Now())
+
+	query := `
+		SELECT id, user_id, word, translation, created_at, example
+		FROM words
+		WHERE user_id = $1 AND source_lang = $2 AND target_lang = $3
+			AND example <> ''
+			AND (hidden_forever = FALSE OR hidden_forever IS NULL)
+			AND (hidden_until IS NULL OR hidden_until <= NOW())
+		ORDER BY RANDOM()
+		LIMIT $4
 	`
-	_, err := r.db.Exec(query, wordID)
-	return err
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pair.Source, pair.Target, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []domain.Word
+	for rows.Next() {
+		var w domain.Word
+		if err := rows.Scan(&w.ID, &w.UserID, &w.Word, &w.Translation, &w.CreatedAt, &w.Example); err != nil {
+			return nil, err
+		}
+		words = append(words, w)
+	}
+
+	return words, rows.Err()
 }
 
-// HideWordForever permanently hides a word from random pair
-func (r *WordRepo) HideWordForever(wordID int) error {
+// SearchWords returns up to limit user's words of any pair whose word or translation
+// contains query, ignoring case. Words starting with query come first, then the newest.
+// Substring matching is served by the trigram indexes of migration 012.
+func (r *WordRepo) SearchWords(ctx context.Context, userID int64, query string, limit int) ([]domain.Word, error) {
+	defer observeQuery("search_words", time.Now())
+
+	sqlQuery := `
+		SELECT id, user_id, word, translation, created_at
+		FROM words
+		WHERE user_id = $1 AND (LOWER(word) LIKE $2 OR LOWER(translation) LIKE $2)
+		ORDER BY (LOWER(word) LIKE $3 OR LOWER(translation) LIKE $3) DESC, created_at DESC
+		LIMIT $4
+	`
+	pattern := escapeLike(strings.ToLower(query))
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, userID, "%"+pattern+"%", pattern+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []domain.Word
+	for rows.Next() {
+		var w domain.Word
+		if err := rows.Scan(&w.ID, &w.UserID, &w.Word, &w.Translation, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		words = append(words, w)
+	}
+
+	return words, rows.Err()
+}
+
+// escapeLike makes LIKE wildcards in s match literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// HideWordForever permanently hides user's word from random pair
+func (r *WordRepo) HideWordForever(ctx context.Context, userID int64, wordID int) error {
+	defer observeQuery("hide_word_forever", time.Now())
+
 	query := `
 		UPDATE words
 		SET hidden_forever = TRUE
-		WHERE id = $1
+		WHERE id = $1 AND user_id = $2
+	`
+	res, err := r.db.ExecContext(ctx, query, wordID, userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("word %d of user %d not found", wordID, userID)
+	}
+	return nil
+}
+
+// CountDueWords returns how many words of the pair are available for review.
+// Uses the same pair and visibility rules as GetRandomWord
+func (r *WordRepo) CountDueWords(ctx context.Context, userID int64, pair domain.LanguagePair) (int, error) {
+	defer observeQuery("count_due_words", time.Now())
+
+	query := `
+		SELECT COUNT(*)
+		FROM words
+		WHERE user_id = $1 AND source_lang = $2 AND target_lang = $3
+			AND (hidden_forever = FALSE OR hidden_forever IS NULL)
+			AND (hidden_until IS NULL OR hidden_until <= NOW())
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID, pair.Source, pair.Target).Scan(&count)
+	return count, err
+}
+
+// RecordReview stores a graded review and updates the word's correct streak.
+// Returns true if this review made the word mastered.
+func (r *WordRepo) RecordReview(ctx context.Context, userID int64, wordID int, correct bool, direction domain.ReviewDirection) (bool, error) {
+	defer observeQuery("record_review", time.Now())
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// NOW() is fixed for the transaction, so mastered_at = NOW()
+	// only holds if it was set by this very update
+	update := `
+		UPDATE words
+		SET correct_streak = CASE WHEN $3 THEN correct_streak + 1 ELSE 0 END,
+			mastered_at = CASE
+				WHEN $3 AND correct_streak + 1 >= $4 AND mastered_at IS NULL THEN NOW()
+				ELSE mastered_at
+			END
+		WHERE id = $1 AND user_id = $2
+		RETURNING COALESCE(mastered_at = NOW(), FALSE)
+	`
+	var mastered bool
+	err = tx.QueryRowContext(ctx, update, wordID, userID, correct, domain.MasteryStreak).Scan(&mastered)
+	if err == sql.ErrNoRows {
+		return false, fmt.Errorf("word %d of user %d not found", wordID, userID)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	insert := `
+		INSERT INTO reviews (user_id, word_id, correct, direction)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+	`
+	if _, err := tx.ExecContext(ctx, insert, userID, wordID, correct, string(direction)); err != nil {
+		return false, err
+	}
+
+	return mastered, tx.Commit()
+}
+
+// GetRandomProblemWord returns a random visible word of the pair whose last review failed.
+// Returns nil if there are no such words.
+func (r *WordRepo) GetRandomProblemWord(ctx context.Context, userID int64, pair domain.LanguagePair) (*domain.Word, error) {
+	defer observeQuery("get_random_problem_word", time.Now())
+
+	var w domain.Word
+	var hiddenUntil sql.NullTime
+	query := `
+		SELECT w.id, w.user_id, w.word, w.translation, w.created_at, w.hidden_until, w.hidden_forever
+		FROM words w
+		WHERE w.user_id = $1 AND w.source_lang = $2 AND w.target_lang = $3
+			AND (w.hidden_forever = FALSE OR w.hidden_forever IS NULL)
+			AND (w.hidden_until IS NULL OR w.hidden_until <= NOW())
+			AND (
+				SELECT r.correct
+				FROM reviews r
+				WHERE r.word_id = w.id
+				ORDER BY r.reviewed_at DESC, r.id DESC
+				LIMIT 1
+			) = FALSE
+		ORDER BY RANDOM()
+		LIMIT 1
+	`
+	err := r.db.QueryRowContext(ctx, query, userID, pair.Source, pair.Target).Scan(
+		&w.ID, &w.UserID, &w.Word, &w.Translation, &w.CreatedAt, &hiddenUntil, &w.HiddenForever,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if hiddenUntil.Valid {
+		w.HiddenUntil = &hiddenUntil.Time
+	}
+
+	return &w, nil
+}
+
+// GetRandomWordIDs returns IDs of up to limit random visible words of the pair; 0 means all.
+// Uses the same visibility rules as GetRandomWord.
+func (r *WordRepo) GetRandomWordIDs(ctx context.Context, userID int64, pair domain.LanguagePair, limit int) ([]int, error) {
+	defer observeQuery("get_random_word_ids", time.Now())
+
+	// LIMIT NULL returns all rows
+	query := `
+		SELECT id
+		FROM words
+		WHERE user_id = $1 AND source_lang = $3 AND target_lang = $4
+			AND (hidden_forever = FALSE OR hidden_forever IS NULL)
+			AND (hidden_until IS NULL OR hidden_until <= NOW())
+		ORDER BY RANDOM()
+		LIMIT NULLIF($2, 0)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, pair.Source, pair.Target)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// GetWordsByIDs returns user's words with the given IDs in no particular order
+func (r *WordRepo) GetWordsByIDs(ctx context.Context, userID int64, ids []int) ([]domain.Word, error) {
+	defer observeQuery("get_words_by_ids", time.Now())
+
+	query := `
+		SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever
+		FROM words
+		WHERE user_id = $1 AND id = ANY($2)
 	`
-	_, err := r.db.Exec(query, wordID)
-	return err
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []domain.Word
+	for rows.Next() {
+		var w domain.Word
+		var hiddenUntil sql.NullTime
+		if err := rows.Scan(&w.ID, &w.UserID, &w.Word, &w.Translation, &w.CreatedAt, &hiddenUntil, &w.HiddenForever); err != nil {
+			return nil, err
+		}
+		if hiddenUntil.Valid {
+			w.HiddenUntil = &hiddenUntil.Time
+		}
+		words = append(words, w)
+	}
+
+	return words, rows.Err()
 }
 
+// GetLanguagePairs returns the pairs the user has words in, most words first
+func (r *WordRepo) GetLanguagePairs(ctx context.Context, userID int64) ([]domain.LanguagePair, error) {
+	defer observeQuery("get_language_pairs", time.Now())
+
+	query := `
+		SELECT source_lang, target_lang
+		FROM words
+		WHERE user_id = $1
+		GROUP BY source_lang, target_lang
+		ORDER BY COUNT(*) DESC, source_lang, target_lang
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pairs []domain.LanguagePair
+	for rows.Next() {
+		var p domain.LanguagePair
+		if err := rows.Scan(&p.Source, &p.Target); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, p)
+	}
+
+	return pairs, rows.Err()
+}
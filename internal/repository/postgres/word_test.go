@@ -1,13 +1,18 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"testing"
 	"time"
 
+	"languager/internal/domain"
+
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWordRepo_SaveWord(t *testing.T) {
@@ -21,13 +26,52 @@ func TestWordRepo_SaveWord(t *testing.T) {
 	word := "hello"
 	translation := "привет"
 
-	mock.ExpectExec("INSERT INTO words").
-		WithArgs(userID, word, translation).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("INSERT INTO words \\(user_id, word, translation, source_lang, target_lang\\) VALUES (.+) RETURNING id").
+		WithArgs(userID, word, translation, "de", "ru").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
 
-	err = repo.SaveWord(userID, word, translation)
+	id, err := repo.SaveWord(context.Background(), userID, domain.LanguagePair{Source: "de", Target: "ru"}, word, translation)
 
 	assert.NoError(t, err)
+	assert.Equal(t, 42, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWordRepo_SwapWord(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWordRepo(db)
+	created := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("UPDATE words SET word = translation, translation = word WHERE id = \\$1 AND user_id = \\$2 RETURNING").
+		WithArgs(7, int64(123)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "word", "translation", "created_at"}).
+			AddRow(7, 123, "hello", "привет", created))
+
+	word, err := repo.SwapWord(context.Background(), 123, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Word{ID: 7, UserID: 123, Word: "hello", Translation: "привет", CreatedAt: created}, word)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWordRepo_SwapWord_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWordRepo(db)
+
+	mock.ExpectQuery("UPDATE words SET word = translation").
+		WithArgs(7, int64(123)).
+		WillReturnError(sql.ErrNoRows)
+
+	word, err := repo.SwapWord(context.Background(), 123, 7)
+
+	assert.NoError(t, err)
+	assert.Nil(t, word)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -85,15 +129,15 @@ func TestWordRepo_GetRandomWord(t *testing.T) {
 
 			repo := NewWordRepo(db)
 
-			query := "SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever FROM words WHERE user_id = \\$1 AND \\(hidden_forever = FALSE OR hidden_forever IS NULL\\) AND \\(hidden_until IS NULL OR hidden_until <= NOW\\(\\)\\)"
+			query := "SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever FROM words WHERE user_id = \\$1 AND source_lang = \\$2 AND target_lang = \\$3 AND \\(hidden_forever = FALSE OR hidden_forever IS NULL\\) AND \\(hidden_until IS NULL OR hidden_until <= NOW\\(\\)\\)"
 
 			if tt.mockError != nil {
-				mock.ExpectQuery(query).WithArgs(tt.userID).WillReturnError(tt.mockError)
+				mock.ExpectQuery(query).WithArgs(tt.userID, "en", "ru").WillReturnError(tt.mockError)
 			} else {
-				mock.ExpectQuery(query).WithArgs(tt.userID).WillReturnRows(tt.mockRows)
+				mock.ExpectQuery(query).WithArgs(tt.userID, "en", "ru").WillReturnRows(tt.mockRows)
 			}
 
-			word, err := repo.GetRandomWord(tt.userID)
+			word, err := repo.GetRandomWord(context.Background(), tt.userID, domain.DefaultLanguagePair)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -120,6 +164,7 @@ func TestWordRepo_GetDaysWithWords(t *testing.T) {
 	repo := NewWordRepo(db)
 
 	userID := int64(123)
+	historyDays := 60
 	limit := 7
 	offset := 0
 
@@ -127,11 +172,11 @@ func TestWordRepo_GetDaysWithWords(t *testing.T) {
 		AddRow(time.Now(), 5).
 		AddRow(time.Now().AddDate(0, 0, -1), 3)
 
-	mock.ExpectQuery("SELECT DATE\\(created_at\\)").
-		WithArgs(userID, limit, offset).
+	mock.ExpectQuery("SELECT DATE\\(created_at AT TIME ZONE \\$5\\)").
+		WithArgs(userID, historyDays, limit, offset, "Europe/Moscow", "en", "ru").
 		WillReturnRows(rows)
 
-	days, err := repo.GetDaysWithWords(userID, limit, offset)
+	days, err := repo.GetDaysWithWords(context.Background(), userID, domain.DefaultLanguagePair, historyDays, limit, offset, moscow(t))
 
 	assert.NoError(t, err)
 	assert.Len(t, days, 2)
@@ -148,14 +193,15 @@ func TestWordRepo_GetDaysWithWords_QueryError(t *testing.T) {
 	repo := NewWordRepo(db)
 
 	userID := int64(123)
+	historyDays := 60
 	limit := 7
 	offset := 0
 
-	mock.ExpectQuery("SELECT DATE\\(created_at\\)").
-		WithArgs(userID, limit, offset).
+	mock.ExpectQuery("SELECT DATE\\(created_at AT TIME ZONE \\$5\\)").
+		WithArgs(userID, historyDays, limit, offset, "Europe/Moscow", "en", "ru").
 		WillReturnError(fmt.Errorf("query error"))
 
-	days, err := repo.GetDaysWithWords(userID, limit, offset)
+	days, err := repo.GetDaysWithWords(context.Background(), userID, domain.DefaultLanguagePair, historyDays, limit, offset, moscow(t))
 
 	assert.Error(t, err)
 	assert.Nil(t, days)
@@ -170,6 +216,7 @@ func TestWordRepo_GetDaysWithWords_ScanError(t *testing.T) {
 	repo := NewWordRepo(db)
 
 	userID := int64(123)
+	historyDays := 60
 	limit := 7
 	offset := 0
 
@@ -177,11 +224,11 @@ func TestWordRepo_GetDaysWithWords_ScanError(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"day", "count"}).
 		AddRow("invalid", 5)
 
-	mock.ExpectQuery("SELECT DATE\\(created_at\\)").
-		WithArgs(userID, limit, offset).
+	mock.ExpectQuery("SELECT DATE\\(created_at AT TIME ZONE \\$5\\)").
+		WithArgs(userID, historyDays, limit, offset, "Europe/Moscow", "en", "ru").
 		WillReturnRows(rows)
 
-	days, err := repo.GetDaysWithWords(userID, limit, offset)
+	days, err := repo.GetDaysWithWords(context.Background(), userID, domain.DefaultLanguagePair, historyDays, limit, offset, moscow(t))
 
 	assert.Error(t, err)
 	assert.Nil(t, days)
@@ -199,11 +246,11 @@ func TestWordRepo_GetTotalDaysCount(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{"count"}).AddRow(14)
 
-	mock.ExpectQuery("SELECT COUNT\\(DISTINCT DATE\\(created_at\\)\\)").
-		WithArgs(userID).
+	mock.ExpectQuery("SELECT COUNT\\(DISTINCT DATE\\(created_at AT TIME ZONE \\$3\\)\\)").
+		WithArgs(userID, 60, "Europe/Moscow", "en", "ru").
 		WillReturnRows(rows)
 
-	count, err := repo.GetTotalDaysCount(userID)
+	count, err := repo.GetTotalDaysCount(context.Background(), userID, domain.DefaultLanguagePair, 60, moscow(t))
 
 	assert.NoError(t, err)
 	assert.Equal(t, 14, count)
@@ -225,11 +272,11 @@ func TestWordRepo_GetWordsByDate(t *testing.T) {
 		AddRow(2, userID, "world", "мир", date, time.Now().AddDate(0, 0, 1), false).
 		AddRow(3, userID, "test", "тест", date, nil, true)
 
-	mock.ExpectQuery("SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever FROM words WHERE user_id = \\$1 AND DATE\\(created_at\\) = DATE\\(\\$2\\)").
-		WithArgs(userID, date).
+	mock.ExpectQuery("SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever FROM words WHERE user_id = \\$1 AND source_lang = \\$4 AND target_lang = \\$5 AND created_at >= \\$2 AND created_at < \\$3").
+		WithArgs(userID, sqlmock.AnyArg(), sqlmock.AnyArg(), "en", "ru").
 		WillReturnRows(rows)
 
-	words, err := repo.GetWordsByDate(userID, date)
+	words, err := repo.GetWordsByDate(context.Background(), userID, domain.DefaultLanguagePair, date, moscow(t))
 
 	assert.NoError(t, err)
 	assert.Len(t, words, 3)
@@ -255,11 +302,11 @@ func TestWordRepo_GetWordsByDate_QueryError(t *testing.T) {
 	userID := int64(123)
 	date := time.Now()
 
-	mock.ExpectQuery("SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever FROM words WHERE user_id = \\$1 AND DATE\\(created_at\\) = DATE\\(\\$2\\)").
-		WithArgs(userID, date).
+	mock.ExpectQuery("SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever FROM words WHERE user_id = \\$1 AND source_lang = \\$4 AND target_lang = \\$5 AND created_at >= \\$2 AND created_at < \\$3").
+		WithArgs(userID, sqlmock.AnyArg(), sqlmock.AnyArg(), "en", "ru").
 		WillReturnError(fmt.Errorf("query error"))
 
-	words, err := repo.GetWordsByDate(userID, date)
+	words, err := repo.GetWordsByDate(context.Background(), userID, domain.DefaultLanguagePair, date, moscow(t))
 
 	assert.Error(t, err)
 	assert.Nil(t, words)
@@ -280,11 +327,11 @@ func TestWordRepo_GetWordsByDate_ScanError(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "user_id", "word", "translation", "created_at", "hidden_until", "hidden_forever"}).
 		AddRow("invalid", userID, "hello", "привет", date, nil, false)
 
-	mock.ExpectQuery("SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever FROM words WHERE user_id = \\$1 AND DATE\\(created_at\\) = DATE\\(\\$2\\)").
-		WithArgs(userID, date).
+	mock.ExpectQuery("SELECT id, user_id, word, translation, created_at, hidden_until, hidden_forever FROM words WHERE user_id = \\$1 AND source_lang = \\$4 AND target_lang = \\$5 AND created_at >= \\$2 AND created_at < \\$3").
+		WithArgs(userID, sqlmock.AnyArg(), sqlmock.AnyArg(), "en", "ru").
 		WillReturnRows(rows)
 
-	words, err := repo.GetWordsByDate(userID, date)
+	words, err := repo.GetWordsByDate(context.Background(), userID, domain.DefaultLanguagePair, date, moscow(t))
 
 	assert.Error(t, err)
 	assert.Nil(t, words)
@@ -300,51 +347,387 @@ func TestWordRepo_CleanOldWords(t *testing.T) {
 
 	days := 60
 
-	mock.ExpectExec("DELETE FROM words WHERE created_at").
-		WithArgs(days).
+	// Only decks with subscribers or copies keep their words
+	mock.ExpectExec("DELETE FROM words w WHERE w.created_at < NOW\\(\\) - INTERVAL '1 day' \\* COALESCE(.+)"+
+		"AND NOT EXISTS \\( SELECT 1 FROM deck_words dw WHERE dw.word_id = w.id "+
+		"AND \\( EXISTS \\(SELECT 1 FROM deck_subscriptions ds WHERE ds.deck_id = dw.deck_id\\) "+
+		"OR EXISTS \\( SELECT 1 FROM deck_words dc JOIN words c ON c.copied_from = dc.word_id WHERE dc.deck_id = dw.deck_id \\)").
+		WithArgs(days, "history_days").
 		WillReturnResult(sqlmock.NewResult(0, 10))
 
-	err = repo.CleanOldWords(days)
+	deleted, err := repo.CleanOldWords(context.Background(), days)
 
 	assert.NoError(t, err)
+	assert.Equal(t, int64(10), deleted)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestWordRepo_HideWordFor7Days(t *testing.T) {
+func TestWordRepo_SnoozeWord(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr bool
+	}{
+		{name: "snoozed", rows: 1},
+		{name: "not user's word", rows: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewWordRepo(db)
+
+			mock.ExpectExec("UPDATE words SET hidden_until = NOW\\(\\) \\+ INTERVAL '1 second' \\* \\$3 WHERE id = \\$1 AND user_id = \\$2").
+				WithArgs(1, int64(123), int64(3*24*60*60)).
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			err = repo.SnoozeWord(context.Background(), 123, 1, 3*24*time.Hour)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordRepo_SetExample(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr bool
+	}{
+		{name: "stored", rows: 1},
+		{name: "not user's word", rows: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewWordRepo(db)
+
+			mock.ExpectExec("UPDATE words SET example = \\$3 WHERE id = \\$1 AND user_id = \\$2").
+				WithArgs(1, int64(123), "I like apples").
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			err = repo.SetExample(context.Background(), 123, 1, "I like apples")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordRepo_GetRandomExampleWords(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
 	repo := NewWordRepo(db)
 
-	wordID := 1
+	rows := sqlmock.NewRows([]string{"id", "user_id", "word", "translation", "created_at", "example"}).
+		AddRow(7, 123, "like", "нравиться", time.Now(), "I like apples").
+		AddRow(8, 123, "run", "бегать", time.Now(), "She is running")
+	mock.ExpectQuery("SELECT (.+) FROM words WHERE user_id = \\$1 AND source_lang = \\$2 AND target_lang = \\$3 AND example <> '' (.+) LIMIT \\$4").
+		WithArgs(int64(123), "en", "ru", 5).
+		WillReturnRows(rows)
 
-	mock.ExpectExec("UPDATE words SET hidden_until = NOW\\(\\) \\+ INTERVAL '7 days' WHERE id = \\$1").
-		WithArgs(wordID).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+	words, err := repo.GetRandomExampleWords(context.Background(), 123, domain.DefaultLanguagePair, 5)
 
-	err = repo.HideWordFor7Days(wordID)
+	assert.NoError(t, err)
+	assert.Len(t, words, 2)
+	assert.Equal(t, "I like apples", words[0].Example)
+	assert.Equal(t, "She is running", words[1].Example)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWordRepo_SearchWords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWordRepo(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "word", "translation", "created_at"}).
+		AddRow(7, 123, "apple", "яблоко", time.Now()).
+		AddRow(8, 123, "pineapple", "ананас", time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM words WHERE user_id = \\$1 AND \\(LOWER\\(word\\) LIKE \\$2 OR LOWER\\(translation\\) LIKE \\$2\\) ORDER BY (.+) LIMIT \\$4").
+		WithArgs(int64(123), "%app%", "app%", 20).
+		WillReturnRows(rows)
+
+	words, err := repo.SearchWords(context.Background(), 123, "App", 20)
 
 	assert.NoError(t, err)
+	assert.Len(t, words, 2)
+	assert.Equal(t, "apple", words[0].Word)
+	assert.Equal(t, "яблоко", words[0].Translation)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, "app", escapeLike("app"))
+	assert.Equal(t, `100\%`, escapeLike("100%"))
+	assert.Equal(t, `a\_b\\c`, escapeLike(`a_b\c`))
+}
+
 func TestWordRepo_HideWordForever(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr bool
+	}{
+		{name: "hidden", rows: 1},
+		{name: "not user's word", rows: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewWordRepo(db)
+
+			mock.ExpectExec("UPDATE words SET hidden_forever = TRUE WHERE id = \\$1 AND user_id = \\$2").
+				WithArgs(1, int64(123)).
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			err = repo.HideWordForever(context.Background(), 123, 1)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordRepo_CleanOldWords_Cancelled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWordRepo(db)
+
+	mock.ExpectExec("DELETE FROM words w").
+		WithArgs(60, "history_days").
+		WillDelayFor(time.Second).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = repo.CleanOldWords(ctx, 60)
+
+	// The query is aborted instead of waiting for the database
+	assert.Error(t, err)
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
+
+func TestWordRepo_CountDueWords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWordRepo(db)
+
+	// Only words of the pair count, like in GetRandomWord
+	pair := domain.LanguagePair{Source: "de", Target: "ru"}
+	rows := sqlmock.NewRows([]string{"count"}).AddRow(12)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM words WHERE user_id = \\$1 AND source_lang = \\$2 AND target_lang = \\$3 AND \\(hidden_forever = FALSE OR hidden_forever IS NULL\\)").
+		WithArgs(int64(123), "de", "ru").
+		WillReturnRows(rows)
+
+	count, err := repo.CountDueWords(context.Background(), 123, pair)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWordRepo_RecordReview(t *testing.T) {
+	tests := []struct {
+		name     string
+		correct  bool
+		mastered bool
+	}{
+		{name: "correct answer masters the word", correct: true, mastered: true},
+		{name: "wrong answer", correct: false, mastered: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewWordRepo(db)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("UPDATE words SET correct_streak = .* WHERE id = \\$1 AND user_id = \\$2 RETURNING").
+				WithArgs(5, int64(123), tt.correct, domain.MasteryStreak).
+				WillReturnRows(sqlmock.NewRows([]string{"mastered"}).AddRow(tt.mastered))
+			mock.ExpectExec("INSERT INTO reviews \\(user_id, word_id, correct, direction\\)").
+				WithArgs(int64(123), 5, tt.correct, "reverse").
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectCommit()
+
+			mastered, err := repo.RecordReview(context.Background(), 123, 5, tt.correct, domain.DirectionReverse)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.mastered, mastered)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordRepo_RecordReview_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWordRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE words SET correct_streak").
+		WithArgs(5, int64(999), true, domain.MasteryStreak).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repo.RecordReview(context.Background(), 999, 5, true, domain.DirectionForward)
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWordRepo_GetRandomProblemWord(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWordRepo(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "word", "translation", "created_at", "hidden_until", "hidden_forever"}).
+		AddRow(7, 123, "through", "через", time.Now(), nil, false)
+	mock.ExpectQuery("SELECT (.+) FROM words w WHERE w.user_id = \\$1 AND w.source_lang = \\$2 AND w.target_lang = \\$3 (.+) SELECT r.correct FROM reviews r (.+) = FALSE ORDER BY RANDOM\\(\\)").
+		WithArgs(int64(123), "en", "ru").
+		WillReturnRows(rows)
+
+	word, err := repo.GetRandomProblemWord(context.Background(), 123, domain.DefaultLanguagePair)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, word)
+	assert.Equal(t, 7, word.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWordRepo_GetRandomProblemWord_None(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
 	repo := NewWordRepo(db)
 
-	wordID := 1
+	mock.ExpectQuery("SELECT (.+) FROM words w").
+		WithArgs(int64(123), "en", "ru").
+		WillReturnError(sql.ErrNoRows)
 
-	mock.ExpectExec("UPDATE words SET hidden_forever = TRUE WHERE id = \\$1").
-		WithArgs(wordID).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+	word, err := repo.GetRandomProblemWord(context.Background(), 123, domain.DefaultLanguagePair)
 
-	err = repo.HideWordForever(wordID)
+	assert.NoError(t, err)
+	assert.Nil(t, word)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWordRepo_GetRandomWordIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+	}{
+		{name: "batch", limit: 10},
+		{name: "all", limit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewWordRepo(db)
+
+			mock.ExpectQuery("SELECT id FROM words WHERE user_id = \\$1 (.+) ORDER BY RANDOM\\(\\) LIMIT NULLIF\\(\\$2, 0\\)").
+				WithArgs(int64(123), tt.limit, "en", "ru").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(1))
+
+			ids, err := repo.GetRandomWordIDs(context.Background(), 123, domain.DefaultLanguagePair, tt.limit)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []int{3, 1}, ids)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordRepo_GetWordsByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWordRepo(db)
+	created := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "word", "translation", "created_at", "hidden_until", "hidden_forever"}).
+		AddRow(1, 123, "hello", "привет", created, nil, false)
+	mock.ExpectQuery("SELECT (.+) FROM words WHERE user_id = \\$1 AND id = ANY\\(\\$2\\)").
+		WithArgs(int64(123), pq.Array([]int{1, 2})).
+		WillReturnRows(rows)
+
+	words, err := repo.GetWordsByIDs(context.Background(), 123, []int{1, 2})
 
 	assert.NoError(t, err)
+	assert.Equal(t, []domain.Word{{ID: 1, UserID: 123, Word: "hello", Translation: "привет", CreatedAt: created}}, words)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func moscow(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	return loc
+}
+
+func TestWordRepo_GetLanguagePairs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWordRepo(db)
+
+	rows := sqlmock.NewRows([]string{"source_lang", "target_lang"}).
+		AddRow("en", "ru").
+		AddRow("de", "ru")
+	mock.ExpectQuery("SELECT source_lang, target_lang FROM words WHERE user_id = \\$1 GROUP BY source_lang, target_lang ORDER BY COUNT\\(\\*\\) DESC").
+		WithArgs(int64(123)).
+		WillReturnRows(rows)
+
+	pairs, err := repo.GetLanguagePairs(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.LanguagePair{{Source: "en", Target: "ru"}, {Source: "de", Target: "ru"}}, pairs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"time"
 
 	"languager/internal/domain"
@@ -8,20 +9,180 @@ import (
 
 // UserRepository defines user data operations
 type UserRepository interface {
-	IsAuthorized(userID int64) (bool, error)
-	AuthorizeUser(userID int64) error
-	EnsureUserExists(userID int64) error
+	IsAuthorized(ctx context.Context, userID int64) (bool, error)
+	AuthorizeUser(ctx context.Context, userID int64) error
+	RevokeUser(ctx context.Context, userID int64) error
+	EnsureUserExists(ctx context.Context, userID int64) error
+}
+
+// StateRepository persists user dialog states
+type StateRepository interface {
+	LoadStates(ctx context.Context) (map[int64]*domain.StateData, error)
+	// SaveStates upserts the given states; idle states are deleted
+	SaveStates(ctx context.Context, states map[int64]*domain.StateData) error
 }
 
 // WordRepository defines word data operations
 type WordRepository interface {
-	SaveWord(userID int64, word, translation string) error
-	GetRandomWord(userID int64) (*domain.Word, error)
-	GetDaysWithWords(userID int64, limit, offset int) ([]domain.Day, error)
-	GetWordsByDate(userID int64, date time.Time) ([]domain.Word, error)
-	CleanOldWords(days int) error
-	GetTotalDaysCount(userID int64) (int, error)
-	HideWordFor7Days(wordID int) error
-	HideWordForever(wordID int) error
+	// SaveWord stores a word and returns its ID
+	SaveWord(ctx context.Context, userID int64, pair domain.LanguagePair, word, translation string) (int, error)
+	// SwapWord swaps the word and the translation; nil if there is no such word
+	SwapWord(ctx context.Context, userID int64, wordID int) (*domain.Word, error)
+	GetRandomWord(ctx context.Context, userID int64, pair domain.LanguagePair) (*domain.Word, error)
+	GetDaysWithWords(ctx context.Context, userID int64, pair domain.LanguagePair, historyDays, limit, offset int, loc *time.Location) ([]domain.Day, error)
+	GetWordsByDate(ctx context.Context, userID int64, pair domain.LanguagePair, date time.Time, loc *time.Location) ([]domain.Word, error)
+	// CleanOldWords deletes words older than each user's history_days setting or defaultDays
+	// and returns how many were deleted
+	CleanOldWords(ctx context.Context, defaultDays int) (int64, error)
+	GetTotalDaysCount(ctx context.Context, userID int64, pair domain.LanguagePair, historyDays int, loc *time.Location) (int, error)
+	// SnoozeWord hides user's word from random pair for the given duration
+	SnoozeWord(ctx context.Context, userID int64, wordID int, duration time.Duration) error
+	// HideWordForever permanently hides user's word from random pair
+	HideWordForever(ctx context.Context, userID int64, wordID int) error
+	// CountDueWords returns how many words of the pair are available for review
+	CountDueWords(ctx context.Context, userID int64, pair domain.LanguagePair) (int, error)
+	// RecordReview stores a graded review; returns true if the word just got mastered
+	RecordReview(ctx context.Context, userID int64, wordID int, correct bool, direction domain.ReviewDirection) (bool, error)
+	// GetRandomProblemWord returns a random visible word whose last review failed, nil if none
+	GetRandomProblemWord(ctx context.Context, userID int64, pair domain.LanguagePair) (*domain.Word, error)
+	// GetRandomWordIDs returns IDs of up to limit random visible words; 0 means all
+	GetRandomWordIDs(ctx context.Context, userID int64, pair domain.LanguagePair, limit int) ([]int, error)
+	// GetWordsByIDs returns user's words with the given IDs in no particular order
+	GetWordsByIDs(ctx context.Context, userID int64, ids []int) ([]domain.Word, error)
+	// GetLanguagePairs returns the pairs the user has words in, most words first
+	GetLanguagePairs(ctx context.Context, userID int64) ([]domain.LanguagePair, error)
+	// SetExample stores an example sentence of user's word; empty removes it
+	SetExample(ctx context.Context, userID int64, wordID int, example string) error
+	// GetRandomExampleWords returns up to limit random visible words that have an example
+	GetRandomExampleWords(ctx context.Context, userID int64, pair domain.LanguagePair, limit int) ([]domain.Word, error)
+	// SearchWords returns up to limit words or translations containing query, prefix matches first
+	SearchWords(ctx context.Context, userID int64, query string, limit int) ([]domain.Word, error)
+}
+
+// DeckRepository stores shared decks and their subscriptions
+type DeckRepository interface {
+	// CreateDeck stores the deck with the owner's current words of its pair.
+	// Sets ID, CreatedAt and WordCount.
+	CreateDeck(ctx context.Context, deck *domain.Deck) error
+	// GetDeckByCode returns nil if there is no such deck
+	GetDeckByCode(ctx context.Context, code string) (*domain.Deck, error)
+	ListOwnDecks(ctx context.Context, ownerID int64) ([]domain.Deck, error)
+	ListSubscribedDecks(ctx context.Context, userID int64) ([]domain.Deck, error)
+	DeleteDeck(ctx context.Context, deckID int) error
+	// GetSubscription returns nil if the user isn't subscribed
+	GetSubscription(ctx context.Context, deckID int, userID int64) (*domain.DeckSubscription, error)
+	// SaveSubscription subscribes the user or updates the subscription
+	SaveSubscription(ctx context.Context, sub domain.DeckSubscription) error
+	DeleteSubscription(ctx context.Context, deckID int, userID int64) error
+	// CopyDeckWords copies deck words the user has no copies of yet and returns how many
+	CopyDeckWords(ctx context.Context, deckID int, userID int64) (int, error)
+	// AddWordToDecks adds the owner's word to their decks of the pair and returns their IDs
+	AddWordToDecks(ctx context.Context, ownerID int64, pair domain.LanguagePair, wordID int) ([]int, error)
+	// CopyWordToFollowers copies a deck word to subscribers following the deck and returns how many
+	CopyWordToFollowers(ctx context.Context, deckID, wordID int) (int, error)
+}
+
+// AchievementRepository stores earned achievements and computes progress toward them
+type AchievementRepository interface {
+	// GetProgress returns the user's stored metrics; event metrics are left out
+	GetProgress(ctx context.Context, userID int64) (domain.AchievementProgress, error)
+	// ListEarned returns when each earned achievement was earned, by ID
+	ListEarned(ctx context.Context, userID int64) (map[string]time.Time, error)
+	// SaveEarned stores the achievements and returns IDs of the ones not earned before
+	SaveEarned(ctx context.Context, userID int64, ids []string) ([]string, error)
+}
+
+// AuditRepository stores the audit log
+type AuditRepository interface {
+	// Save appends the entry to the log and sets its ID and time
+	Save(ctx context.Context, entry *domain.AuditEntry) error
+	// List returns entries matching the filter, newest first
+	List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+// GroupRepository stores group chats' vocabularies, quizzes and scores
+type GroupRepository interface {
+	// SaveGroup enables the bot in the chat or updates its title
+	SaveGroup(ctx context.Context, chatID int64, title string, enabledBy int64) error
+	GroupExists(ctx context.Context, chatID int64) (bool, error)
+	// AddWord stores the word and sets its ID; false if the group has it already
+	AddWord(ctx context.Context, word *domain.GroupWord) (bool, error)
+	// GetRandomWords returns up to limit random words of the group
+	GetRandomWords(ctx context.Context, chatID int64, limit int) ([]domain.GroupWord, error)
+	CreateQuiz(ctx context.Context, chatID int64, wordID int) (int, error)
+	// GetQuiz returns nil if the group has no such quiz; Options are left empty
+	GetQuiz(ctx context.Context, chatID int64, quizID int) (*domain.GroupQuiz, error)
+	// RecordAttempt marks the member's answer; false if they answered already
+	RecordAttempt(ctx context.Context, quizID int, userID int64) (bool, error)
+	// ClaimWin makes the member the winner and gives them a point;
+	// false if the quiz was solved already
+	ClaimWin(ctx context.Context, chatID int64, quizID int, userID int64, name string) (bool, error)
+	// GetTopScores returns the leaderboard, best first
+	GetTopScores(ctx context.Context, chatID int64, limit int) ([]domain.GroupScore, error)
 }
 
+// ReminderRepository stores reminder schedules and delivered reminders
+type ReminderRepository interface {
+	// GetReminderSettings returns nil if the user has no schedule yet
+	GetReminderSettings(ctx context.Context, userID int64) (*domain.ReminderSettings, error)
+	SaveReminderSettings(ctx context.Context, settings *domain.ReminderSettings) error
+	// ListActiveReminders returns unpaused schedules of authorized users
+	ListActiveReminders(ctx context.Context) ([]domain.ReminderSettings, error)
+	// ClaimDelivery marks a slot as sent; false if it was already claimed
+	ClaimDelivery(ctx context.Context, userID int64, scheduledFor time.Time) (bool, error)
+	ReleaseDelivery(ctx context.Context, userID int64, scheduledFor time.Time) error
+	CleanOldDeliveries(ctx context.Context, before time.Time) error
+}
+
+// StreakRepository stores daily activity and study streaks
+type StreakRepository interface {
+	// AddActivity adds delta to the day's counters and returns the new totals
+	AddActivity(ctx context.Context, userID int64, day time.Time, delta domain.Activity) (domain.Activity, error)
+	GetActivity(ctx context.Context, userID int64, day time.Time) (domain.Activity, error)
+	// GetStreak returns nil if the user has no streak yet
+	GetStreak(ctx context.Context, userID int64) (*domain.Streak, error)
+	SaveStreak(ctx context.Context, streak *domain.Streak) error
+}
+
+// StatsRepository computes per-user statistics
+type StatsRepository interface {
+	GetWordTotals(ctx context.Context, userID int64, pair domain.LanguagePair) (domain.WordTotals, error)
+	// GetReviewTotals returns the number of all and correct reviews
+	GetReviewTotals(ctx context.Context, userID int64, pair domain.LanguagePair) (total, correct int, err error)
+	// GetAddedPerDay returns words added per day since from; days are in loc
+	GetAddedPerDay(ctx context.Context, userID int64, pair domain.LanguagePair, from time.Time, loc *time.Location) ([]domain.DailyCount, error)
+	// GetReviewsPerDay returns reviews per day since from; days are in loc
+	GetReviewsPerDay(ctx context.Context, userID int64, pair domain.LanguagePair, from time.Time, loc *time.Location) ([]domain.DailyReviews, error)
+	// GetActivityPerDay returns words added plus reviews per day since the civil day from
+	GetActivityPerDay(ctx context.Context, userID int64, from time.Time) ([]domain.DailyCount, error)
+	// GetHardestWords returns words with the most failed reviews
+	GetHardestWords(ctx context.Context, userID int64, pair domain.LanguagePair, limit int) ([]domain.HardWord, error)
+	// GetReviewsByDirection returns review totals per direction
+	GetReviewsByDirection(ctx context.Context, userID int64, pair domain.LanguagePair) ([]domain.DirectionReviews, error)
+}
+
+// SettingsRepository stores user preferences as key-value pairs
+type SettingsRepository interface {
+	// GetSettings returns all stored preferences of the user
+	GetSettings(ctx context.Context, userID int64) (map[string]string, error)
+	SetSetting(ctx context.Context, userID int64, key, value string) error
+}
+
+// ReportRepository stores weekly report subscriptions and gathers report data.
+// Periods are half-open: [from, to).
+type ReportRepository interface {
+	IsSubscribed(ctx context.Context, userID int64) (bool, error)
+	SetSubscribed(ctx context.Context, userID int64, subscribed bool) error
+	// ListSubscribers returns subscribed authorized users
+	ListSubscribers(ctx context.Context) ([]int64, error)
+	// ClaimReport marks a week's report as sent; false if it was already claimed
+	ClaimReport(ctx context.Context, userID int64, weekStart time.Time) (bool, error)
+	ReleaseReport(ctx context.Context, userID int64, weekStart time.Time) error
+	CleanOldReports(ctx context.Context, before time.Time) error
+	// GetPeriodActivity returns words added and graded reviews in the period
+	GetPeriodActivity(ctx context.Context, userID int64, from, to time.Time) (added, reviews, correct int, err error)
+	// GetMasteredWords returns words mastered in the period
+	GetMasteredWords(ctx context.Context, userID int64, from, to time.Time) ([]domain.Word, error)
+	// GetFailingWords returns words failed in the period, most failures first
+	GetFailingWords(ctx context.Context, userID int64, from, to time.Time, limit int) ([]domain.HardWord, error)
+}
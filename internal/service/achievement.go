@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"languager/internal/domain"
+	"languager/internal/repository"
+)
+
+// AchievementSender announces an earned achievement to the user
+type AchievementSender interface {
+	SendAchievement(ctx context.Context, userID int64, achievement domain.Achievement) error
+}
+
+// AchievementService awards achievements from domain.Achievements
+type AchievementService struct {
+	repo         repository.AchievementRepository
+	achievements []domain.Achievement
+}
+
+// NewAchievementService creates a new achievement service
+func NewAchievementService(repo repository.AchievementRepository) *AchievementService {
+	return &AchievementService{repo: repo, achievements: domain.Achievements}
+}
+
+// Check awards achievements reached after the event and returns the new ones.
+// Runs after every save and review; costs one query once all are earned.
+func (s *AchievementService) Check(ctx context.Context, userID int64, event domain.AchievementEvent) ([]domain.Achievement, error) {
+	earned, err := s.repo.ListEarned(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []domain.Achievement
+	for _, a := range s.achievements {
+		if _, ok := earned[a.ID]; !ok {
+			pending = append(pending, a)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	progress, err := s.repo.GetProgress(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	event.Apply(progress)
+
+	var reached []string
+	for _, a := range pending {
+		if a.Reached(progress) {
+			reached = append(reached, a.ID)
+		}
+	}
+	if len(reached) == 0 {
+		return nil, nil
+	}
+
+	saved, err := s.repo.SaveEarned(ctx, userID, reached)
+	if err != nil {
+		return nil, err
+	}
+	return s.byIDs(saved), nil
+}
+
+// Award checks achievements after the event and announces the new ones through sender
+func (s *AchievementService) Award(ctx context.Context, userID int64, event domain.AchievementEvent, sender AchievementSender) error {
+	earned, err := s.Check(ctx, userID, event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, a := range earned {
+		errs = append(errs, sender.SendAchievement(ctx, userID, a))
+	}
+	return errors.Join(errs...)
+}
+
+// List returns all achievements with the user's progress
+func (s *AchievementService) List(ctx context.Context, userID int64) ([]domain.AchievementStatus, error) {
+	earned, err := s.repo.ListEarned(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := s.repo.GetProgress(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]domain.AchievementStatus, 0, len(s.achievements))
+	for _, a := range s.achievements {
+		status := domain.AchievementStatus{Achievement: a, Progress: min(progress[a.Metric], a.Goal)}
+		if at, ok := earned[a.ID]; ok {
+			at := at
+			status.EarnedAt = &at
+			status.Progress = a.Goal
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// byIDs returns the achievements with the IDs in definition order
+func (s *AchievementService) byIDs(ids []string) []domain.Achievement {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	var result []domain.Achievement
+	for _, a := range s.achievements {
+		if wanted[a.ID] {
+			result = append(result, a)
+		}
+	}
+	return result
+}
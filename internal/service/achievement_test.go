@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testAchievements = []domain.Achievement{
+	{ID: "first_word", Metric: domain.MetricWordsAdded, Goal: 1},
+	{ID: "words_100", Metric: domain.MetricWordsAdded, Goal: 100},
+	{ID: "streak_7", Metric: domain.MetricBestStreak, Goal: 7},
+	{ID: "perfect_session", Metric: domain.MetricPerfectSessions, Goal: 1},
+}
+
+func newTestAchievementService(repo *testutil.MockAchievementRepository) *AchievementService {
+	s := NewAchievementService(repo)
+	s.achievements = testAchievements
+	return s
+}
+
+func TestAchievementService_Check(t *testing.T) {
+	earnedAt := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		earned   map[string]time.Time
+		progress domain.AchievementProgress
+		event    domain.AchievementEvent
+		reached  []string
+		saved    []string
+		expected []string
+	}{
+		{
+			name:     "new achievements",
+			earned:   map[string]time.Time{"first_word": earnedAt},
+			progress: domain.AchievementProgress{domain.MetricWordsAdded: 100, domain.MetricBestStreak: 7},
+			reached:  []string{"words_100", "streak_7"},
+			saved:    []string{"words_100", "streak_7"},
+			expected: []string{"words_100", "streak_7"},
+		},
+		{
+			name:     "perfect session event",
+			earned:   map[string]time.Time{"first_word": earnedAt},
+			progress: domain.AchievementProgress{domain.MetricWordsAdded: 5},
+			event:    domain.AchievementEvent{PerfectSession: true},
+			reached:  []string{"perfect_session"},
+			saved:    []string{"perfect_session"},
+			expected: []string{"perfect_session"},
+		},
+		{
+			name:     "announced by a concurrent update",
+			earned:   map[string]time.Time{},
+			progress: domain.AchievementProgress{domain.MetricWordsAdded: 1},
+			reached:  []string{"first_word"},
+			saved:    nil,
+			expected: nil,
+		},
+		{
+			name:     "nothing reached",
+			earned:   map[string]time.Time{"first_word": earnedAt},
+			progress: domain.AchievementProgress{domain.MetricWordsAdded: 99},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(testutil.MockAchievementRepository)
+			mockRepo.On("ListEarned", mock.Anything, int64(123)).Return(tt.earned, nil)
+			mockRepo.On("GetProgress", mock.Anything, int64(123)).Return(tt.progress, nil)
+			if tt.reached != nil {
+				mockRepo.On("SaveEarned", mock.Anything, int64(123), tt.reached).Return(tt.saved, nil)
+			}
+
+			earned, err := newTestAchievementService(mockRepo).Check(context.Background(), 123, tt.event)
+
+			require.NoError(t, err)
+			var ids []string
+			for _, a := range earned {
+				ids = append(ids, a.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+			mockRepo.AssertExpectations(t)
+			if tt.reached == nil {
+				mockRepo.AssertNotCalled(t, "SaveEarned", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAchievementService_Check_AllEarned(t *testing.T) {
+	earned := make(map[string]time.Time)
+	for _, a := range testAchievements {
+		earned[a.ID] = time.Now()
+	}
+
+	mockRepo := new(testutil.MockAchievementRepository)
+	mockRepo.On("ListEarned", mock.Anything, int64(123)).Return(earned, nil)
+
+	result, err := newTestAchievementService(mockRepo).Check(context.Background(), 123, domain.AchievementEvent{PerfectSession: true})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	mockRepo.AssertNotCalled(t, "GetProgress", mock.Anything, mock.Anything)
+}
+
+func TestAchievementService_List(t *testing.T) {
+	earnedAt := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	mockRepo := new(testutil.MockAchievementRepository)
+	mockRepo.On("ListEarned", mock.Anything, int64(123)).Return(map[string]time.Time{"first_word": earnedAt}, nil)
+	mockRepo.On("GetProgress", mock.Anything, int64(123)).Return(domain.AchievementProgress{
+		domain.MetricWordsAdded: 42,
+		domain.MetricBestStreak: 12,
+	}, nil)
+
+	statuses, err := newTestAchievementService(mockRepo).List(context.Background(), 123)
+
+	require.NoError(t, err)
+	require.Len(t, statuses, len(testAchievements))
+
+	assert.Equal(t, &earnedAt, statuses[0].EarnedAt)
+	assert.Equal(t, 1, statuses[0].Progress)
+
+	assert.Nil(t, statuses[1].EarnedAt)
+	assert.Equal(t, 42, statuses[1].Progress)
+
+	// Reached but not awarded yet: progress is capped at the goal
+	assert.Nil(t, statuses[2].EarnedAt)
+	assert.Equal(t, 7, statuses[2].Progress)
+
+	assert.Equal(t, 0, statuses[3].Progress)
+}
+
+// fakeAchievementSender records announced achievements
+type fakeAchievementSender struct {
+	sent []string
+}
+
+func (f *fakeAchievementSender) SendAchievement(ctx context.Context, userID int64, a domain.Achievement) error {
+	f.sent = append(f.sent, a.ID)
+	return nil
+}
+
+func TestSubscribeAchievements(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    domain.Event
+		progress domain.AchievementProgress
+		expected []string
+	}{
+		{
+			name:     "word saved",
+			event:    domain.WordSaved{UserID: 123},
+			progress: domain.AchievementProgress{domain.MetricWordsAdded: 1},
+			expected: []string{"first_word"},
+		},
+		{
+			name:     "correct answer",
+			event:    domain.ReviewGraded{UserID: 123, Correct: true},
+			progress: domain.AchievementProgress{domain.MetricWordsAdded: 100},
+			expected: []string{"first_word", "words_100"},
+		},
+		{
+			name:     "goal reached",
+			event:    domain.GoalReached{UserID: 123, Streak: 7, Best: 7},
+			progress: domain.AchievementProgress{domain.MetricBestStreak: 7},
+			expected: []string{"streak_7"},
+		},
+		{
+			name:     "perfect session",
+			event:    domain.SessionFinished{UserID: 123, Total: 10, Correct: 10, Perfect: true},
+			progress: domain.AchievementProgress{},
+			expected: []string{"perfect_session"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(testutil.MockAchievementRepository)
+			mockRepo.On("ListEarned", mock.Anything, int64(123)).Return(map[string]time.Time{}, nil)
+			mockRepo.On("GetProgress", mock.Anything, int64(123)).Return(tt.progress, nil)
+			mockRepo.On("SaveEarned", mock.Anything, int64(123), tt.expected).Return(tt.expected, nil)
+
+			jobs := &fakeJobs{}
+			bus := NewEventBus(jobs, testutil.NewTestLogger())
+			sender := &fakeAchievementSender{}
+			SubscribeAchievements(bus, newTestAchievementService(mockRepo), sender)
+
+			bus.Publish(context.Background(), tt.event)
+			assert.Empty(t, sender.sent, "achievements are awarded in the background")
+
+			jobs.runAll()
+			assert.Equal(t, tt.expected, sender.sent)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSubscribeAchievements_Ignored(t *testing.T) {
+	mockRepo := new(testutil.MockAchievementRepository)
+	jobs := &fakeJobs{}
+	bus := NewEventBus(jobs, testutil.NewTestLogger())
+	sender := &fakeAchievementSender{}
+	SubscribeAchievements(bus, newTestAchievementService(mockRepo), sender)
+
+	// Wrong answers and imperfect sessions can't earn anything
+	bus.Publish(context.Background(), domain.ReviewGraded{UserID: 123})
+	bus.Publish(context.Background(), domain.SessionFinished{UserID: 123, Total: 10, Correct: 9})
+	jobs.runAll()
+
+	assert.Empty(t, sender.sent)
+	mockRepo.AssertNotCalled(t, "ListEarned", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/repository"
+)
+
+// AuditPageSize is how many entries /audit shows
+const AuditPageSize = 20
+
+// AuditService records security-relevant and data-changing actions
+// and lets admins read them
+type AuditService struct {
+	auditRepo repository.AuditRepository
+	admins    map[int64]bool
+}
+
+// NewAuditService creates a new audit service; adminIDs may read the log and revoke users
+func NewAuditService(auditRepo repository.AuditRepository, adminIDs []int64) *AuditService {
+	admins := make(map[int64]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[id] = true
+	}
+	return &AuditService{auditRepo: auditRepo, admins: admins}
+}
+
+// IsAdmin reports whether the user is an admin
+func (s *AuditService) IsAdmin(userID int64) bool {
+	return s.admins[userID]
+}
+
+// Record writes an entry to the log; metadata may be nil
+func (s *AuditService) Record(ctx context.Context, actorID int64, action domain.AuditAction, target string, metadata map[string]any) error {
+	return s.auditRepo.Save(ctx, &domain.AuditEntry{
+		ActorID:  actorID,
+		Action:   action,
+		Target:   target,
+		Metadata: metadata,
+	})
+}
+
+// List returns the latest entries matching the filter, up to AuditPageSize
+func (s *AuditService) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	if filter.Limit <= 0 || filter.Limit > AuditPageSize {
+		filter.Limit = AuditPageSize
+	}
+	return s.auditRepo.List(ctx, filter)
+}
+
+// OnWordHidden records a word snoozed or hidden forever
+func (s *AuditService) OnWordHidden(ctx context.Context, e domain.WordHidden) error {
+	metadata := map[string]any{"forever": e.Forever()}
+	if !e.Forever() {
+		metadata["until"] = e.Until.UTC().Format(time.RFC3339)
+	}
+	return s.Record(ctx, e.UserID, domain.AuditWordHidden, domain.AuditWord(e.WordID), metadata)
+}
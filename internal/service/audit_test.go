@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditService_IsAdmin(t *testing.T) {
+	service := NewAuditService(new(testutil.MockAuditRepository), []int64{1, 2})
+
+	assert.True(t, service.IsAdmin(1))
+	assert.True(t, service.IsAdmin(2))
+	assert.False(t, service.IsAdmin(3))
+	assert.False(t, NewAuditService(new(testutil.MockAuditRepository), nil).IsAdmin(0))
+}
+
+func TestAuditService_List(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int
+		expectedLimit int
+	}{
+		{name: "default", limit: 0, expectedLimit: AuditPageSize},
+		{name: "smaller", limit: 5, expectedLimit: 5},
+		{name: "too large", limit: 1000, expectedLimit: AuditPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(testutil.MockAuditRepository)
+			expected := domain.AuditFilter{UserID: 123, Action: domain.AuditAuthFailed, Limit: tt.expectedLimit}
+			mockRepo.On("List", mock.Anything, expected).Return([]domain.AuditEntry{{ID: 1}}, nil)
+
+			service := NewAuditService(mockRepo, nil)
+			entries, err := service.List(context.Background(), domain.AuditFilter{UserID: 123, Action: domain.AuditAuthFailed, Limit: tt.limit})
+
+			assert.NoError(t, err)
+			assert.Len(t, entries, 1)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuditService_OnWordHidden(t *testing.T) {
+	until := time.Date(2024, 12, 15, 10, 0, 0, 0, time.FixedZone("MSK", 3*3600))
+
+	tests := []struct {
+		name     string
+		event    domain.WordHidden
+		metadata map[string]any
+	}{
+		{
+			name:     "snoozed",
+			event:    domain.WordHidden{UserID: 123, WordID: 7, Until: until},
+			metadata: map[string]any{"forever": false, "until": "2024-12-15T07:00:00Z"},
+		},
+		{
+			name:     "forever",
+			event:    domain.WordHidden{UserID: 123, WordID: 7},
+			metadata: map[string]any{"forever": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(testutil.MockAuditRepository)
+			var saved *domain.AuditEntry
+			mockRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(*domain.AuditEntry)
+			}).Return(nil)
+
+			service := NewAuditService(mockRepo, nil)
+			require.NoError(t, service.OnWordHidden(context.Background(), tt.event))
+
+			require.NotNil(t, saved)
+			assert.Equal(t, int64(123), saved.ActorID)
+			assert.Equal(t, domain.AuditWordHidden, saved.Action)
+			assert.Equal(t, "word:7", saved.Target)
+			assert.Equal(t, tt.metadata, saved.Metadata)
+		})
+	}
+}
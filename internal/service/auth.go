@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"languager/internal/repository"
 )
 
@@ -24,17 +25,21 @@ func (s *AuthService) CheckPassword(password string) bool {
 }
 
 // IsAuthorized checks if user is authorized
-func (s *AuthService) IsAuthorized(userID int64) (bool, error) {
-	return s.userRepo.IsAuthorized(userID)
+func (s *AuthService) IsAuthorized(ctx context.Context, userID int64) (bool, error) {
+	return s.userRepo.IsAuthorized(ctx, userID)
 }
 
 // AuthorizeUser authorizes a user
-func (s *AuthService) AuthorizeUser(userID int64) error {
-	return s.userRepo.AuthorizeUser(userID)
+func (s *AuthService) AuthorizeUser(ctx context.Context, userID int64) error {
+	return s.userRepo.AuthorizeUser(ctx, userID)
 }
 
-// EnsureUserExists creates user record if doesn't exist
-func (s *AuthService) EnsureUserExists(userID int64) error {
-	return s.userRepo.EnsureUserExists(userID)
+// RevokeUser revokes user's authorization
+func (s *AuthService) RevokeUser(ctx context.Context, userID int64) error {
+	return s.userRepo.RevokeUser(ctx, userID)
 }
 
+// EnsureUserExists creates user record if doesn't exist
+func (s *AuthService) EnsureUserExists(ctx context.Context, userID int64) error {
+	return s.userRepo.EnsureUserExists(ctx, userID)
+}
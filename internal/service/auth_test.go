@@ -1,11 +1,13 @@
 package service
 
 import (
+	"context"
 	"testing"
 
 	"languager/internal/testutil"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestAuthService_CheckPassword(t *testing.T) {
@@ -83,11 +85,11 @@ func TestAuthService_IsAuthorized(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(testutil.MockUserRepository)
-			mockRepo.On("IsAuthorized", tt.userID).Return(tt.mockReturn, tt.mockError)
+			mockRepo.On("IsAuthorized", mock.Anything, tt.userID).Return(tt.mockReturn, tt.mockError)
 
 			service := NewAuthService(mockRepo, "password")
 
-			authorized, err := service.IsAuthorized(tt.userID)
+			authorized, err := service.IsAuthorized(context.Background(), tt.userID)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -103,25 +105,36 @@ func TestAuthService_IsAuthorized(t *testing.T) {
 
 func TestAuthService_AuthorizeUser(t *testing.T) {
 	mockRepo := new(testutil.MockUserRepository)
-	mockRepo.On("AuthorizeUser", int64(123)).Return(nil)
+	mockRepo.On("AuthorizeUser", mock.Anything, int64(123)).Return(nil)
 
 	service := NewAuthService(mockRepo, "password")
 
-	err := service.AuthorizeUser(123)
+	err := service.AuthorizeUser(context.Background(), 123)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestAuthService_EnsureUserExists(t *testing.T) {
+func TestAuthService_RevokeUser(t *testing.T) {
 	mockRepo := new(testutil.MockUserRepository)
-	mockRepo.On("EnsureUserExists", int64(123)).Return(nil)
+	mockRepo.On("RevokeUser", mock.Anything, int64(123)).Return(nil)
 
 	service := NewAuthService(mockRepo, "password")
 
-	err := service.EnsureUserExists(123)
+	err := service.RevokeUser(context.Background(), 123)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAuthService_EnsureUserExists(t *testing.T) {
+	mockRepo := new(testutil.MockUserRepository)
+	mockRepo.On("EnsureUserExists", mock.Anything, int64(123)).Return(nil)
+
+	service := NewAuthService(mockRepo, "password")
+
+	err := service.EnsureUserExists(context.Background(), 123)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
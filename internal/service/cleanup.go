@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"languager/internal/domain"
+	"languager/internal/metrics"
+	"languager/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+// CleanupService removes expired data
+type CleanupService struct {
+	wordRepo      repository.WordRepository
+	auditService  *AuditService
+	retentionDays int
+	logger        *zap.Logger
+}
+
+// NewCleanupService creates a new cleanup service.
+// retentionDays applies to users who haven't changed their history setting.
+func NewCleanupService(wordRepo repository.WordRepository, auditService *AuditService, retentionDays int, logger *zap.Logger) *CleanupService {
+	return &CleanupService{
+		wordRepo:      wordRepo,
+		auditService:  auditService,
+		retentionDays: retentionDays,
+		logger:        logger,
+	}
+}
+
+// CleanupOldData removes words older than each user's history window
+func (s *CleanupService) CleanupOldData(ctx context.Context) error {
+	s.logger.Info("Starting cleanup of old words", zap.Int("default_retention_days", s.retentionDays))
+
+	deleted, err := s.wordRepo.CleanOldWords(ctx, s.retentionDays)
+	if err != nil {
+		metrics.CleanupRuns.WithLabelValues("error").Inc()
+		s.logger.Error("Failed to cleanup old words", zap.Error(err))
+		return err
+	}
+
+	metrics.CleanupRuns.WithLabelValues("success").Inc()
+
+	if deleted > 0 {
+		metadata := map[string]any{"words": deleted, "default_retention_days": s.retentionDays}
+		if err := s.auditService.Record(ctx, domain.SystemActor, domain.AuditWordsCleaned, "", metadata); err != nil {
+			s.logger.Error("Failed to record cleanup in audit log", zap.Error(err))
+		}
+	}
+
+	s.logger.Info("Cleanup completed successfully", zap.Int64("deleted", deleted))
+	return nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCleanupService_CleanupOldData(t *testing.T) {
+	tests := []struct {
+		name          string
+		deleted       int64
+		mockError     error
+		auditError    error
+		expectAudit   bool
+		expectedError bool
+	}{
+		{
+			name:          "successful cleanup",
+			deleted:       3,
+			mockError:     nil,
+			expectAudit:   true,
+			expectedError: false,
+		},
+		{
+			name:          "nothing to clean",
+			deleted:       0,
+			mockError:     nil,
+			expectAudit:   false,
+			expectedError: false,
+		},
+		{
+			name:          "audit error doesn't fail cleanup",
+			deleted:       3,
+			auditError:    fmt.Errorf("db error"),
+			expectAudit:   true,
+			expectedError: false,
+		},
+		{
+			name:          "database error",
+			mockError:     fmt.Errorf("db error"),
+			expectAudit:   false,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(testutil.MockWordRepository)
+			mockRepo.On("CleanOldWords", mock.Anything, 60).Return(tt.deleted, tt.mockError)
+
+			mockAudit := new(testutil.MockAuditRepository)
+			if tt.expectAudit {
+				mockAudit.On("Save", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+					return e.ActorID == domain.SystemActor && e.Action == domain.AuditWordsCleaned && e.Metadata["words"] == tt.deleted
+				})).Return(tt.auditError)
+			}
+
+			logger := testutil.NewTestLogger()
+			service := NewCleanupService(mockRepo, NewAuditService(mockAudit, nil), 60, logger)
+
+			err := service.CleanupOldData(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockAudit.AssertExpectations(t)
+		})
+	}
+}
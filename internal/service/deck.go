@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"languager/internal/domain"
+	"languager/internal/repository"
+)
+
+var (
+	// ErrDeckNotFound is returned for unknown share codes
+	ErrDeckNotFound = errors.New("deck not found")
+	// ErrDeckForbidden is returned when the user's role doesn't allow the action,
+	// e.g. deleting someone else's deck or subscribing to one's own
+	ErrDeckForbidden = errors.New("deck action not allowed")
+	// ErrDeckExists is returned when the language pair is already published
+	ErrDeckExists = errors.New("deck of the pair already exists")
+	// ErrInvalidDeckName is returned for empty or too long names
+	ErrInvalidDeckName = errors.New("invalid deck name")
+)
+
+// DeckService publishes language pairs as shared decks and manages subscriptions.
+// Anyone who knows the share code may view a deck, copy its words or subscribe;
+// only the owner may delete it, and only subscribers may change their subscription.
+type DeckService struct {
+	deckRepo repository.DeckRepository
+	newCode  func() (string, error)
+}
+
+// NewDeckService creates a new deck service
+func NewDeckService(deckRepo repository.DeckRepository) *DeckService {
+	return &DeckService{deckRepo: deckRepo, newCode: newDeckCode}
+}
+
+// Publish shares the owner's words of the pair, including ones added later, as a deck
+func (s *DeckService) Publish(ctx context.Context, ownerID int64, pair domain.LanguagePair, name string) (*domain.Deck, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || utf8.RuneCountInString(name) > domain.MaxDeckNameLength {
+		return nil, ErrInvalidDeckName
+	}
+
+	own, err := s.deckRepo.ListOwnDecks(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range own {
+		if d.Pair == pair {
+			return nil, ErrDeckExists
+		}
+	}
+
+	code, err := s.newCode()
+	if err != nil {
+		return nil, err
+	}
+	deck := &domain.Deck{OwnerID: ownerID, Code: code, Name: name, Pair: pair}
+	if err := s.deckRepo.CreateDeck(ctx, deck); err != nil {
+		return nil, err
+	}
+	return deck, nil
+}
+
+// Open returns the deck with the share code as seen by the user
+func (s *DeckService) Open(ctx context.Context, userID int64, code string) (*domain.DeckView, error) {
+	deck, err := s.deckRepo.GetDeckByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if deck == nil {
+		return nil, ErrDeckNotFound
+	}
+
+	view := &domain.DeckView{Deck: *deck, Role: domain.DeckVisitor}
+	if deck.OwnerID == userID {
+		view.Role = domain.DeckOwner
+		return view, nil
+	}
+
+	sub, err := s.deckRepo.GetSubscription(ctx, deck.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if sub != nil {
+		view.Role = domain.DeckSubscriber
+		view.Follow = sub.Follow
+	}
+	return view, nil
+}
+
+// ListDecks returns decks published by the user and decks the user is subscribed to
+func (s *DeckService) ListDecks(ctx context.Context, userID int64) (own, subscribed []domain.Deck, err error) {
+	if own, err = s.deckRepo.ListOwnDecks(ctx, userID); err != nil {
+		return nil, nil, err
+	}
+	if subscribed, err = s.deckRepo.ListSubscribedDecks(ctx, userID); err != nil {
+		return nil, nil, err
+	}
+	return own, subscribed, nil
+}
+
+// Copy copies the deck's words the user doesn't have yet and returns how many
+func (s *DeckService) Copy(ctx context.Context, userID int64, code string) (int, error) {
+	view, err := s.open(ctx, userID, code, domain.DeckVisitor, domain.DeckSubscriber)
+	if err != nil {
+		return 0, err
+	}
+	return s.deckRepo.CopyDeckWords(ctx, view.ID, userID)
+}
+
+// Subscribe subscribes the user to the owner's future additions
+// and copies the current words. Returns how many words were copied.
+func (s *DeckService) Subscribe(ctx context.Context, userID int64, code string) (int, error) {
+	view, err := s.open(ctx, userID, code, domain.DeckVisitor, domain.DeckSubscriber)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.deckRepo.SaveSubscription(ctx, domain.DeckSubscription{DeckID: view.ID, UserID: userID, Follow: true}); err != nil {
+		return 0, err
+	}
+	return s.deckRepo.CopyDeckWords(ctx, view.ID, userID)
+}
+
+// SetFollow turns copying of the owner's future additions on or off
+func (s *DeckService) SetFollow(ctx context.Context, userID int64, code string, follow bool) error {
+	view, err := s.open(ctx, userID, code, domain.DeckSubscriber)
+	if err != nil {
+		return err
+	}
+	return s.deckRepo.SaveSubscription(ctx, domain.DeckSubscription{DeckID: view.ID, UserID: userID, Follow: follow})
+}
+
+// Unsubscribe removes the subscription; copied words stay
+func (s *DeckService) Unsubscribe(ctx context.Context, userID int64, code string) error {
+	view, err := s.open(ctx, userID, code, domain.DeckSubscriber)
+	if err != nil {
+		return err
+	}
+	return s.deckRepo.DeleteSubscription(ctx, view.ID, userID)
+}
+
+// Delete deletes the owner's deck; words copied by others stay with them
+func (s *DeckService) Delete(ctx context.Context, userID int64, code string) error {
+	view, err := s.open(ctx, userID, code, domain.DeckOwner)
+	if err != nil {
+		return err
+	}
+	return s.deckRepo.DeleteDeck(ctx, view.ID)
+}
+
+// OnWordSaved puts a new word into the owner's decks and copies it to followers
+func (s *DeckService) OnWordSaved(ctx context.Context, e domain.WordSaved) error {
+	_, err := s.WordAdded(ctx, e.UserID, e.Pair, e.WordID)
+	return err
+}
+
+// WordAdded puts a word the owner just saved into their decks of the pair
+// and copies it to following subscribers. Returns how many copies were made.
+func (s *DeckService) WordAdded(ctx context.Context, ownerID int64, pair domain.LanguagePair, wordID int) (int, error) {
+	deckIDs, err := s.deckRepo.AddWordToDecks(ctx, ownerID, pair, wordID)
+	if err != nil {
+		return 0, err
+	}
+
+	copied := 0
+	for _, id := range deckIDs {
+		n, err := s.deckRepo.CopyWordToFollowers(ctx, id, wordID)
+		if err != nil {
+			return copied, err
+		}
+		copied += n
+	}
+	return copied, nil
+}
+
+// open opens the deck and checks that the user has one of the roles
+func (s *DeckService) open(ctx context.Context, userID int64, code string, roles ...domain.DeckRole) (*domain.DeckView, error) {
+	view, err := s.Open(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if view.Role == role {
+			return view, nil
+		}
+	}
+	return nil, ErrDeckForbidden
+}
+
+// newDeckCode returns a random share code
+func newDeckCode() (string, error) {
+	buf := make([]byte, domain.DeckCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate deck code: %w", err)
+	}
+	// 256 is a multiple of the alphabet size, so every character is equally likely
+	for i, b := range buf {
+		buf[i] = domain.DeckCodeAlphabet[int(b)%len(domain.DeckCodeAlphabet)]
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testDeck = &domain.Deck{ID: 5, OwnerID: 123, Code: "abc234de", Name: "Course", Pair: domain.DefaultLanguagePair}
+
+func newTestDeckService(repo *testutil.MockDeckRepository) *DeckService {
+	s := NewDeckService(repo)
+	s.newCode = func() (string, error) { return "abc234de", nil }
+	return s
+}
+
+func TestDeckService_Publish(t *testing.T) {
+	mockRepo := new(testutil.MockDeckRepository)
+	mockRepo.On("ListOwnDecks", mock.Anything, int64(123)).Return([]domain.Deck{
+		{ID: 4, OwnerID: 123, Pair: domain.LanguagePair{Source: "de", Target: "ru"}},
+	}, nil)
+	mockRepo.On("CreateDeck", mock.Anything, &domain.Deck{OwnerID: 123, Code: "abc234de", Name: "My course", Pair: domain.DefaultLanguagePair}).Return(nil)
+
+	deck, err := newTestDeckService(mockRepo).Publish(context.Background(), 123, domain.DefaultLanguagePair, "  My   course ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "abc234de", deck.Code)
+	assert.Equal(t, "My course", deck.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeckService_Publish_Errors(t *testing.T) {
+	mockRepo := new(testutil.MockDeckRepository)
+	mockRepo.On("ListOwnDecks", mock.Anything, int64(123)).Return([]domain.Deck{*testDeck}, nil)
+	service := newTestDeckService(mockRepo)
+
+	_, err := service.Publish(context.Background(), 123, domain.DefaultLanguagePair, " ")
+	assert.ErrorIs(t, err, ErrInvalidDeckName)
+
+	_, err = service.Publish(context.Background(), 123, domain.DefaultLanguagePair, strings.Repeat("a", domain.MaxDeckNameLength+1))
+	assert.ErrorIs(t, err, ErrInvalidDeckName)
+
+	_, err = service.Publish(context.Background(), 123, domain.DefaultLanguagePair, "Again")
+	assert.ErrorIs(t, err, ErrDeckExists)
+
+	mockRepo.AssertNotCalled(t, "CreateDeck", mock.Anything, mock.Anything)
+}
+
+func TestDeckService_Open(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID int64
+		sub    *domain.DeckSubscription
+		role   domain.DeckRole
+		follow bool
+	}{
+		{name: "owner", userID: 123, role: domain.DeckOwner},
+		{name: "visitor", userID: 456, role: domain.DeckVisitor},
+		{name: "subscriber", userID: 456, sub: &domain.DeckSubscription{DeckID: 5, UserID: 456, Follow: true}, role: domain.DeckSubscriber, follow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(testutil.MockDeckRepository)
+			mockRepo.On("GetDeckByCode", mock.Anything, "abc234de").Return(testDeck, nil)
+			if tt.userID != testDeck.OwnerID {
+				if tt.sub != nil {
+					mockRepo.On("GetSubscription", mock.Anything, 5, tt.userID).Return(tt.sub, nil)
+				} else {
+					mockRepo.On("GetSubscription", mock.Anything, 5, tt.userID).Return(nil, nil)
+				}
+			}
+
+			view, err := newTestDeckService(mockRepo).Open(context.Background(), tt.userID, "abc234de")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.role, view.Role)
+			assert.Equal(t, tt.follow, view.Follow)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeckService_Open_NotFound(t *testing.T) {
+	mockRepo := new(testutil.MockDeckRepository)
+	mockRepo.On("GetDeckByCode", mock.Anything, "zzzzzzzz").Return(nil, nil)
+
+	_, err := newTestDeckService(mockRepo).Open(context.Background(), 456, "zzzzzzzz")
+
+	assert.ErrorIs(t, err, ErrDeckNotFound)
+}
+
+func TestDeckService_Subscribe(t *testing.T) {
+	mockRepo := new(testutil.MockDeckRepository)
+	mockRepo.On("GetDeckByCode", mock.Anything, "abc234de").Return(testDeck, nil)
+	mockRepo.On("GetSubscription", mock.Anything, 5, int64(456)).Return(nil, nil)
+	mockRepo.On("SaveSubscription", mock.Anything, domain.DeckSubscription{DeckID: 5, UserID: 456, Follow: true}).Return(nil)
+	mockRepo.On("CopyDeckWords", mock.Anything, 5, int64(456)).Return(12, nil)
+
+	copied, err := newTestDeckService(mockRepo).Subscribe(context.Background(), 456, "abc234de")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, copied)
+	mockRepo.AssertExpectations(t)
+}
+
+// The permission model: every action is allowed to some roles only
+func TestDeckService_Permissions(t *testing.T) {
+	ctx := context.Background()
+	const owner, visitor = int64(123), int64(456)
+
+	mockRepo := new(testutil.MockDeckRepository)
+	mockRepo.On("GetDeckByCode", mock.Anything, "abc234de").Return(testDeck, nil)
+	mockRepo.On("GetSubscription", mock.Anything, 5, visitor).Return(nil, nil)
+	service := newTestDeckService(mockRepo)
+
+	_, err := service.Copy(ctx, owner, "abc234de")
+	assert.ErrorIs(t, err, ErrDeckForbidden, "owner copies own deck")
+	_, err = service.Subscribe(ctx, owner, "abc234de")
+	assert.ErrorIs(t, err, ErrDeckForbidden, "owner subscribes to own deck")
+	assert.ErrorIs(t, service.SetFollow(ctx, visitor, "abc234de", false), ErrDeckForbidden, "visitor changes subscription")
+	assert.ErrorIs(t, service.Unsubscribe(ctx, visitor, "abc234de"), ErrDeckForbidden, "visitor unsubscribes")
+	assert.ErrorIs(t, service.Delete(ctx, visitor, "abc234de"), ErrDeckForbidden, "visitor deletes deck")
+
+	mockRepo.AssertNotCalled(t, "CopyDeckWords", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "SaveSubscription", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "DeleteSubscription", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "DeleteDeck", mock.Anything, mock.Anything)
+}
+
+func TestDeckService_Delete(t *testing.T) {
+	mockRepo := new(testutil.MockDeckRepository)
+	mockRepo.On("GetDeckByCode", mock.Anything, "abc234de").Return(testDeck, nil)
+	mockRepo.On("DeleteDeck", mock.Anything, 5).Return(nil)
+
+	err := newTestDeckService(mockRepo).Delete(context.Background(), 123, "abc234de")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeckService_WordAdded(t *testing.T) {
+	mockRepo := new(testutil.MockDeckRepository)
+	mockRepo.On("AddWordToDecks", mock.Anything, int64(123), domain.DefaultLanguagePair, 42).Return([]int{5}, nil)
+	mockRepo.On("CopyWordToFollowers", mock.Anything, 5, 42).Return(3, nil)
+
+	copied, err := newTestDeckService(mockRepo).WordAdded(context.Background(), 123, domain.DefaultLanguagePair, 42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, copied)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNewDeckCode(t *testing.T) {
+	code, err := newDeckCode()
+	assert.NoError(t, err)
+
+	parsed, ok := domain.ParseDeckCode(code)
+	assert.True(t, ok, code)
+	assert.Equal(t, code, parsed)
+}
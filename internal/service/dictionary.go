@@ -0,0 +1,28 @@
+package service
+
+import (
+	"languager/internal/dictionary"
+	"languager/internal/domain"
+)
+
+// suggestionsLimit is how many translations are offered for a new word
+const suggestionsLimit = 4
+
+// DictionaryService suggests translations of new words
+type DictionaryService struct {
+	dict dictionary.Dictionary
+}
+
+// NewDictionaryService creates a new dictionary service.
+// A nil dictionary suggests nothing.
+func NewDictionaryService(dict dictionary.Dictionary) *DictionaryService {
+	return &DictionaryService{dict: dict}
+}
+
+// Suggest returns translations of word in the language pair, best first
+func (s *DictionaryService) Suggest(pair domain.LanguagePair, word string) []string {
+	if s.dict == nil {
+		return nil
+	}
+	return s.dict.Lookup(pair, word, suggestionsLimit)
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"languager/internal/dictionary"
+	"languager/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDictionaryService_Suggest(t *testing.T) {
+	dict := dictionary.NewTSV()
+	require.NoError(t, dict.Load(domain.DefaultLanguagePair, strings.NewReader("run\tбежать; бегать; запускать; управлять; работать\n")))
+
+	service := NewDictionaryService(dict)
+
+	assert.Equal(t, []string{"бежать", "бегать", "запускать", "управлять"}, service.Suggest(domain.DefaultLanguagePair, "run"))
+	assert.Empty(t, service.Suggest(domain.DefaultLanguagePair, "walk"))
+}
+
+func TestDictionaryService_Suggest_NoDictionary(t *testing.T) {
+	service := NewDictionaryService(nil)
+
+	assert.Empty(t, service.Suggest(domain.DefaultLanguagePair, "run"))
+}
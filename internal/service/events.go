@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/metrics"
+
+	"go.uber.org/zap"
+)
+
+// Delivery tells when a subscriber runs
+type Delivery int
+
+const (
+	// Sync subscribers run in Publish, one after another in subscription order
+	Sync Delivery = iota
+	// Async subscribers run in the background after Publish returns
+	Async
+)
+
+// AsyncEventTimeout limits an async subscriber; it outlives the request
+// that published the event, so it can't use the request's deadline
+const AsyncEventTimeout = 30 * time.Second
+
+// JobRunner runs async subscribers; *shutdown.Coordinator lets shutdown wait for them
+type JobRunner interface {
+	Go(name string, job func())
+}
+
+// subscriber is a named handler of one event type
+type subscriber struct {
+	name     string
+	delivery Delivery
+	accepts  func(event domain.Event) bool
+	handle   func(ctx context.Context, event domain.Event) error
+}
+
+// EventBus delivers domain events to subscribers in-process.
+// A failing or panicking subscriber is logged and doesn't affect the publisher
+// or other subscribers.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers []subscriber
+	jobs        JobRunner
+	logger      *zap.Logger
+}
+
+// NewEventBus creates a bus; async subscribers run on jobs, or in plain
+// goroutines if jobs is nil
+func NewEventBus(jobs JobRunner, logger *zap.Logger) *EventBus {
+	return &EventBus{jobs: jobs, logger: logger}
+}
+
+// Subscribe registers handle for events of type E under name
+func Subscribe[E domain.Event](bus *EventBus, name string, delivery Delivery, handle func(ctx context.Context, event E) error) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	bus.subscribers = append(bus.subscribers, subscriber{
+		name:     name,
+		delivery: delivery,
+		accepts: func(event domain.Event) bool {
+			_, ok := event.(E)
+			return ok
+		},
+		handle: func(ctx context.Context, event domain.Event) error {
+			return handle(ctx, event.(E))
+		},
+	})
+}
+
+// Publish delivers the event to its subscribers. Sync ones are done when it returns.
+func (b *EventBus) Publish(ctx context.Context, event domain.Event) {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	for _, sub := range subscribers {
+		if !sub.accepts(event) {
+			continue
+		}
+		if sub.delivery == Sync {
+			b.deliver(ctx, sub, event)
+			continue
+		}
+
+		sub := sub
+		job := func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), AsyncEventTimeout)
+			defer cancel()
+			b.deliver(ctx, sub, event)
+		}
+		if b.jobs != nil {
+			b.jobs.Go("event "+event.EventName()+" "+sub.name, job)
+		} else {
+			go job()
+		}
+	}
+}
+
+// deliver runs one subscriber, turning a panic into an error
+func (b *EventBus) deliver(ctx context.Context, sub subscriber, event domain.Event) {
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		err = sub.handle(ctx, event)
+	}()
+	if err == nil {
+		return
+	}
+
+	metrics.EventErrors.WithLabelValues(event.EventName(), sub.name).Inc()
+	b.logger.Error("Event subscriber failed",
+		zap.Error(err),
+		zap.String("event", event.EventName()),
+		zap.String("subscriber", sub.name),
+	)
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/metrics"
+	"languager/internal/testutil"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJobs records async jobs and runs them on demand
+type fakeJobs struct {
+	names []string
+	jobs  []func()
+}
+
+func (f *fakeJobs) Go(name string, job func()) {
+	f.names = append(f.names, name)
+	f.jobs = append(f.jobs, job)
+}
+
+func (f *fakeJobs) runAll() {
+	for _, job := range f.jobs {
+		job()
+	}
+}
+
+func TestEventBus_SyncOrderAndTypes(t *testing.T) {
+	bus := NewEventBus(nil, testutil.NewTestLogger())
+
+	var got []string
+	Subscribe(bus, "first", Sync, func(ctx context.Context, e domain.WordSaved) error {
+		got = append(got, "first "+e.Word)
+		return nil
+	})
+	Subscribe(bus, "hidden", Sync, func(ctx context.Context, e domain.WordHidden) error {
+		got = append(got, "hidden")
+		return nil
+	})
+	Subscribe(bus, "second", Sync, func(ctx context.Context, e domain.WordSaved) error {
+		got = append(got, "second "+e.Word)
+		return nil
+	})
+
+	bus.Publish(context.Background(), domain.WordSaved{Word: "hello"})
+
+	assert.Equal(t, []string{"first hello", "second hello"}, got)
+}
+
+func TestEventBus_IsolatesFailures(t *testing.T) {
+	bus := NewEventBus(nil, testutil.NewTestLogger())
+
+	called := false
+	Subscribe(bus, "failing", Sync, func(ctx context.Context, e domain.ReviewGraded) error {
+		return errors.New("boom")
+	})
+	Subscribe(bus, "panicking", Sync, func(ctx context.Context, e domain.ReviewGraded) error {
+		panic("boom")
+	})
+	Subscribe(bus, "healthy", Sync, func(ctx context.Context, e domain.ReviewGraded) error {
+		called = true
+		return nil
+	})
+
+	assert.NotPanics(t, func() {
+		bus.Publish(context.Background(), domain.ReviewGraded{UserID: 123})
+	})
+	assert.True(t, called)
+}
+
+func TestEventBus_Async(t *testing.T) {
+	jobs := &fakeJobs{}
+	bus := NewEventBus(jobs, testutil.NewTestLogger())
+
+	ran := false
+	var ctxErr error
+	var hasDeadline bool
+	Subscribe(bus, "decks", Async, func(ctx context.Context, e domain.WordSaved) error {
+		ran = true
+		ctxErr = ctx.Err()
+		_, hasDeadline = ctx.Deadline()
+		return nil
+	})
+	Subscribe(bus, "other", Async, func(ctx context.Context, e domain.WordHidden) error {
+		t.Fatal("unexpected event")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bus.Publish(ctx, domain.WordSaved{UserID: 123})
+	cancel()
+
+	require.Equal(t, []string{"event word_saved decks"}, jobs.names)
+	assert.False(t, ran, "async subscriber must not run in Publish")
+
+	jobs.runAll()
+	require.True(t, ran)
+	assert.NoError(t, ctxErr, "async subscriber outlives the publisher's context")
+	assert.True(t, hasDeadline)
+}
+
+func TestEventBus_AsyncWithoutRunner(t *testing.T) {
+	bus := NewEventBus(nil, testutil.NewTestLogger())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	Subscribe(bus, "async", Async, func(ctx context.Context, e domain.WordSaved) error {
+		defer wg.Done()
+		panic("boom")
+	})
+
+	bus.Publish(context.Background(), domain.WordSaved{})
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("async subscriber didn't run")
+	}
+}
+
+func TestWordService_PublishesEvents(t *testing.T) {
+	mockRepo := new(testutil.MockWordRepository)
+	mockRepo.On("SaveWord", mock.Anything, int64(123), domain.DefaultLanguagePair, "hello", "привет").Return(42, nil)
+	mockRepo.On("SnoozeWord", mock.Anything, int64(123), 42, 72*time.Hour).Return(nil)
+	mockRepo.On("HideWordForever", mock.Anything, int64(123), 42).Return(nil)
+	mockRepo.On("RecordReview", mock.Anything, int64(123), 42, true, domain.DefaultReviewDirection).Return(true, nil)
+	mockRepo.On("RecordReview", mock.Anything, int64(123), 7, false, domain.DefaultReviewDirection).Return(false, errors.New("db error"))
+
+	bus := NewEventBus(nil, testutil.NewTestLogger())
+	var events []domain.Event
+	Subscribe(bus, "saved", Sync, func(ctx context.Context, e domain.WordSaved) error {
+		events = append(events, e)
+		return nil
+	})
+	Subscribe(bus, "hidden", Sync, func(ctx context.Context, e domain.WordHidden) error {
+		events = append(events, e)
+		return nil
+	})
+	Subscribe(bus, "graded", Sync, func(ctx context.Context, e domain.ReviewGraded) error {
+		events = append(events, e)
+		return nil
+	})
+
+	service := NewWordService(mockRepo, bus)
+	ctx := context.Background()
+
+	_, err := service.SaveWordPair(ctx, 123, domain.DefaultLanguagePair, "hello", "привет")
+	require.NoError(t, err)
+	require.NoError(t, service.SnoozeWord(ctx, 123, 42, 72*time.Hour))
+	require.NoError(t, service.HideWordForever(ctx, 123, 42))
+	_, err = service.GradeReview(ctx, 123, 42, true, domain.DefaultReviewDirection)
+	require.NoError(t, err)
+	_, err = service.GradeReview(ctx, 123, 7, false, domain.DefaultReviewDirection)
+	require.Error(t, err)
+
+	require.Len(t, events, 4, "failed calls publish nothing")
+	assert.Equal(t, domain.WordSaved{UserID: 123, WordID: 42, Pair: domain.DefaultLanguagePair, Word: "hello", Translation: "привет"}, events[0])
+
+	snoozed := events[1].(domain.WordHidden)
+	assert.False(t, snoozed.Forever())
+	assert.WithinDuration(t, time.Now().Add(72*time.Hour), snoozed.Until, time.Minute)
+
+	assert.True(t, events[2].(domain.WordHidden).Forever())
+	assert.Equal(t, domain.ReviewGraded{UserID: 123, WordID: 42, Correct: true, Direction: domain.DefaultReviewDirection, Mastered: true}, events[3])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWordService_FinishSession(t *testing.T) {
+	bus := NewEventBus(nil, testutil.NewTestLogger())
+	var got []domain.SessionFinished
+	Subscribe(bus, "finished", Sync, func(ctx context.Context, e domain.SessionFinished) error {
+		got = append(got, e)
+		return nil
+	})
+
+	ids := make([]int, domain.PerfectSessionMin)
+	perfect := domain.NewReviewSession(ids)
+	for !perfect.Done() {
+		perfect.Answer(true)
+	}
+	flawed := domain.NewReviewSession(ids)
+	for !flawed.Done() {
+		flawed.Answer(flawed.Pos > 0)
+	}
+
+	service := NewWordService(new(testutil.MockWordRepository), bus)
+	service.FinishSession(context.Background(), 123, perfect)
+	service.FinishSession(context.Background(), 123, flawed)
+
+	assert.Equal(t, []domain.SessionFinished{
+		{UserID: 123, Total: 10, Correct: 10, Perfect: true},
+		{UserID: 123, Total: 10, Correct: 9, Perfect: false},
+	}, got)
+}
+
+func TestSubscribeMetrics_CountsReviewsWhenGraded(t *testing.T) {
+	bus := NewEventBus(nil, testutil.NewTestLogger())
+	SubscribeMetrics(bus)
+
+	before := promtestutil.ToFloat64(metrics.ReviewsDone)
+	correct := promtestutil.ToFloat64(metrics.ReviewsGraded.WithLabelValues("correct"))
+
+	bus.Publish(context.Background(), domain.ReviewGraded{UserID: 123, Correct: true, Direction: domain.DirectionCloze})
+	bus.Publish(context.Background(), domain.ReviewGraded{UserID: 123, Direction: domain.DefaultReviewDirection})
+
+	assert.Equal(t, before+2, promtestutil.ToFloat64(metrics.ReviewsDone), "every mode counts a review once graded")
+	assert.Equal(t, correct+1, promtestutil.ToFloat64(metrics.ReviewsGraded.WithLabelValues("correct")))
+}
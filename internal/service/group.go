@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"unicode/utf8"
+
+	"languager/internal/domain"
+	"languager/internal/repository"
+)
+
+const (
+	// GroupQuizOptions is the number of answers offered by a quiz
+	GroupQuizOptions = 4
+	// GroupTopLimit is the length of a group's leaderboard
+	GroupTopLimit = 10
+	// MaxGroupWordLength limits words and translations added in groups, in characters
+	MaxGroupWordLength = 100
+)
+
+var (
+	// ErrInvalidGroupWord is returned when /add isn't "word - translation"
+	// or either part is over MaxGroupWordLength
+	ErrInvalidGroupWord = errors.New("invalid group word")
+	// ErrGroupWordExists is returned when the group has the word already
+	ErrGroupWordExists = errors.New("group word already exists")
+	// ErrNotEnoughGroupWords is returned when a quiz can't offer a choice
+	ErrNotEnoughGroupWords = errors.New("not enough group words for a quiz")
+	// ErrQuizNotFound is returned for answers to quizzes of another chat or deleted ones
+	ErrQuizNotFound = errors.New("quiz not found")
+)
+
+// QuizOutcome is the result of a member's answer to a quiz
+type QuizOutcome int
+
+const (
+	// QuizWrong is a wrong answer; the member can't answer again
+	QuizWrong QuizOutcome = iota
+	// QuizRepeated is a second answer of the same member, ignored
+	QuizRepeated
+	// QuizTaken is an answer to a quiz someone else has solved
+	QuizTaken
+	// QuizWon is the first correct answer
+	QuizWon
+)
+
+// GroupService keeps group chats' shared vocabularies, quizzes and leaderboards.
+// Group data is separate from members' private words.
+type GroupService struct {
+	groupRepo repository.GroupRepository
+	shuffle   func(n int, swap func(i, j int))
+}
+
+// NewGroupService creates a new group service
+func NewGroupService(groupRepo repository.GroupRepository) *GroupService {
+	return &GroupService{groupRepo: groupRepo, shuffle: rand.Shuffle}
+}
+
+// IsEnabled reports whether the bot was enabled in the chat
+func (s *GroupService) IsEnabled(ctx context.Context, chatID int64) (bool, error) {
+	return s.groupRepo.GroupExists(ctx, chatID)
+}
+
+// Enable turns the bot on in the chat; the caller checks that userID may do it
+func (s *GroupService) Enable(ctx context.Context, chatID int64, title string, userID int64) error {
+	return s.groupRepo.SaveGroup(ctx, chatID, title, userID)
+}
+
+// AddWord adds "word - translation" to the group's vocabulary
+func (s *GroupService) AddWord(ctx context.Context, chatID, userID int64, text string) (*domain.GroupWord, error) {
+	word, translation, ok := domain.ParseGroupWord(text)
+	if !ok ||
+		utf8.RuneCountInString(word) > MaxGroupWordLength ||
+		utf8.RuneCountInString(translation) > MaxGroupWordLength {
+		return nil, ErrInvalidGroupWord
+	}
+
+	w := &domain.GroupWord{ChatID: chatID, AddedBy: userID, Word: word, Translation: translation}
+	added, err := s.groupRepo.AddWord(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, ErrGroupWordExists
+	}
+	return w, nil
+}
+
+// NewQuiz asks for the translation of a random group word.
+// Wrong options are other words' translations, each one different.
+func (s *GroupService) NewQuiz(ctx context.Context, chatID int64) (*domain.GroupQuiz, error) {
+	// Extra words make up for ones with the same translation
+	words, err := s.groupRepo.GetRandomWords(ctx, chatID, 2*GroupQuizOptions)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, ErrNotEnoughGroupWords
+	}
+
+	options := []domain.GroupWord{words[0]}
+	seen := map[string]bool{fold(words[0].Translation): true}
+	for _, w := range words[1:] {
+		if len(options) == GroupQuizOptions {
+			break
+		}
+		if key := fold(w.Translation); !seen[key] {
+			seen[key] = true
+			options = append(options, w)
+		}
+	}
+	if len(options) < 2 {
+		return nil, ErrNotEnoughGroupWords
+	}
+
+	id, err := s.groupRepo.CreateQuiz(ctx, chatID, words[0].ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.shuffle(len(options), func(i, j int) { options[i], options[j] = options[j], options[i] })
+	return &domain.GroupQuiz{ID: id, ChatID: chatID, Word: words[0], Options: options}, nil
+}
+
+// Answer records the member's choice. Each member answers once, and only
+// the first correct answer scores, even if several arrive at the same time.
+func (s *GroupService) Answer(ctx context.Context, chatID int64, quizID int, userID int64, name string, optionID int) (QuizOutcome, *domain.GroupQuiz, error) {
+	quiz, err := s.groupRepo.GetQuiz(ctx, chatID, quizID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if quiz == nil {
+		return 0, nil, ErrQuizNotFound
+	}
+	if quiz.WinnerID != 0 {
+		return QuizTaken, quiz, nil
+	}
+
+	first, err := s.groupRepo.RecordAttempt(ctx, quizID, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !first {
+		return QuizRepeated, quiz, nil
+	}
+	if optionID != quiz.Word.ID {
+		return QuizWrong, quiz, nil
+	}
+
+	won, err := s.groupRepo.ClaimWin(ctx, chatID, quizID, userID, name)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !won {
+		return QuizTaken, quiz, nil
+	}
+	quiz.WinnerID = userID
+	return QuizWon, quiz, nil
+}
+
+// Top returns the group's leaderboard, best first
+func (s *GroupService) Top(ctx context.Context, chatID int64) ([]domain.GroupScore, error) {
+	return s.groupRepo.GetTopScores(ctx, chatID, GroupTopLimit)
+}
+
+// fold makes translations comparable regardless of case and ё/е
+func fold(s string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "ё", "е")
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGroupService(repo *testutil.MockGroupRepository) *GroupService {
+	s := NewGroupService(repo)
+	s.shuffle = func(int, func(i, j int)) {}
+	return s
+}
+
+func TestGroupService_AddWord(t *testing.T) {
+	mockRepo := new(testutil.MockGroupRepository)
+	mockRepo.On("AddWord", mock.Anything, &domain.GroupWord{ChatID: -100, AddedBy: 123, Word: "apple", Translation: "яблоко"}).
+		Return(true, nil).Once()
+	mockRepo.On("AddWord", mock.Anything, mock.Anything).Return(false, nil)
+	service := newTestGroupService(mockRepo)
+
+	word, err := service.AddWord(context.Background(), -100, 123, "apple - яблоко")
+	require.NoError(t, err)
+	assert.Equal(t, "apple", word.Word)
+
+	_, err = service.AddWord(context.Background(), -100, 123, "apple - яблоко")
+	assert.ErrorIs(t, err, ErrGroupWordExists)
+
+	_, err = service.AddWord(context.Background(), -100, 123, "apple")
+	assert.ErrorIs(t, err, ErrInvalidGroupWord)
+
+	_, err = service.AddWord(context.Background(), -100, 123, strings.Repeat("a", MaxGroupWordLength+1)+" - a")
+	assert.ErrorIs(t, err, ErrInvalidGroupWord)
+}
+
+func TestGroupService_NewQuiz(t *testing.T) {
+	words := []domain.GroupWord{
+		{ID: 1, Word: "apple", Translation: "яблоко"},
+		{ID: 2, Word: "pear", Translation: "груша"},
+		{ID: 3, Word: "Apple", Translation: "Яблоко"},
+		{ID: 4, Word: "cat", Translation: "кот"},
+		{ID: 5, Word: "dog", Translation: "собака"},
+		{ID: 6, Word: "sun", Translation: "солнце"},
+	}
+
+	mockRepo := new(testutil.MockGroupRepository)
+	mockRepo.On("GetRandomWords", mock.Anything, int64(-100), 2*GroupQuizOptions).Return(words, nil)
+	mockRepo.On("CreateQuiz", mock.Anything, int64(-100), 1).Return(9, nil)
+
+	quiz, err := newTestGroupService(mockRepo).NewQuiz(context.Background(), -100)
+
+	require.NoError(t, err)
+	assert.Equal(t, 9, quiz.ID)
+	assert.Equal(t, 1, quiz.Word.ID)
+
+	var ids []int
+	for _, o := range quiz.Options {
+		ids = append(ids, o.ID)
+	}
+	// The word with the same translation can't be a wrong option
+	assert.Equal(t, []int{1, 2, 4, 5}, ids)
+}
+
+func TestGroupService_NewQuiz_NotEnoughWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []domain.GroupWord
+	}{
+		{name: "no words"},
+		{name: "one word", words: []domain.GroupWord{{ID: 1, Translation: "яблоко"}}},
+		{name: "same translations", words: []domain.GroupWord{{ID: 1, Translation: "яблоко"}, {ID: 2, Translation: "яблоко"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(testutil.MockGroupRepository)
+			mockRepo.On("GetRandomWords", mock.Anything, int64(-100), mock.Anything).Return(tt.words, nil)
+
+			_, err := newTestGroupService(mockRepo).NewQuiz(context.Background(), -100)
+
+			assert.ErrorIs(t, err, ErrNotEnoughGroupWords)
+			mockRepo.AssertNotCalled(t, "CreateQuiz", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGroupService_Answer(t *testing.T) {
+	open := &domain.GroupQuiz{ID: 3, ChatID: -100, Word: domain.GroupWord{ID: 7}}
+
+	tests := []struct {
+		name     string
+		quiz     *domain.GroupQuiz
+		first    bool
+		optionID int
+		claimed  bool
+		expected QuizOutcome
+	}{
+		{name: "first correct answer", quiz: open, first: true, optionID: 7, claimed: true, expected: QuizWon},
+		{name: "correct but someone was faster", quiz: open, first: true, optionID: 7, claimed: false, expected: QuizTaken},
+		{name: "wrong answer", quiz: open, first: true, optionID: 8, expected: QuizWrong},
+		{name: "second answer", quiz: open, first: false, optionID: 7, expected: QuizRepeated},
+		{name: "solved quiz", quiz: &domain.GroupQuiz{ID: 3, ChatID: -100, WinnerID: 456}, optionID: 7, expected: QuizTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := *tt.quiz
+			mockRepo := new(testutil.MockGroupRepository)
+			mockRepo.On("GetQuiz", mock.Anything, int64(-100), 3).Return(&quiz, nil)
+			mockRepo.On("RecordAttempt", mock.Anything, 3, int64(123)).Return(tt.first, nil)
+			mockRepo.On("ClaimWin", mock.Anything, int64(-100), 3, int64(123), "Alice").Return(tt.claimed, nil)
+
+			outcome, _, err := newTestGroupService(mockRepo).Answer(context.Background(), -100, 3, 123, "Alice", tt.optionID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, outcome)
+			if tt.expected != QuizWon && tt.expected != QuizTaken {
+				mockRepo.AssertNotCalled(t, "ClaimWin", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestGroupService_Answer_NotFound(t *testing.T) {
+	mockRepo := new(testutil.MockGroupRepository)
+	mockRepo.On("GetQuiz", mock.Anything, int64(-100), 3).Return(nil, nil)
+
+	_, _, err := newTestGroupService(mockRepo).Answer(context.Background(), -100, 3, 123, "Alice", 7)
+
+	assert.ErrorIs(t, err, ErrQuizNotFound)
+}
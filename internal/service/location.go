@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"languager/internal/domain"
+)
+
+// LocationResolver returns the time zone a user's days are counted in
+type LocationResolver interface {
+	UserLocation(ctx context.Context, userID int64) (*time.Location, error)
+}
+
+// FixedLocation resolves every user to the same time zone
+type FixedLocation struct {
+	Location *time.Location
+}
+
+// UserLocation implements LocationResolver
+func (f FixedLocation) UserLocation(context.Context, int64) (*time.Location, error) {
+	return f.Location, nil
+}
+
+// PairResolver returns the language pair a user is studying now
+type PairResolver interface {
+	UserPair(ctx context.Context, userID int64) (domain.LanguagePair, error)
+}
+
+// FixedPair resolves every user to the same language pair
+type FixedPair struct {
+	Pair domain.LanguagePair
+}
+
+// UserPair implements PairResolver
+func (f FixedPair) UserPair(context.Context, int64) (domain.LanguagePair, error) {
+	return f.Pair, nil
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/metrics"
+	"languager/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// reminderCatchUp is how long a missed slot is still delivered, e.g. after a restart
+	reminderCatchUp = 15 * time.Minute
+	// deliveryRetention is how long delivery records are kept
+	deliveryRetention = 7 * 24 * time.Hour
+	// MaxReminderTimes limits reminder times per user
+	MaxReminderTimes = 5
+)
+
+var (
+	// ErrInvalidReminderTime is returned for times not in HH:MM format
+	ErrInvalidReminderTime = errors.New("invalid reminder time")
+	// ErrTooManyReminderTimes is returned when MaxReminderTimes is reached
+	ErrTooManyReminderTimes = errors.New("too many reminder times")
+	// ErrRecipientUnavailable is returned by a ReminderSender or ReportSender
+	// when the user can't receive messages anymore, e.g. blocked the bot
+	ErrRecipientUnavailable = errors.New("recipient unavailable")
+)
+
+// ReminderSender delivers reminders to users
+type ReminderSender interface {
+	SendReminder(ctx context.Context, userID int64, dueWords int) error
+}
+
+// ReminderService manages reminder schedules and sends due reminders
+type ReminderService struct {
+	reminderRepo repository.ReminderRepository
+	wordRepo     repository.WordRepository
+	locations    LocationResolver
+	pairs        PairResolver
+	logger       *zap.Logger
+}
+
+// NewReminderService creates a new reminder service.
+// Reminder times are interpreted in the user's time zone,
+// and due words are counted in the user's active pair.
+func NewReminderService(
+	reminderRepo repository.ReminderRepository,
+	wordRepo repository.WordRepository,
+	locations LocationResolver,
+	pairs PairResolver,
+	logger *zap.Logger,
+) *ReminderService {
+	return &ReminderService{
+		reminderRepo: reminderRepo,
+		wordRepo:     wordRepo,
+		locations:    locations,
+		pairs:        pairs,
+		logger:       logger,
+	}
+}
+
+// UserLocation returns the time zone user's reminder times are interpreted in
+func (s *ReminderService) UserLocation(ctx context.Context, userID int64) (*time.Location, error) {
+	return s.locations.UserLocation(ctx, userID)
+}
+
+// GetSettings returns user's reminder schedule, empty if not configured yet
+func (s *ReminderService) GetSettings(ctx context.Context, userID int64) (*domain.ReminderSettings, error) {
+	settings, err := s.reminderRepo.GetReminderSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = domain.NewReminderSettings(userID)
+	}
+	return settings, nil
+}
+
+// AddTime adds a HH:MM reminder time
+func (s *ReminderService) AddTime(ctx context.Context, userID int64, clock string) (*domain.ReminderSettings, error) {
+	minutes, err := domain.ParseClock(clock)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReminderTime, err)
+	}
+
+	return s.update(ctx, userID, func(settings *domain.ReminderSettings) error {
+		if len(settings.Times) >= MaxReminderTimes {
+			return ErrTooManyReminderTimes
+		}
+		settings.AddTime(minutes)
+		return nil
+	})
+}
+
+// RemoveTime removes a reminder time given in minutes after midnight
+func (s *ReminderService) RemoveTime(ctx context.Context, userID int64, minutes int) (*domain.ReminderSettings, error) {
+	return s.update(ctx, userID, func(settings *domain.ReminderSettings) error {
+		settings.RemoveTime(minutes)
+		return nil
+	})
+}
+
+// ToggleDay enables or disables reminders on a weekday
+func (s *ReminderService) ToggleDay(ctx context.Context, userID int64, day time.Weekday) (*domain.ReminderSettings, error) {
+	return s.update(ctx, userID, func(settings *domain.ReminderSettings) error {
+		settings.ToggleDay(day)
+		return nil
+	})
+}
+
+// TogglePause pauses or resumes reminders
+func (s *ReminderService) TogglePause(ctx context.Context, userID int64) (*domain.ReminderSettings, error) {
+	return s.update(ctx, userID, func(settings *domain.ReminderSettings) error {
+		settings.Paused = !settings.Paused
+		return nil
+	})
+}
+
+func (s *ReminderService) update(ctx context.Context, userID int64, change func(*domain.ReminderSettings) error) (*domain.ReminderSettings, error) {
+	settings, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(settings); err != nil {
+		return nil, err
+	}
+	if err := s.reminderRepo.SaveReminderSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SendDue sends reminders whose slots are due at now and returns how many were sent.
+// Every slot is claimed in the database before sending, so restarts and
+// concurrent runs never deliver the same reminder twice.
+func (s *ReminderService) SendDue(ctx context.Context, now time.Time, sender ReminderSender) (int, error) {
+	schedules, err := s.reminderRepo.ListActiveReminders(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range schedules {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		loc, err := s.locations.UserLocation(ctx, schedules[i].UserID)
+		if err != nil {
+			s.logger.Error("Failed to resolve user time zone",
+				zap.Int64("user_id", schedules[i].UserID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		slots := schedules[i].DueSlots(now, loc, reminderCatchUp)
+		if len(slots) == 0 {
+			continue
+		}
+
+		// After downtime several slots may be due; one reminder is enough
+		ok, err := s.deliver(ctx, &schedules[i], slots[len(slots)-1], sender)
+		if err != nil {
+			s.logger.Error("Failed to send reminder",
+				zap.Int64("user_id", schedules[i].UserID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// deliver claims the slot and sends the reminder, releasing the claim on failure
+func (s *ReminderService) deliver(ctx context.Context, settings *domain.ReminderSettings, slot time.Time, sender ReminderSender) (bool, error) {
+	claimed, err := s.reminderRepo.ClaimDelivery(ctx, settings.UserID, slot)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	// Reviews started from the reminder show words of the active pair only
+	pair, err := s.pairs.UserPair(ctx, settings.UserID)
+	if err != nil {
+		s.release(ctx, settings.UserID, slot)
+		return false, err
+	}
+	dueWords, err := s.wordRepo.CountDueWords(ctx, settings.UserID, pair)
+	if err != nil {
+		s.release(ctx, settings.UserID, slot)
+		return false, err
+	}
+	if dueWords == 0 {
+		// Nothing to review; keep the claim so the slot isn't retried
+		metrics.RemindersSent.WithLabelValues("nothing_due").Inc()
+		return false, nil
+	}
+
+	err = sender.SendReminder(ctx, settings.UserID, dueWords)
+	if errors.Is(err, ErrRecipientUnavailable) {
+		// Retrying won't help, pause until the user comes back
+		metrics.RemindersSent.WithLabelValues("unavailable").Inc()
+		settings.Paused = true
+		return false, s.reminderRepo.SaveReminderSettings(ctx, settings)
+	}
+	if err != nil {
+		metrics.RemindersSent.WithLabelValues("failed").Inc()
+		s.release(ctx, settings.UserID, slot)
+		return false, err
+	}
+
+	metrics.RemindersSent.WithLabelValues("sent").Inc()
+	return true, nil
+}
+
+func (s *ReminderService) release(ctx context.Context, userID int64, slot time.Time) {
+	if err := s.reminderRepo.ReleaseDelivery(ctx, userID, slot); err != nil {
+		s.logger.Warn("Failed to release reminder claim",
+			zap.Int64("user_id", userID),
+			zap.Time("slot", slot),
+			zap.Error(err),
+		)
+	}
+}
+
+// PruneDeliveries removes old delivery records
+func (s *ReminderService) PruneDeliveries(ctx context.Context) error {
+	return s.reminderRepo.CleanOldDeliveries(ctx, time.Now().Add(-deliveryRetention))
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeSender records sent reminders
+type fakeSender struct {
+	sent map[int64]int
+	err  error
+}
+
+func (f *fakeSender) SendReminder(ctx context.Context, userID int64, dueWords int) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.sent == nil {
+		f.sent = make(map[int64]int)
+	}
+	f.sent[userID] = dueWords
+	return nil
+}
+
+func newTestReminderService(t *testing.T) (*ReminderService, *testutil.MockReminderRepository, *testutil.MockWordRepository) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	reminderRepo := new(testutil.MockReminderRepository)
+	wordRepo := new(testutil.MockWordRepository)
+	return NewReminderService(reminderRepo, wordRepo, FixedLocation{moscow}, FixedPair{domain.DefaultLanguagePair}, zap.NewNop()), reminderRepo, wordRepo
+}
+
+func TestReminderService_AddTime(t *testing.T) {
+	tests := []struct {
+		name        string
+		clock       string
+		existing    *domain.ReminderSettings
+		expectSave  bool
+		expectedErr error
+	}{
+		{
+			name:       "first time",
+			clock:      "09:00",
+			existing:   nil,
+			expectSave: true,
+		},
+		{
+			name:        "invalid format",
+			clock:       "9 am",
+			expectedErr: ErrInvalidReminderTime,
+		},
+		{
+			name:        "limit reached",
+			clock:       "21:00",
+			existing:    &domain.ReminderSettings{UserID: 123, Times: []int{1, 2, 3, 4, 5}, Days: domain.AllWeekdays},
+			expectedErr: ErrTooManyReminderTimes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, reminderRepo, _ := newTestReminderService(t)
+			reminderRepo.On("GetReminderSettings", mock.Anything, int64(123)).Return(tt.existing, nil).Maybe()
+			if tt.expectSave {
+				reminderRepo.On("SaveReminderSettings", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			settings, err := service.AddTime(context.Background(), 123, tt.clock)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				reminderRepo.AssertNotCalled(t, "SaveReminderSettings", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []int{9 * 60}, settings.Times)
+			assert.Equal(t, domain.AllWeekdays, settings.Days)
+			reminderRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReminderService_SendDue(t *testing.T) {
+	service, reminderRepo, wordRepo := newTestReminderService(t)
+	moscow, _ := service.UserLocation(context.Background(), 123)
+
+	now := time.Date(2024, 12, 16, 9, 2, 0, 0, moscow)
+	slot := time.Date(2024, 12, 16, 9, 0, 0, 0, moscow)
+
+	reminderRepo.On("ListActiveReminders", mock.Anything).Return([]domain.ReminderSettings{
+		{UserID: 1, Times: []int{9 * 60}, Days: domain.AllWeekdays},  // due
+		{UserID: 2, Times: []int{9 * 60}, Days: domain.AllWeekdays},  // already sent before restart
+		{UserID: 3, Times: []int{20 * 60}, Days: domain.AllWeekdays}, // not due yet
+		{UserID: 4, Times: []int{9 * 60}, Days: domain.AllWeekdays},  // nothing to review
+	}, nil)
+	reminderRepo.On("ClaimDelivery", mock.Anything, int64(1), slot).Return(true, nil)
+	reminderRepo.On("ClaimDelivery", mock.Anything, int64(2), slot).Return(false, nil)
+	reminderRepo.On("ClaimDelivery", mock.Anything, int64(4), slot).Return(true, nil)
+	wordRepo.On("CountDueWords", mock.Anything, int64(1), domain.DefaultLanguagePair).Return(12, nil)
+	wordRepo.On("CountDueWords", mock.Anything, int64(4), domain.DefaultLanguagePair).Return(0, nil)
+
+	sender := &fakeSender{}
+	sent, err := service.SendDue(context.Background(), now, sender)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, map[int64]int{1: 12}, sender.sent)
+	reminderRepo.AssertExpectations(t)
+	wordRepo.AssertExpectations(t)
+}
+
+func TestReminderService_SendDue_OnlyLatestMissedSlot(t *testing.T) {
+	service, reminderRepo, wordRepo := newTestReminderService(t)
+	moscow, _ := service.UserLocation(context.Background(), 123)
+
+	now := time.Date(2024, 12, 16, 9, 12, 0, 0, moscow)
+	latest := time.Date(2024, 12, 16, 9, 10, 0, 0, moscow)
+
+	reminderRepo.On("ListActiveReminders", mock.Anything).Return([]domain.ReminderSettings{
+		{UserID: 1, Times: []int{9 * 60, 9*60 + 10}, Days: domain.AllWeekdays},
+	}, nil)
+	reminderRepo.On("ClaimDelivery", mock.Anything, int64(1), latest).Return(true, nil).Once()
+	wordRepo.On("CountDueWords", mock.Anything, int64(1), domain.DefaultLanguagePair).Return(3, nil)
+
+	sent, err := service.SendDue(context.Background(), now, &fakeSender{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	reminderRepo.AssertExpectations(t)
+}
+
+func TestReminderService_SendDue_SendFailureReleasesClaim(t *testing.T) {
+	service, reminderRepo, wordRepo := newTestReminderService(t)
+	moscow, _ := service.UserLocation(context.Background(), 123)
+
+	now := time.Date(2024, 12, 16, 9, 0, 0, 0, moscow)
+
+	reminderRepo.On("ListActiveReminders", mock.Anything).Return([]domain.ReminderSettings{
+		{UserID: 1, Times: []int{9 * 60}, Days: domain.AllWeekdays},
+	}, nil)
+	reminderRepo.On("ClaimDelivery", mock.Anything, int64(1), now).Return(true, nil)
+	reminderRepo.On("ReleaseDelivery", mock.Anything, int64(1), now).Return(nil)
+	wordRepo.On("CountDueWords", mock.Anything, int64(1), domain.DefaultLanguagePair).Return(3, nil)
+
+	sent, err := service.SendDue(context.Background(), now, &fakeSender{err: fmt.Errorf("network error")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	reminderRepo.AssertExpectations(t)
+}
+
+func TestReminderService_SendDue_UnavailablePauses(t *testing.T) {
+	service, reminderRepo, wordRepo := newTestReminderService(t)
+	moscow, _ := service.UserLocation(context.Background(), 123)
+
+	now := time.Date(2024, 12, 16, 9, 0, 0, 0, moscow)
+
+	reminderRepo.On("ListActiveReminders", mock.Anything).Return([]domain.ReminderSettings{
+		{UserID: 1, Times: []int{9 * 60}, Days: domain.AllWeekdays},
+	}, nil)
+	reminderRepo.On("ClaimDelivery", mock.Anything, int64(1), now).Return(true, nil)
+	reminderRepo.On("SaveReminderSettings", mock.Anything, mock.MatchedBy(func(s *domain.ReminderSettings) bool {
+		return s.UserID == 1 && s.Paused
+	})).Return(nil)
+	wordRepo.On("CountDueWords", mock.Anything, int64(1), domain.DefaultLanguagePair).Return(3, nil)
+
+	sender := &fakeSender{err: fmt.Errorf("send: %w", ErrRecipientUnavailable)}
+	sent, err := service.SendDue(context.Background(), now, sender)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	reminderRepo.AssertExpectations(t)
+	reminderRepo.AssertNotCalled(t, "ReleaseDelivery", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReminderService_SendDue_ListError(t *testing.T) {
+	service, reminderRepo, _ := newTestReminderService(t)
+	reminderRepo.On("ListActiveReminders", mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+	_, err := service.SendDue(context.Background(), time.Now(), &fakeSender{})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/metrics"
+	"languager/internal/repository"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// weeklyReportHour is the local hour on Monday after which reports are sent
+	weeklyReportHour = 10
+	// failingWordsLimit is how many failing words a report lists
+	failingWordsLimit = 5
+	// reportRetention is how long report delivery records are kept
+	reportRetention = 12 * 7 * 24 * time.Hour
+)
+
+// ReportSender delivers weekly reports to users
+type ReportSender interface {
+	SendWeeklyReport(ctx context.Context, userID int64, report *domain.WeeklyReport) error
+}
+
+// ReportService builds weekly learning reports and sends them to subscribers
+type ReportService struct {
+	reportRepo repository.ReportRepository
+	streakRepo repository.StreakRepository
+	locations  LocationResolver
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewReportService creates a new weekly report service.
+// Weeks start on Monday in the user's time zone.
+func NewReportService(
+	reportRepo repository.ReportRepository,
+	streakRepo repository.StreakRepository,
+	locations LocationResolver,
+	logger *zap.Logger,
+) *ReportService {
+	return &ReportService{
+		reportRepo: reportRepo,
+		streakRepo: streakRepo,
+		locations:  locations,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// IsSubscribed reports whether the user receives weekly reports
+func (s *ReportService) IsSubscribed(ctx context.Context, userID int64) (bool, error) {
+	return s.reportRepo.IsSubscribed(ctx, userID)
+}
+
+// ToggleSubscription opts the user in or out and returns the new state
+func (s *ReportService) ToggleSubscription(ctx context.Context, userID int64) (bool, error) {
+	subscribed, err := s.reportRepo.IsSubscribed(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if err := s.reportRepo.SetSubscribed(ctx, userID, !subscribed); err != nil {
+		return false, err
+	}
+	return !subscribed, nil
+}
+
+// LastWeekReport returns the report for the previous full week
+func (s *ReportService) LastWeekReport(ctx context.Context, userID int64) (*domain.WeeklyReport, error) {
+	loc, err := s.locations.UserLocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildReport(ctx, userID, lastWeekStart(s.now(), loc), loc)
+}
+
+// BuildReport returns the report for the week starting on the civil Monday weekStart
+func (s *ReportService) BuildReport(ctx context.Context, userID int64, weekStart time.Time) (*domain.WeeklyReport, error) {
+	loc, err := s.locations.UserLocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildReport(ctx, userID, weekStart, loc)
+}
+
+func (s *ReportService) buildReport(ctx context.Context, userID int64, weekStart time.Time, loc *time.Location) (*domain.WeeklyReport, error) {
+	from := time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 7)
+
+	report := &domain.WeeklyReport{WeekStart: weekStart}
+
+	var err error
+	report.WordsAdded, report.Reviews, report.CorrectReviews, err = s.reportRepo.GetPeriodActivity(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Mastered, err = s.reportRepo.GetMasteredWords(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Failing, err = s.reportRepo.GetFailingWords(ctx, userID, from, to, failingWordsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	streak, err := s.streakRepo.GetStreak(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if streak != nil {
+		report.Streak = streak.CurrentAt(domain.CivilDay(s.now(), loc))
+		report.BestStreak = streak.Best
+	}
+
+	return report, nil
+}
+
+// SendDue sends last week's reports to users for whom it's Monday
+// and returns how many were sent.
+// Every report is claimed in the database before sending, so restarts and
+// concurrent runs never deliver the same report twice.
+func (s *ReportService) SendDue(ctx context.Context, now time.Time, sender ReportSender) (int, error) {
+	users, err := s.reportRepo.ListSubscribers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, userID := range users {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		ok, err := s.deliver(ctx, userID, now, sender)
+		if err != nil {
+			s.logger.Error("Failed to send weekly report",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// deliver claims the week and sends the report if it's Monday for the user,
+// releasing the claim on failure
+func (s *ReportService) deliver(ctx context.Context, userID int64, now time.Time, sender ReportSender) (bool, error) {
+	loc, err := s.locations.UserLocation(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	local := now.In(loc)
+	if local.Weekday() != time.Monday || local.Hour() < weeklyReportHour {
+		return false, nil
+	}
+
+	weekStart := lastWeekStart(now, loc)
+	claimed, err := s.reportRepo.ClaimReport(ctx, userID, weekStart)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	report, err := s.buildReport(ctx, userID, weekStart, loc)
+	if err != nil {
+		s.release(ctx, userID, weekStart)
+		return false, err
+	}
+	if report.Empty() {
+		// Nothing to report; keep the claim so the week isn't retried
+		metrics.WeeklyReportsSent.WithLabelValues("empty").Inc()
+		return false, nil
+	}
+
+	err = sender.SendWeeklyReport(ctx, userID, report)
+	if errors.Is(err, ErrRecipientUnavailable) {
+		// Retrying won't help, unsubscribe until the user opts in again
+		metrics.WeeklyReportsSent.WithLabelValues("unavailable").Inc()
+		return false, s.reportRepo.SetSubscribed(ctx, userID, false)
+	}
+	if err != nil {
+		metrics.WeeklyReportsSent.WithLabelValues("failed").Inc()
+		s.release(ctx, userID, weekStart)
+		return false, err
+	}
+
+	metrics.WeeklyReportsSent.WithLabelValues("sent").Inc()
+	return true, nil
+}
+
+func (s *ReportService) release(ctx context.Context, userID int64, weekStart time.Time) {
+	if err := s.reportRepo.ReleaseReport(ctx, userID, weekStart); err != nil {
+		s.logger.Warn("Failed to release weekly report claim",
+			zap.Int64("user_id", userID),
+			zap.Time("week_start", weekStart),
+			zap.Error(err),
+		)
+	}
+}
+
+// lastWeekStart returns the Monday of the week before now in loc
+func lastWeekStart(now time.Time, loc *time.Location) time.Time {
+	return domain.WeekStart(domain.CivilDay(now, loc)).AddDate(0, 0, -7)
+}
+
+// PruneDeliveries removes old report delivery records
+func (s *ReportService) PruneDeliveries(ctx context.Context) error {
+	// A day more or less doesn't matter this far back
+	before := domain.CivilDay(s.now().Add(-reportRetention), time.UTC)
+	return s.reportRepo.CleanOldReports(ctx, before)
+}
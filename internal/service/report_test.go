@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeReportSender records sent reports
+type fakeReportSender struct {
+	sent map[int64]*domain.WeeklyReport
+	err  error
+}
+
+func (f *fakeReportSender) SendWeeklyReport(ctx context.Context, userID int64, report *domain.WeeklyReport) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.sent == nil {
+		f.sent = make(map[int64]*domain.WeeklyReport)
+	}
+	f.sent[userID] = report
+	return nil
+}
+
+func newTestReportService(t *testing.T) (*ReportService, *testutil.MockReportRepository, *testutil.MockStreakRepository, *time.Location) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	reportRepo := new(testutil.MockReportRepository)
+	streakRepo := new(testutil.MockStreakRepository)
+	return NewReportService(reportRepo, streakRepo, FixedLocation{moscow}, zap.NewNop()), reportRepo, streakRepo, moscow
+}
+
+func TestReportService_BuildReport(t *testing.T) {
+	service, reportRepo, streakRepo, moscow := newTestReportService(t)
+
+	// Monday 2024-12-16 in Moscow, reporting the week of December 9
+	service.now = func() time.Time { return time.Date(2024, 12, 16, 11, 0, 0, 0, moscow) }
+	weekStart := time.Date(2024, 12, 9, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2024, 12, 9, 0, 0, 0, 0, moscow)
+	to := time.Date(2024, 12, 16, 0, 0, 0, 0, moscow)
+	lastGoal := time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC)
+
+	reportRepo.On("GetPeriodActivity", mock.Anything, int64(123), from, to).Return(10, 20, 15, nil)
+	reportRepo.On("GetMasteredWords", mock.Anything, int64(123), from, to).Return([]domain.Word{{Word: "hello"}}, nil)
+	reportRepo.On("GetFailingWords", mock.Anything, int64(123), from, to, failingWordsLimit).
+		Return([]domain.HardWord{{Word: "through", Failures: 2, Reviews: 3}}, nil)
+	streakRepo.On("GetStreak", mock.Anything, int64(123)).
+		Return(&domain.Streak{UserID: 123, Current: 4, Best: 9, LastGoalDay: &lastGoal}, nil)
+
+	report, err := service.BuildReport(context.Background(), 123, weekStart)
+
+	require.NoError(t, err)
+	assert.Equal(t, weekStart, report.WeekStart)
+	assert.Equal(t, 10, report.WordsAdded)
+	assert.Equal(t, 75, report.Accuracy())
+	assert.Len(t, report.Mastered, 1)
+	assert.Len(t, report.Failing, 1)
+	assert.Equal(t, 4, report.Streak)
+	assert.Equal(t, 9, report.BestStreak)
+	reportRepo.AssertExpectations(t)
+}
+
+func TestReportService_SendDue(t *testing.T) {
+	tests := []struct {
+		name          string
+		now           time.Time
+		claimed       bool
+		reviews       int
+		senderErr     error
+		expectSent    int
+		expectRelease bool
+		expectUnsub   bool
+	}{
+		{name: "monday after report hour", now: time.Date(2024, 12, 16, 10, 5, 0, 0, time.UTC), claimed: true, reviews: 5, expectSent: 1},
+		{name: "already sent", now: time.Date(2024, 12, 16, 10, 5, 0, 0, time.UTC), claimed: false},
+		{name: "empty week", now: time.Date(2024, 12, 16, 10, 5, 0, 0, time.UTC), claimed: true},
+		{name: "send failed", now: time.Date(2024, 12, 16, 10, 5, 0, 0, time.UTC), claimed: true, reviews: 5, senderErr: fmt.Errorf("network"), expectRelease: true},
+		{name: "user blocked the bot", now: time.Date(2024, 12, 16, 10, 5, 0, 0, time.UTC), claimed: true, reviews: 5, senderErr: ErrRecipientUnavailable, expectUnsub: true},
+	}
+
+	weekStart := time.Date(2024, 12, 9, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, reportRepo, streakRepo, _ := newTestReportService(t)
+			service.now = func() time.Time { return tt.now }
+
+			reportRepo.On("ListSubscribers", mock.Anything).Return([]int64{123}, nil)
+			reportRepo.On("ClaimReport", mock.Anything, int64(123), weekStart).Return(tt.claimed, nil)
+			reportRepo.On("GetPeriodActivity", mock.Anything, int64(123), mock.Anything, mock.Anything).Return(0, tt.reviews, tt.reviews, nil)
+			reportRepo.On("GetMasteredWords", mock.Anything, int64(123), mock.Anything, mock.Anything).Return([]domain.Word{}, nil)
+			reportRepo.On("GetFailingWords", mock.Anything, int64(123), mock.Anything, mock.Anything, failingWordsLimit).Return([]domain.HardWord{}, nil)
+			streakRepo.On("GetStreak", mock.Anything, int64(123)).Return(nil, nil)
+			if tt.expectRelease {
+				reportRepo.On("ReleaseReport", mock.Anything, int64(123), weekStart).Return(nil)
+			}
+			if tt.expectUnsub {
+				reportRepo.On("SetSubscribed", mock.Anything, int64(123), false).Return(nil)
+			}
+
+			sender := &fakeReportSender{err: tt.senderErr}
+			sent, err := service.SendDue(context.Background(), tt.now, sender)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectSent, sent)
+			assert.Len(t, sender.sent, tt.expectSent)
+			if tt.expectRelease {
+				reportRepo.AssertCalled(t, "ReleaseReport", mock.Anything, int64(123), weekStart)
+			}
+			if tt.expectUnsub {
+				reportRepo.AssertCalled(t, "SetSubscribed", mock.Anything, int64(123), false)
+			}
+		})
+	}
+}
+
+func TestReportService_SendDue_NotMonday(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+	}{
+		{name: "monday before report hour", now: time.Date(2024, 12, 16, 6, 0, 0, 0, time.UTC)},
+		{name: "tuesday", now: time.Date(2024, 12, 17, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, reportRepo, _, _ := newTestReportService(t)
+			reportRepo.On("ListSubscribers", mock.Anything).Return([]int64{123}, nil)
+
+			sent, err := service.SendDue(context.Background(), tt.now, &fakeReportSender{})
+
+			assert.NoError(t, err)
+			assert.Equal(t, 0, sent)
+			reportRepo.AssertNotCalled(t, "ClaimReport", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReportService_ToggleSubscription(t *testing.T) {
+	service, reportRepo, _, _ := newTestReportService(t)
+
+	reportRepo.On("IsSubscribed", mock.Anything, int64(123)).Return(false, nil)
+	reportRepo.On("SetSubscribed", mock.Anything, int64(123), true).Return(nil)
+
+	subscribed, err := service.ToggleSubscription(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.True(t, subscribed)
+	reportRepo.AssertExpectations(t)
+}
+
+// userLocations resolves each user to their own time zone
+type userLocations map[int64]*time.Location
+
+func (u userLocations) UserLocation(_ context.Context, userID int64) (*time.Location, error) {
+	return u[userID], nil
+}
+
+func TestReportService_SendDue_UserTimeZone(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	reportRepo := new(testutil.MockReportRepository)
+	streakRepo := new(testutil.MockStreakRepository)
+	service := NewReportService(reportRepo, streakRepo, userLocations{1: moscow, 2: tokyo}, zap.NewNop())
+
+	// 11:00 in Tokyo, but only 05:00 in Moscow
+	now := time.Date(2024, 12, 16, 2, 0, 0, 0, time.UTC)
+	weekStart := time.Date(2024, 12, 9, 0, 0, 0, 0, time.UTC)
+
+	reportRepo.On("ListSubscribers", mock.Anything).Return([]int64{1, 2}, nil)
+	reportRepo.On("ClaimReport", mock.Anything, int64(2), weekStart).Return(true, nil)
+	reportRepo.On("GetPeriodActivity", mock.Anything, int64(2), time.Date(2024, 12, 9, 0, 0, 0, 0, tokyo), time.Date(2024, 12, 16, 0, 0, 0, 0, tokyo)).
+		Return(1, 0, 0, nil)
+	reportRepo.On("GetMasteredWords", mock.Anything, int64(2), mock.Anything, mock.Anything).Return([]domain.Word{}, nil)
+	reportRepo.On("GetFailingWords", mock.Anything, int64(2), mock.Anything, mock.Anything, failingWordsLimit).Return([]domain.HardWord{}, nil)
+	streakRepo.On("GetStreak", mock.Anything, int64(2)).Return(nil, nil)
+
+	sender := &fakeReportSender{}
+	sent, err := service.SendDue(context.Background(), now, sender)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Contains(t, sender.sent, int64(2))
+	reportRepo.AssertNotCalled(t, "ClaimReport", mock.Anything, int64(1), mock.Anything)
+	reportRepo.AssertExpectations(t)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"languager/internal/cache"
+	"languager/internal/domain"
+	"languager/internal/repository"
+)
+
+const (
+	// SearchLimit is how many words a search returns
+	SearchLimit = 20
+	// MaxSearchQuery limits query length; longer queries are cut
+	MaxSearchQuery = 64
+	// SearchCacheTTL is how long search results are reused.
+	// Words saved meanwhile show up after it expires.
+	SearchCacheTTL = 30 * time.Second
+	// searchCacheSize is how many recent queries are cached
+	searchCacheSize = 1000
+)
+
+// searchKey identifies cached results of a user's query
+type searchKey struct {
+	userID int64
+	query  string
+}
+
+// SearchService searches user's saved words, e.g. for inline queries.
+// Inline queries arrive on every keystroke, so results are cached.
+type SearchService struct {
+	wordRepo repository.WordRepository
+	results  *cache.LRU[searchKey, []domain.Word]
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(wordRepo repository.WordRepository) *SearchService {
+	return &SearchService{
+		wordRepo: wordRepo,
+		results:  cache.NewLRU[searchKey, []domain.Word](searchCacheSize, SearchCacheTTL),
+	}
+}
+
+// Search returns user's words or translations containing query, prefix matches first.
+// An empty query returns the newest words.
+func (s *SearchService) Search(ctx context.Context, userID int64, query string) ([]domain.Word, error) {
+	key := searchKey{userID: userID, query: normalizeQuery(query)}
+	if words, ok := s.results.Get(key); ok {
+		return words, nil
+	}
+
+	words, err := s.wordRepo.SearchWords(ctx, userID, key.query, SearchLimit)
+	if err != nil {
+		return nil, err
+	}
+	s.results.Set(key, words)
+	return words, nil
+}
+
+// normalizeQuery lowercases the query, collapses spaces and cuts it to MaxSearchQuery runes
+func normalizeQuery(query string) string {
+	query = strings.ToLower(strings.Join(strings.Fields(query), " "))
+	if r := []rune(query); len(r) > MaxSearchQuery {
+		query = string(r[:MaxSearchQuery])
+	}
+	return query
+}
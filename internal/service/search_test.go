@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	mockRepo := new(testutil.MockWordRepository)
+	words := []domain.Word{{ID: 1, Word: "apple", Translation: "яблоко"}}
+	mockRepo.On("SearchWords", mock.Anything, int64(123), "app", SearchLimit).Return(words, nil).Once()
+
+	service := NewSearchService(mockRepo)
+
+	result, err := service.Search(context.Background(), 123, "App")
+	assert.NoError(t, err)
+	assert.Equal(t, words, result)
+
+	// Same normalized query is served from the cache
+	result, err = service.Search(context.Background(), 123, "  app ")
+	assert.NoError(t, err)
+	assert.Equal(t, words, result)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchService_Search_PerUser(t *testing.T) {
+	mockRepo := new(testutil.MockWordRepository)
+	mockRepo.On("SearchWords", mock.Anything, int64(1), "app", SearchLimit).Return([]domain.Word{{ID: 1}}, nil).Once()
+	mockRepo.On("SearchWords", mock.Anything, int64(2), "app", SearchLimit).Return([]domain.Word{}, nil).Once()
+
+	service := NewSearchService(mockRepo)
+
+	first, _ := service.Search(context.Background(), 1, "app")
+	second, _ := service.Search(context.Background(), 2, "app")
+
+	assert.Len(t, first, 1)
+	assert.Empty(t, second)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchService_Search_ErrorNotCached(t *testing.T) {
+	mockRepo := new(testutil.MockWordRepository)
+	mockRepo.On("SearchWords", mock.Anything, int64(123), "app", SearchLimit).Return(nil, fmt.Errorf("database error")).Once()
+	mockRepo.On("SearchWords", mock.Anything, int64(123), "app", SearchLimit).Return([]domain.Word{{ID: 1}}, nil).Once()
+
+	service := NewSearchService(mockRepo)
+
+	_, err := service.Search(context.Background(), 123, "app")
+	assert.Error(t, err)
+
+	result, err := service.Search(context.Background(), 123, "app")
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	assert.Equal(t, "give up", normalizeQuery("  Give   UP "))
+	assert.Equal(t, "", normalizeQuery(" "))
+	assert.Equal(t, MaxSearchQuery, len([]rune(normalizeQuery(strings.Repeat("я", 100)))))
+}
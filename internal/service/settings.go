@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/i18n"
+	"languager/internal/repository"
+)
+
+// SettingsService manages user preferences stored in user_settings
+type SettingsService struct {
+	settingsRepo repository.SettingsRepository
+	defaults     domain.Settings
+}
+
+// NewSettingsService creates a new settings service.
+// defaults apply to every setting the user hasn't changed.
+func NewSettingsService(settingsRepo repository.SettingsRepository, defaults domain.Settings) *SettingsService {
+	return &SettingsService{
+		settingsRepo: settingsRepo,
+		defaults:     defaults,
+	}
+}
+
+// Get returns user's settings with defaults filled in
+func (s *SettingsService) Get(ctx context.Context, userID int64) (domain.Settings, error) {
+	stored, err := s.settingsRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return domain.Settings{}, err
+	}
+
+	settings := s.defaults
+	for key, value := range stored {
+		// Values that are no longer valid fall back to defaults
+		_ = settings.Set(key, value)
+	}
+	return settings, nil
+}
+
+// Set validates and stores a single setting and returns the updated settings
+func (s *SettingsService) Set(ctx context.Context, userID int64, key, value string) (domain.Settings, error) {
+	settings, err := s.Get(ctx, userID)
+	if err != nil {
+		return domain.Settings{}, err
+	}
+	if err := settings.Set(key, value); err != nil {
+		return domain.Settings{}, err
+	}
+	if key == domain.SettingLanguage && value != domain.LanguageAuto {
+		if _, ok := i18n.Match(value); !ok {
+			return domain.Settings{}, fmt.Errorf("%s: unsupported language %q", key, value)
+		}
+	}
+	if err := s.settingsRepo.SetSetting(ctx, userID, key, value); err != nil {
+		return domain.Settings{}, err
+	}
+	return settings, nil
+}
+
+// ReviewDirection returns which side of a pair review cards show
+func (s *SettingsService) ReviewDirection(ctx context.Context, userID int64) (domain.ReviewDirection, error) {
+	settings, err := s.Get(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return settings.Direction, nil
+}
+
+// UserLocation implements LocationResolver
+func (s *SettingsService) UserLocation(ctx context.Context, userID int64) (*time.Location, error) {
+	settings, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return settings.Location, nil
+}
+
+// UserPair implements PairResolver
+func (s *SettingsService) UserPair(ctx context.Context, userID int64) (domain.LanguagePair, error) {
+	settings, err := s.Get(ctx, userID)
+	if err != nil {
+		return domain.LanguagePair{}, err
+	}
+	return settings.Pair, nil
+}
+
+// Defaults returns settings of a user who hasn't changed anything
+func (s *SettingsService) Defaults() domain.Settings {
+	return s.defaults
+}
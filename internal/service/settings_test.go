@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testSettingsDefaults() domain.Settings {
+	return domain.Settings{
+		PageSize:    7,
+		HistoryDays: 60,
+		HideDays:    7,
+		Location:    time.UTC,
+		Direction:   domain.DefaultReviewDirection,
+		Pair:        domain.DefaultLanguagePair,
+	}
+}
+
+func TestSettingsService_Get(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		stored   map[string]string
+		expected func(s *domain.Settings)
+	}{
+		{name: "not set", stored: map[string]string{}, expected: func(*domain.Settings) {}},
+		{
+			name: "stored",
+			stored: map[string]string{
+				"page_size":        "10",
+				"history_days":     "90",
+				"hide_days":        "3",
+				"timezone":         "Asia/Tokyo",
+				"review_direction": "reverse",
+			},
+			expected: func(s *domain.Settings) {
+				s.PageSize = 10
+				s.HistoryDays = 90
+				s.HideDays = 3
+				s.Location = tokyo
+				s.Direction = domain.DirectionReverse
+			},
+		},
+		{
+			name:     "invalid values fall back to defaults",
+			stored:   map[string]string{"page_size": "1000", "review_direction": "sideways", "color": "red"},
+			expected: func(*domain.Settings) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(testutil.MockSettingsRepository)
+			repo.On("GetSettings", mock.Anything, int64(123)).Return(tt.stored, nil)
+
+			service := NewSettingsService(repo, testSettingsDefaults())
+			settings, err := service.Get(context.Background(), 123)
+
+			expected := testSettingsDefaults()
+			tt.expected(&expected)
+
+			require.NoError(t, err)
+			assert.Equal(t, expected, settings)
+		})
+	}
+}
+
+func TestSettingsService_Get_Error(t *testing.T) {
+	repo := new(testutil.MockSettingsRepository)
+	repo.On("GetSettings", mock.Anything, int64(123)).Return(nil, fmt.Errorf("db error"))
+
+	service := NewSettingsService(repo, testSettingsDefaults())
+
+	_, err := service.Get(context.Background(), 123)
+	assert.Error(t, err)
+	_, err = service.ReviewDirection(context.Background(), 123)
+	assert.Error(t, err)
+	_, err = service.UserLocation(context.Background(), 123)
+	assert.Error(t, err)
+}
+
+func TestSettingsService_Set(t *testing.T) {
+	repo := new(testutil.MockSettingsRepository)
+	repo.On("GetSettings", mock.Anything, int64(123)).Return(map[string]string{}, nil)
+	repo.On("SetSetting", mock.Anything, int64(123), "page_size", "10").Return(nil)
+
+	service := NewSettingsService(repo, testSettingsDefaults())
+
+	settings, err := service.Set(context.Background(), 123, domain.SettingPageSize, "10")
+	require.NoError(t, err)
+	assert.Equal(t, 10, settings.PageSize)
+
+	_, err = service.Set(context.Background(), 123, domain.SettingPageSize, "1000")
+	assert.Error(t, err)
+	_, err = service.Set(context.Background(), 123, "color", "red")
+	assert.Error(t, err)
+	_, err = service.Set(context.Background(), 123, domain.SettingLanguage, "de")
+	assert.Error(t, err, "unsupported interface language")
+
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "SetSetting", 1)
+}
+
+func TestSettingsService_ReviewDirection(t *testing.T) {
+	repo := new(testutil.MockSettingsRepository)
+	repo.On("GetSettings", mock.Anything, int64(123)).Return(map[string]string{"review_direction": "reverse"}, nil)
+
+	service := NewSettingsService(repo, testSettingsDefaults())
+	direction, err := service.ReviewDirection(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DirectionReverse, direction)
+}
+
+func TestSettingsService_UserLocation(t *testing.T) {
+	repo := new(testutil.MockSettingsRepository)
+	repo.On("GetSettings", mock.Anything, int64(123)).Return(map[string]string{"timezone": "Asia/Tokyo"}, nil)
+	repo.On("GetSettings", mock.Anything, int64(456)).Return(map[string]string{}, nil)
+
+	service := NewSettingsService(repo, testSettingsDefaults())
+
+	loc, err := service.UserLocation(context.Background(), 123)
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", loc.String())
+
+	loc, err = service.UserLocation(context.Background(), 456)
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+}
+
+func TestSettingsService_UserPair(t *testing.T) {
+	repo := new(testutil.MockSettingsRepository)
+	repo.On("GetSettings", mock.Anything, int64(123)).Return(map[string]string{"language_pair": "de-ru"}, nil)
+
+	service := NewSettingsService(repo, testSettingsDefaults())
+	pair, err := service.UserPair(context.Background(), 123)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.LanguagePair{Source: "de", Target: "ru"}, pair)
+}
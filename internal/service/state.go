@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"languager/internal/domain"
+	"languager/internal/repository"
+)
+
+// StateService keeps user dialog states in memory and persists
+// them on Flush, so a restart doesn't lose half-entered word pairs
+type StateService struct {
+	stateRepo repository.StateRepository
+
+	mu     sync.RWMutex
+	states map[int64]*domain.StateData
+	dirty  map[int64]struct{}
+}
+
+// NewStateService creates a new state service
+func NewStateService(stateRepo repository.StateRepository) *StateService {
+	return &StateService{
+		stateRepo: stateRepo,
+		states:    make(map[int64]*domain.StateData),
+		dirty:     make(map[int64]struct{}),
+	}
+}
+
+// Get returns user's current state
+func (s *StateService) Get(userID int64) *domain.StateData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, exists := s.states[userID]
+	if !exists {
+		return &domain.StateData{State: domain.StateIdle}
+	}
+	return state
+}
+
+// Set sets user's state
+func (s *StateService) Set(userID int64, state *domain.StateData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[userID] = state
+	s.dirty[userID] = struct{}{}
+}
+
+// Load restores persisted states, returning how many were loaded
+func (s *StateService) Load(ctx context.Context) (int, error) {
+	states, err := s.stateRepo.LoadStates(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for userID, state := range states {
+		// Don't overwrite states changed since startup
+		if _, changed := s.dirty[userID]; !changed {
+			s.states[userID] = state
+		}
+	}
+	return len(states), nil
+}
+
+// Flush persists states changed since the last flush, returning how many were written
+func (s *StateService) Flush(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	pending := make(map[int64]*domain.StateData, len(s.dirty))
+	for userID := range s.dirty {
+		pending[userID] = s.states[userID]
+	}
+	s.dirty = make(map[int64]struct{})
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	if err := s.stateRepo.SaveStates(ctx, pending); err != nil {
+		// Keep them dirty so the next flush retries
+		s.mu.Lock()
+		for userID := range pending {
+			s.dirty[userID] = struct{}{}
+		}
+		s.mu.Unlock()
+		return 0, err
+	}
+
+	return len(pending), nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStateService_GetDefaultsToIdle(t *testing.T) {
+	service := NewStateService(new(testutil.MockStateRepository))
+
+	state := service.Get(123)
+
+	assert.Equal(t, domain.StateIdle, state.State)
+}
+
+func TestStateService_SetAndGet(t *testing.T) {
+	service := NewStateService(new(testutil.MockStateRepository))
+
+	service.Set(123, &domain.StateData{State: domain.StateWaitingTranslation, CurrentWord: "hello"})
+
+	state := service.Get(123)
+	assert.Equal(t, domain.StateWaitingTranslation, state.State)
+	assert.Equal(t, "hello", state.CurrentWord)
+}
+
+func TestStateService_Load(t *testing.T) {
+	mockRepo := new(testutil.MockStateRepository)
+	mockRepo.On("LoadStates", mock.Anything).Return(map[int64]*domain.StateData{
+		123: {State: domain.StateWaitingTranslation, CurrentWord: "hello"},
+		456: {State: domain.StateWaitingWord},
+	}, nil)
+
+	service := NewStateService(mockRepo)
+
+	// A state changed before Load must win over the persisted one
+	service.Set(456, &domain.StateData{State: domain.StateIdle})
+
+	n, err := service.Load(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "hello", service.Get(123).CurrentWord)
+	assert.Equal(t, domain.StateIdle, service.Get(456).State)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStateService_Load_Error(t *testing.T) {
+	mockRepo := new(testutil.MockStateRepository)
+	mockRepo.On("LoadStates", mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+	service := NewStateService(mockRepo)
+
+	_, err := service.Load(context.Background())
+
+	assert.Error(t, err)
+}
+
+func TestStateService_Flush(t *testing.T) {
+	mockRepo := new(testutil.MockStateRepository)
+	service := NewStateService(mockRepo)
+
+	// Nothing changed, nothing written
+	n, err := service.Flush(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	waiting := &domain.StateData{State: domain.StateWaitingTranslation, CurrentWord: "hello"}
+	service.Set(123, waiting)
+
+	mockRepo.On("SaveStates", mock.Anything, map[int64]*domain.StateData{123: waiting}).Return(nil).Once()
+
+	n, err = service.Flush(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// Already flushed
+	n, err = service.Flush(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStateService_Flush_RetriesAfterError(t *testing.T) {
+	mockRepo := new(testutil.MockStateRepository)
+	service := NewStateService(mockRepo)
+
+	service.Set(123, &domain.StateData{State: domain.StateWaitingWord})
+
+	mockRepo.On("SaveStates", mock.Anything, mock.Anything).Return(fmt.Errorf("db error")).Once()
+	mockRepo.On("SaveStates", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := service.Flush(context.Background())
+	assert.Error(t, err)
+
+	n, err := service.Flush(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -1,38 +1,144 @@
 package service
 
 import (
+	"context"
+	"time"
+
+	"languager/internal/domain"
 	"languager/internal/repository"
+)
 
-	"go.uber.org/zap"
+const (
+	// statsDays is how many days the daily charts cover
+	statsDays = 7
+	// statsWeeks is how many weeks the weekly chart covers
+	statsWeeks = 4
+	// hardestWordsLimit is how many hardest words are shown
+	hardestWordsLimit = 5
+	// calendarWeeks is how many weeks the activity calendar covers
+	calendarWeeks = 12
 )
 
-// StatsService handles statistics and cleanup
+// StatsService computes per-user statistics
 type StatsService struct {
-	wordRepo repository.WordRepository
-	logger   *zap.Logger
+	statsRepo repository.StatsRepository
+	locations LocationResolver
+	now       func() time.Time
 }
 
-// NewStatsService creates a new stats service
-func NewStatsService(wordRepo repository.WordRepository, logger *zap.Logger) *StatsService {
+// NewStatsService creates a new stats service.
+// Days are counted in the user's time zone.
+func NewStatsService(statsRepo repository.StatsRepository, locations LocationResolver) *StatsService {
 	return &StatsService{
-		wordRepo: wordRepo,
-		logger:   logger,
+		statsRepo: statsRepo,
+		locations: locations,
+		now:       time.Now,
 	}
 }
 
-// CleanupOldData removes words older than 60 days
-func (s *StatsService) CleanupOldData() error {
-	const retentionDays = 60
+// GetUserStats returns user's statistics dashboard for the language pair
+func (s *StatsService) GetUserStats(ctx context.Context, userID int64, pair domain.LanguagePair) (*domain.UserStats, error) {
+	totals, err := s.statsRepo.GetWordTotals(ctx, userID, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, correct, err := s.statsRepo.GetReviewTotals(ctx, userID, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := s.locations.UserLocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Daily and weekly charts share one window ending today
+	today := domain.CivilDay(s.now(), loc)
+	first := today.AddDate(0, 0, -(statsWeeks*7 - 1))
+	from := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+
+	added, err := s.statsRepo.GetAddedPerDay(ctx, userID, pair, from, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewsPerDay, err := s.statsRepo.GetReviewsPerDay(ctx, userID, pair, from, loc)
+	if err != nil {
+		return nil, err
+	}
 
-	s.logger.Info("Starting cleanup of old words", zap.Int("retention_days", retentionDays))
+	hardest, err := s.statsRepo.GetHardestWords(ctx, userID, pair, hardestWordsLimit)
+	if err != nil {
+		return nil, err
+	}
 
-	err := s.wordRepo.CleanOldWords(retentionDays)
+	byDirection, err := s.statsRepo.GetReviewsByDirection(ctx, userID, pair)
 	if err != nil {
-		s.logger.Error("Failed to cleanup old words", zap.Error(err))
-		return err
+		return nil, err
 	}
 
-	s.logger.Info("Cleanup completed successfully")
-	return nil
+	// Dates from the driver may carry a different zone, so key by civil day
+	addedByDay := make(map[time.Time]int, len(added))
+	for _, c := range added {
+		addedByDay[domain.CivilDay(c.Day, time.UTC)] = c.Count
+	}
+	reviewsByDay := make(map[time.Time]domain.DailyReviews, len(reviewsPerDay))
+	for _, r := range reviewsPerDay {
+		reviewsByDay[domain.CivilDay(r.Day, time.UTC)] = r
+	}
+
+	stats := &domain.UserStats{
+		WordTotals:     totals,
+		Reviews:        reviews,
+		CorrectReviews: correct,
+		Hardest:        hardest,
+		ByDirection:    byDirection,
+	}
+
+	for i := 0; i < statsWeeks*7; i++ {
+		day := first.AddDate(0, 0, i)
+
+		if i%7 == 0 {
+			stats.AddedPerWeek = append(stats.AddedPerWeek, domain.DailyCount{Day: day})
+		}
+		stats.AddedPerWeek[len(stats.AddedPerWeek)-1].Count += addedByDay[day]
+
+		if i >= statsWeeks*7-statsDays {
+			stats.AddedPerDay = append(stats.AddedPerDay, domain.DailyCount{Day: day, Count: addedByDay[day]})
+		}
+
+		r := reviewsByDay[day]
+		r.Day = day
+		stats.ReviewsPerDay = append(stats.ReviewsPerDay, r)
+	}
+
+	return stats, nil
 }
 
+// GetActivityCalendar returns daily activity for the calendar heatmap.
+// The calendar starts on a Monday and ends today.
+func (s *StatsService) GetActivityCalendar(ctx context.Context, userID int64) (time.Time, []int, error) {
+	loc, err := s.locations.UserLocation(ctx, userID)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	today := domain.CivilDay(s.now(), loc)
+	start := domain.WeekStart(today).AddDate(0, 0, -(calendarWeeks-1)*7)
+
+	activity, err := s.statsRepo.GetActivityPerDay(ctx, userID, start)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	values := make([]int, int(today.Sub(start).Hours()/24)+1)
+	for _, c := range activity {
+		i := int(domain.CivilDay(c.Day, time.UTC).Sub(start).Hours() / 24)
+		if i >= 0 && i < len(values) {
+			values[i] += c.Count
+		}
+	}
+
+	return start, values, nil
+}
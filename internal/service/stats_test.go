@@ -1,50 +1,111 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"languager/internal/domain"
 	"languager/internal/testutil"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-func TestStatsService_CleanupOldData(t *testing.T) {
-	tests := []struct {
-		name          string
-		mockError     error
-		expectedError bool
-	}{
-		{
-			name:          "successful cleanup",
-			mockError:     nil,
-			expectedError: false,
-		},
-		{
-			name:          "database error",
-			mockError:     fmt.Errorf("db error"),
-			expectedError: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := new(testutil.MockWordRepository)
-			mockRepo.On("CleanOldWords", 60).Return(tt.mockError)
-
-			logger := testutil.NewTestLogger()
-			service := NewStatsService(mockRepo, logger)
-
-			err := service.CleanupOldData()
-
-			if tt.expectedError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
-
-			mockRepo.AssertExpectations(t)
-		})
-	}
+func TestStatsService_GetUserStats(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	// Monday 2024-12-16 in Moscow
+	now := time.Date(2024, 12, 16, 12, 0, 0, 0, moscow)
+	from := time.Date(2024, 11, 19, 0, 0, 0, 0, moscow)
+	utcDay := func(d int, m time.Month) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+
+	repo := new(testutil.MockStatsRepository)
+	repo.On("GetWordTotals", mock.Anything, int64(123), domain.DefaultLanguagePair).Return(domain.WordTotals{Total: 40, Mastered: 10}, nil)
+	repo.On("GetReviewTotals", mock.Anything, int64(123), domain.DefaultLanguagePair).Return(20, 15, nil)
+	repo.On("GetAddedPerDay", mock.Anything, int64(123), domain.DefaultLanguagePair, from, moscow).Return([]domain.DailyCount{
+		{Day: utcDay(19, time.November), Count: 4},
+		{Day: utcDay(10, time.December), Count: 2},
+		// Same date, but in a fixed zone as the driver may return it
+		{Day: time.Date(2024, 12, 16, 0, 0, 0, 0, time.FixedZone("", 0)), Count: 3},
+	}, nil)
+	repo.On("GetReviewsPerDay", mock.Anything, int64(123), domain.DefaultLanguagePair, from, moscow).Return([]domain.DailyReviews{
+		{Day: utcDay(15, time.December), Total: 10, Correct: 8},
+	}, nil)
+	repo.On("GetHardestWords", mock.Anything, int64(123), domain.DefaultLanguagePair, hardestWordsLimit).Return([]domain.HardWord{
+		{Word: "through", Translation: "через", Failures: 3, Reviews: 4},
+	}, nil)
+	repo.On("GetReviewsByDirection", mock.Anything, int64(123), domain.DefaultLanguagePair).Return([]domain.DirectionReviews{
+		{Direction: domain.DirectionForward, Total: 12, Correct: 9},
+	}, nil)
+
+	service := NewStatsService(repo, FixedLocation{moscow})
+	service.now = func() time.Time { return now }
+
+	stats, err := service.GetUserStats(context.Background(), 123, domain.DefaultLanguagePair)
+
+	require.NoError(t, err)
+	assert.Equal(t, 40, stats.Total)
+	assert.Equal(t, 75, stats.Accuracy())
+
+	require.Len(t, stats.AddedPerDay, statsDays)
+	assert.Equal(t, utcDay(10, time.December), stats.AddedPerDay[0].Day)
+	assert.Equal(t, 2, stats.AddedPerDay[0].Count)
+	assert.Equal(t, 3, stats.AddedPerDay[6].Count)
+
+	require.Len(t, stats.AddedPerWeek, statsWeeks)
+	assert.Equal(t, utcDay(19, time.November), stats.AddedPerWeek[0].Day)
+	assert.Equal(t, 4, stats.AddedPerWeek[0].Count)
+	assert.Equal(t, 5, stats.AddedPerWeek[3].Count)
+
+	require.Len(t, stats.ReviewsPerDay, statsWeeks*7)
+	assert.Equal(t, 10, stats.ReviewsPerDay[26].Total)
+	assert.Equal(t, utcDay(15, time.December), stats.ReviewsPerDay[26].Day)
+
+	assert.Len(t, stats.Hardest, 1)
+	require.Len(t, stats.ByDirection, 1)
+	assert.Equal(t, 75, stats.ByDirection[0].Accuracy())
+	repo.AssertExpectations(t)
+}
+
+func TestStatsService_GetUserStats_Error(t *testing.T) {
+	repo := new(testutil.MockStatsRepository)
+	repo.On("GetWordTotals", mock.Anything, int64(123), domain.DefaultLanguagePair).Return(domain.WordTotals{}, fmt.Errorf("db error"))
+
+	service := NewStatsService(repo, FixedLocation{time.UTC})
+
+	_, err := service.GetUserStats(context.Background(), 123, domain.DefaultLanguagePair)
+
+	assert.Error(t, err)
 }
 
+func TestStatsService_GetActivityCalendar(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	// Wednesday 2024-12-18 in Moscow
+	now := time.Date(2024, 12, 18, 12, 0, 0, 0, moscow)
+	start := time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC)
+
+	repo := new(testutil.MockStatsRepository)
+	repo.On("GetActivityPerDay", mock.Anything, int64(123), start).Return([]domain.DailyCount{
+		{Day: start, Count: 4},
+		{Day: time.Date(2024, 12, 18, 0, 0, 0, 0, time.FixedZone("", 0)), Count: 7},
+	}, nil)
+
+	service := NewStatsService(repo, FixedLocation{moscow})
+	service.now = func() time.Time { return now }
+
+	gotStart, values, err := service.GetActivityCalendar(context.Background(), 123)
+
+	require.NoError(t, err)
+	assert.Equal(t, start, gotStart)
+	assert.Equal(t, time.Monday, gotStart.Weekday())
+	require.Len(t, values, (calendarWeeks-1)*7+3)
+	assert.Equal(t, 4, values[0])
+	assert.Equal(t, 7, values[len(values)-1])
+	repo.AssertExpectations(t)
+}
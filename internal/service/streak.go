@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/repository"
+)
+
+// StreakStatus is a snapshot of user's streak for display
+type StreakStatus struct {
+	Current       int
+	Best          int
+	Today         domain.Activity
+	DailyGoal     int
+	FreezeEnabled bool
+}
+
+// GoalResult describes what recorded activity did to the streak
+type GoalResult struct {
+	Reached    bool // the daily goal was reached just now
+	Streak     int
+	UsedFreeze bool // a freeze saved the streak
+}
+
+// StreakService tracks daily activity, goals and streaks
+type StreakService struct {
+	streakRepo repository.StreakRepository
+	locations  LocationResolver
+	events     *EventBus
+	now        func() time.Time
+}
+
+// NewStreakService creates a new streak service.
+// Days start at midnight in the user's time zone; reached goals are published to events.
+func NewStreakService(streakRepo repository.StreakRepository, locations LocationResolver, events *EventBus) *StreakService {
+	return &StreakService{
+		streakRepo: streakRepo,
+		locations:  locations,
+		events:     events,
+		now:        time.Now,
+	}
+}
+
+func (s *StreakService) today(ctx context.Context, userID int64) (time.Time, error) {
+	loc, err := s.locations.UserLocation(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return domain.CivilDay(s.now(), loc), nil
+}
+
+func (s *StreakService) getStreak(ctx context.Context, userID int64) (*domain.Streak, error) {
+	streak, err := s.streakRepo.GetStreak(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if streak == nil {
+		streak = domain.NewStreak(userID)
+	}
+	return streak, nil
+}
+
+// RecordActivity counts added or reviewed words and extends the streak
+// when they make today's total reach the daily goal
+func (s *StreakService) RecordActivity(ctx context.Context, userID int64, delta domain.Activity) (GoalResult, error) {
+	today, err := s.today(ctx, userID)
+	if err != nil {
+		return GoalResult{}, err
+	}
+
+	totals, err := s.streakRepo.AddActivity(ctx, userID, today, delta)
+	if err != nil {
+		return GoalResult{}, err
+	}
+
+	streak, err := s.getStreak(ctx, userID)
+	if err != nil {
+		return GoalResult{}, err
+	}
+
+	// Only the update that crosses the goal counts, later ones are no-ops
+	before := totals.Total() - delta.Total()
+	if before >= streak.DailyGoal || totals.Total() < streak.DailyGoal {
+		return GoalResult{}, nil
+	}
+
+	return s.reachGoal(ctx, streak, today)
+}
+
+func (s *StreakService) reachGoal(ctx context.Context, streak *domain.Streak, today time.Time) (GoalResult, error) {
+	if streak.LastGoalDay != nil && streak.LastGoalDay.Equal(today) {
+		return GoalResult{}, nil
+	}
+
+	usedFreeze := streak.RecordGoal(today)
+	if err := s.streakRepo.SaveStreak(ctx, streak); err != nil {
+		return GoalResult{}, err
+	}
+
+	s.events.Publish(ctx, domain.GoalReached{UserID: streak.UserID, Streak: streak.Current, Best: streak.Best})
+
+	return GoalResult{Reached: true, Streak: streak.Current, UsedFreeze: usedFreeze}, nil
+}
+
+// Status returns user's current streak and today's progress
+func (s *StreakService) Status(ctx context.Context, userID int64) (StreakStatus, error) {
+	today, err := s.today(ctx, userID)
+	if err != nil {
+		return StreakStatus{}, err
+	}
+
+	streak, err := s.getStreak(ctx, userID)
+	if err != nil {
+		return StreakStatus{}, err
+	}
+
+	activity, err := s.streakRepo.GetActivity(ctx, userID, today)
+	if err != nil {
+		return StreakStatus{}, err
+	}
+
+	return StreakStatus{
+		Current:       streak.CurrentAt(today),
+		Best:          streak.Best,
+		Today:         activity,
+		DailyGoal:     streak.DailyGoal,
+		FreezeEnabled: streak.FreezeEnabled,
+	}, nil
+}
+
+// SetDailyGoal changes the daily goal. If today's activity already
+// meets the new goal, the day is counted right away.
+func (s *StreakService) SetDailyGoal(ctx context.Context, userID int64, goal int) (GoalResult, error) {
+	if goal <= 0 {
+		return GoalResult{}, fmt.Errorf("daily goal must be positive, got %d", goal)
+	}
+
+	streak, err := s.getStreak(ctx, userID)
+	if err != nil {
+		return GoalResult{}, err
+	}
+	streak.DailyGoal = goal
+	if err := s.streakRepo.SaveStreak(ctx, streak); err != nil {
+		return GoalResult{}, err
+	}
+
+	today, err := s.today(ctx, userID)
+	if err != nil {
+		return GoalResult{}, err
+	}
+	activity, err := s.streakRepo.GetActivity(ctx, userID, today)
+	if err != nil {
+		return GoalResult{}, err
+	}
+	if activity.Total() < goal {
+		return GoalResult{}, nil
+	}
+
+	return s.reachGoal(ctx, streak, today)
+}
+
+// ToggleFreeze enables or disables streak freezes
+func (s *StreakService) ToggleFreeze(ctx context.Context, userID int64) error {
+	streak, err := s.getStreak(ctx, userID)
+	if err != nil {
+		return err
+	}
+	streak.FreezeEnabled = !streak.FreezeEnabled
+	return s.streakRepo.SaveStreak(ctx, streak)
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"languager/internal/domain"
+	"languager/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStreakService(t *testing.T, now time.Time) (*StreakService, *testutil.MockStreakRepository) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	repo := new(testutil.MockStreakRepository)
+	service := NewStreakService(repo, FixedLocation{moscow}, NewEventBus(nil, testutil.NewTestLogger()))
+	service.now = func() time.Time { return now }
+	return service, repo
+}
+
+func TestStreakService_RecordActivity(t *testing.T) {
+	// 23:30 UTC on the 15th is already the 16th in Moscow
+	now := time.Date(2024, 12, 15, 23, 30, 0, 0, time.UTC)
+	today := time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name     string
+		totals   domain.Activity
+		streak   *domain.Streak
+		expected GoalResult
+		saved    bool
+	}{
+		{
+			name:     "below goal",
+			totals:   domain.Activity{Reviews: 4},
+			streak:   &domain.Streak{UserID: 123, DailyGoal: 5},
+			expected: GoalResult{},
+		},
+		{
+			name:     "goal reached, streak continues",
+			totals:   domain.Activity{WordsAdded: 2, Reviews: 3},
+			streak:   &domain.Streak{UserID: 123, Current: 2, Best: 2, LastGoalDay: &yesterday, DailyGoal: 5},
+			expected: GoalResult{Reached: true, Streak: 3},
+			saved:    true,
+		},
+		{
+			name:     "first goal for a new user",
+			totals:   domain.Activity{Reviews: 5},
+			streak:   nil,
+			expected: GoalResult{Reached: true, Streak: 1},
+			saved:    true,
+		},
+		{
+			name:     "already above goal",
+			totals:   domain.Activity{Reviews: 6},
+			streak:   &domain.Streak{UserID: 123, Current: 3, Best: 3, LastGoalDay: &today, DailyGoal: 5},
+			expected: GoalResult{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestStreakService(t, now)
+
+			repo.On("AddActivity", mock.Anything, int64(123), today, domain.Activity{Reviews: 1}).Return(tt.totals, nil)
+			repo.On("GetStreak", mock.Anything, int64(123)).Return(tt.streak, nil)
+			if tt.saved {
+				repo.On("SaveStreak", mock.Anything, mock.MatchedBy(func(s *domain.Streak) bool {
+					return s.LastGoalDay.Equal(today) && s.Current == tt.expected.Streak
+				})).Return(nil)
+			}
+
+			var published []domain.GoalReached
+			Subscribe(service.events, "test", Sync, func(ctx context.Context, e domain.GoalReached) error {
+				published = append(published, e)
+				return nil
+			})
+
+			result, err := service.RecordActivity(context.Background(), 123, domain.Activity{Reviews: 1})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			if tt.saved {
+				require.Len(t, published, 1)
+				assert.Equal(t, int64(123), published[0].UserID)
+				assert.Equal(t, tt.expected.Streak, published[0].Streak)
+				assert.GreaterOrEqual(t, published[0].Best, published[0].Streak)
+			} else {
+				assert.Empty(t, published)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStreakService_RecordActivity_Error(t *testing.T) {
+	service, repo := newTestStreakService(t, time.Now())
+	repo.On("AddActivity", mock.Anything, int64(123), mock.Anything, mock.Anything).
+		Return(domain.Activity{}, fmt.Errorf("db error"))
+
+	_, err := service.RecordActivity(context.Background(), 123, domain.Activity{WordsAdded: 1})
+
+	assert.Error(t, err)
+}
+
+func TestStreakService_Status(t *testing.T) {
+	now := time.Date(2024, 12, 16, 12, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC)
+	brokenOn := today.AddDate(0, 0, -3)
+
+	service, repo := newTestStreakService(t, now)
+	repo.On("GetStreak", mock.Anything, int64(123)).Return(&domain.Streak{
+		UserID: 123, Current: 4, Best: 9, LastGoalDay: &brokenOn, DailyGoal: 10,
+	}, nil)
+	repo.On("GetActivity", mock.Anything, int64(123), today).Return(domain.Activity{WordsAdded: 1, Reviews: 2}, nil)
+
+	status, err := service.Status(context.Background(), 123)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, status.Current)
+	assert.Equal(t, 9, status.Best)
+	assert.Equal(t, 3, status.Today.Total())
+	assert.Equal(t, 10, status.DailyGoal)
+}
+
+func TestStreakService_SetDailyGoal(t *testing.T) {
+	now := time.Date(2024, 12, 16, 12, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC)
+
+	service, repo := newTestStreakService(t, now)
+	repo.On("GetStreak", mock.Anything, int64(123)).Return(&domain.Streak{UserID: 123, DailyGoal: 10}, nil)
+	repo.On("SaveStreak", mock.Anything, mock.Anything).Return(nil)
+	repo.On("GetActivity", mock.Anything, int64(123), today).Return(domain.Activity{Reviews: 4}, nil)
+
+	// Lowering the goal below today's total counts the day immediately
+	result, err := service.SetDailyGoal(context.Background(), 123, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, GoalResult{Reached: true, Streak: 1}, result)
+	repo.AssertNumberOfCalls(t, "SaveStreak", 2)
+
+	_, err = service.SetDailyGoal(context.Background(), 123, 0)
+	assert.Error(t, err)
+}
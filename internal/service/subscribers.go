@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+
+	"languager/internal/domain"
+	"languager/internal/metrics"
+)
+
+// SubscribeMetrics counts word and review events
+func SubscribeMetrics(bus *EventBus) {
+	Subscribe(bus, "metrics", Sync, func(ctx context.Context, e domain.WordSaved) error {
+		metrics.WordsSaved.Inc()
+		return nil
+	})
+	Subscribe(bus, "metrics", Sync, func(ctx context.Context, e domain.WordHidden) error {
+		kind := "snooze"
+		if e.Forever() {
+			kind = "forever"
+		}
+		metrics.WordsHidden.WithLabelValues(kind).Inc()
+		return nil
+	})
+	Subscribe(bus, "metrics", Sync, func(ctx context.Context, e domain.ReviewGraded) error {
+		result := "wrong"
+		if e.Correct {
+			result = "correct"
+		}
+		metrics.ReviewsDone.Inc()
+		metrics.ReviewsGraded.WithLabelValues(result).Inc()
+		return nil
+	})
+}
+
+// SubscribeAchievements awards achievements after saves, correct answers, reached
+// goals and perfect sessions, and announces them through sender. Each metric is
+// checked on the event that changes it, once the change is stored.
+func SubscribeAchievements(bus *EventBus, achievements *AchievementService, sender AchievementSender) {
+	Subscribe(bus, "achievements", Async, func(ctx context.Context, e domain.WordSaved) error {
+		return achievements.Award(ctx, e.UserID, domain.AchievementEvent{}, sender)
+	})
+	Subscribe(bus, "achievements", Async, func(ctx context.Context, e domain.ReviewGraded) error {
+		if !e.Correct {
+			return nil
+		}
+		return achievements.Award(ctx, e.UserID, domain.AchievementEvent{}, sender)
+	})
+	Subscribe(bus, "achievements", Async, func(ctx context.Context, e domain.GoalReached) error {
+		return achievements.Award(ctx, e.UserID, domain.AchievementEvent{}, sender)
+	})
+	Subscribe(bus, "achievements", Async, func(ctx context.Context, e domain.SessionFinished) error {
+		if !e.Perfect {
+			return nil
+		}
+		return achievements.Award(ctx, e.UserID, domain.AchievementEvent{PerfectSession: true}, sender)
+	})
+}
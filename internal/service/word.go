@@ -1,52 +1,146 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"languager/internal/cloze"
 	"languager/internal/domain"
 	"languager/internal/repository"
 )
 
-// WordService handles word-related business logic
+const (
+	// MaxExampleLength limits example sentences, in characters
+	MaxExampleLength = 300
+	// clozeCandidates is how many words with examples are tried for a cloze card
+	clozeCandidates = 5
+)
+
+var (
+	// ErrExampleTooLong is returned for examples over MaxExampleLength
+	ErrExampleTooLong = errors.New("example is too long")
+	// ErrExampleMismatch is returned for examples that don't contain the word
+	ErrExampleMismatch = errors.New("example doesn't contain the word")
+)
+
+// WordService handles word-related business logic.
+// Saves, hides and reviews are published to events.
 type WordService struct {
 	wordRepo repository.WordRepository
+	events   *EventBus
 }
 
 // NewWordService creates a new word service
-func NewWordService(wordRepo repository.WordRepository) *WordService {
-	return &WordService{wordRepo: wordRepo}
+func NewWordService(wordRepo repository.WordRepository, events *EventBus) *WordService {
+	return &WordService{wordRepo: wordRepo, events: events}
 }
 
-// SaveWordPair saves a word-translation pair
-func (s *WordService) SaveWordPair(userID int64, word, translation string) error {
+// SaveWordPair saves a word-translation pair in the language pair and returns its ID
+func (s *WordService) SaveWordPair(ctx context.Context, userID int64, pair domain.LanguagePair, word, translation string) (int, error) {
 	if word == "" || translation == "" {
-		return fmt.Errorf("word and translation cannot be empty")
+		return 0, fmt.Errorf("word and translation cannot be empty")
+	}
+	id, err := s.wordRepo.SaveWord(ctx, userID, pair, word, translation)
+	if err != nil {
+		return 0, err
+	}
+
+	s.events.Publish(ctx, domain.WordSaved{UserID: userID, WordID: id, Pair: pair, Word: word, Translation: translation})
+	return id, nil
+}
+
+// SwapWord swaps the word and the translation of a word saved in the wrong order
+func (s *WordService) SwapWord(ctx context.Context, userID int64, wordID int) (*domain.Word, error) {
+	word, err := s.wordRepo.SwapWord(ctx, userID, wordID)
+	if err != nil {
+		return nil, err
+	}
+	if word == nil {
+		return nil, fmt.Errorf("word %d not found", wordID)
+	}
+	return word, nil
+}
+
+// GetRandomPair returns a random word-translation pair of the language pair
+func (s *WordService) GetRandomPair(ctx context.Context, userID int64, pair domain.LanguagePair) (*domain.Word, error) {
+	return s.wordRepo.GetRandomWord(ctx, userID, pair)
+}
+
+// GetProblemPair returns a random word of the language pair the user failed last time,
+// nil if there are none
+func (s *WordService) GetProblemPair(ctx context.Context, userID int64, pair domain.LanguagePair) (*domain.Word, error) {
+	return s.wordRepo.GetRandomProblemWord(ctx, userID, pair)
+}
+
+// StartSession picks up to size random words of the language pair for a review session;
+// 0 means all due words. Returns nil if there is nothing to review.
+func (s *WordService) StartSession(ctx context.Context, userID int64, pair domain.LanguagePair, size int) (*domain.ReviewSession, error) {
+	if size < 0 {
+		return nil, fmt.Errorf("invalid session size %d", size)
 	}
-	return s.wordRepo.SaveWord(userID, word, translation)
+
+	ids, err := s.wordRepo.GetRandomWordIDs(ctx, userID, pair, size)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return domain.NewReviewSession(ids), nil
 }
 
-// GetRandomPair returns a random word-translation pair
-func (s *WordService) GetRandomPair(userID int64) (*domain.Word, error) {
-	return s.wordRepo.GetRandomWord(userID)
+// FinishSession publishes the result of a finished review session
+func (s *WordService) FinishSession(ctx context.Context, userID int64, session *domain.ReviewSession) {
+	s.events.Publish(ctx, domain.SessionFinished{
+		UserID:  userID,
+		Total:   session.Total(),
+		Correct: session.Correct,
+		Perfect: session.Perfect(),
+	})
+}
+
+// GetWordsByIDs returns user's words in the order of ids, skipping deleted ones
+func (s *WordService) GetWordsByIDs(ctx context.Context, userID int64, ids []int) ([]domain.Word, error) {
+	words, err := s.wordRepo.GetWordsByIDs(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]domain.Word, len(words))
+	for _, w := range words {
+		byID[w.ID] = w
+	}
+
+	ordered := make([]domain.Word, 0, len(words))
+	for _, id := range ids {
+		if w, ok := byID[id]; ok {
+			ordered = append(ordered, w)
+		}
+	}
+	return ordered, nil
 }
 
-// GetDaysList returns paginated list of days with word counts
-func (s *WordService) GetDaysList(userID int64, page int) ([]domain.Day, int, error) {
-	const pageSize = 7
+// GetDaysList returns paginated list of days with word counts.
+// Page size, history window, time zone and language pair come from user's settings.
+func (s *WordService) GetDaysList(ctx context.Context, userID int64, page int, settings domain.Settings) ([]domain.Day, int, error) {
+	pageSize := settings.PageSize
 
 	if page < 1 {
 		page = 1
 	}
 
 	offset := (page - 1) * pageSize
-	days, err := s.wordRepo.GetDaysWithWords(userID, pageSize, offset)
+	days, err := s.wordRepo.GetDaysWithWords(ctx, userID, settings.Pair, settings.HistoryDays, pageSize, offset, settings.Location)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Calculate total pages
-	totalDays, err := s.wordRepo.GetTotalDaysCount(userID)
+	totalDays, err := s.wordRepo.GetTotalDaysCount(ctx, userID, settings.Pair, settings.HistoryDays, settings.Location)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -59,24 +153,91 @@ func (s *WordService) GetDaysList(userID int64, page int) ([]domain.Day, int, er
 	return days, totalPages, nil
 }
 
-// GetWordsByDate returns all words for a specific date
-func (s *WordService) GetWordsByDate(userID int64, dateStr string) ([]domain.Word, error) {
+// GetWordsByDate returns all words of the language pair added on a specific date in loc
+func (s *WordService) GetWordsByDate(ctx context.Context, userID int64, pair domain.LanguagePair, dateStr string, loc *time.Location) ([]domain.Word, error) {
 	// Parse date string (YYYYMMDD format)
 	date, err := time.Parse("20060102", dateStr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid date format: %w", err)
 	}
 
-	return s.wordRepo.GetWordsByDate(userID, date)
+	return s.wordRepo.GetWordsByDate(ctx, userID, pair, date, loc)
+}
+
+// GetLanguagePairs returns the pairs the user has words in, most words first
+func (s *WordService) GetLanguagePairs(ctx context.Context, userID int64) ([]domain.LanguagePair, error) {
+	return s.wordRepo.GetLanguagePairs(ctx, userID)
+}
+
+// SnoozeWord hides user's word from random pair for the given duration
+func (s *WordService) SnoozeWord(ctx context.Context, userID int64, wordID int, duration time.Duration) error {
+	if duration <= 0 || duration > domain.MaxHideDays*24*time.Hour {
+		return fmt.Errorf("snooze duration must be between 1 second and %d days, got %s", domain.MaxHideDays, duration)
+	}
+	if err := s.wordRepo.SnoozeWord(ctx, userID, wordID, duration); err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, domain.WordHidden{UserID: userID, WordID: wordID, Until: time.Now().Add(duration)})
+	return nil
+}
+
+// HideWordForever permanently hides user's word from random pair
+func (s *WordService) HideWordForever(ctx context.Context, userID int64, wordID int) error {
+	if err := s.wordRepo.HideWordForever(ctx, userID, wordID); err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, domain.WordHidden{UserID: userID, WordID: wordID})
+	return nil
 }
 
-// HideWordFor7Days hides a word from random pair for 7 days
-func (s *WordService) HideWordFor7Days(wordID int) error {
-	return s.wordRepo.HideWordFor7Days(wordID)
+// SetExample stores an example sentence of the word for cloze cards.
+// The sentence must contain word in some form so that it can be blanked out.
+func (s *WordService) SetExample(ctx context.Context, userID int64, wordID int, word, example string) error {
+	example = strings.TrimSpace(example)
+	if example == "" {
+		return fmt.Errorf("example cannot be empty")
+	}
+	if utf8.RuneCountInString(example) > MaxExampleLength {
+		return ErrExampleTooLong
+	}
+	if _, ok := cloze.Make(example, word); !ok {
+		return ErrExampleMismatch
+	}
+	return s.wordRepo.SetExample(ctx, userID, wordID, example)
 }
 
-// HideWordForever permanently hides a word from random pair
-func (s *WordService) HideWordForever(wordID int) error {
-	return s.wordRepo.HideWordForever(wordID)
+// NextCloze returns a random word of the language pair with its cloze card.
+// Words without an example, or whose example no longer contains the word
+// (e.g. after a swap), are skipped. Returns nil if there is nothing to show.
+func (s *WordService) NextCloze(ctx context.Context, userID int64, pair domain.LanguagePair) (*domain.Word, *cloze.Card, error) {
+	words, err := s.wordRepo.GetRandomExampleWords(ctx, userID, pair, clozeCandidates)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range words {
+		if card, ok := cloze.Make(words[i].Example, words[i].Word); ok {
+			return &words[i], &card, nil
+		}
+	}
+	return nil, nil, nil
 }
 
+// GradeReview records whether the user remembered the word shown in direction.
+// Returns true if the word became mastered.
+func (s *WordService) GradeReview(ctx context.Context, userID int64, wordID int, correct bool, direction domain.ReviewDirection) (bool, error) {
+	mastered, err := s.wordRepo.RecordReview(ctx, userID, wordID, correct, direction)
+	if err != nil {
+		return false, err
+	}
+
+	s.events.Publish(ctx, domain.ReviewGraded{
+		UserID:    userID,
+		WordID:    wordID,
+		Correct:   correct,
+		Direction: direction,
+		Mastered:  mastered,
+	})
+	return mastered, nil
+}
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -61,17 +63,18 @@ func TestWordService_SaveWordPair(t *testing.T) {
 
 			// Only set up mock if inputs are valid
 			if tt.word != "" && tt.translation != "" {
-				mockRepo.On("SaveWord", tt.userID, tt.word, tt.translation).Return(tt.mockError)
+				mockRepo.On("SaveWord", mock.Anything, tt.userID, domain.DefaultLanguagePair, tt.word, tt.translation).Return(42, tt.mockError)
 			}
 
-			service := NewWordService(mockRepo)
+			service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
 
-			err := service.SaveWordPair(tt.userID, tt.word, tt.translation)
+			id, err := service.SaveWordPair(context.Background(), tt.userID, domain.DefaultLanguagePair, tt.word, tt.translation)
 
 			if tt.expectedError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, 42, id)
 			}
 
 			if tt.word != "" && tt.translation != "" {
@@ -121,11 +124,11 @@ func TestWordService_GetRandomPair(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(testutil.MockWordRepository)
-			mockRepo.On("GetRandomWord", tt.userID).Return(tt.mockReturn, tt.mockError)
+			mockRepo.On("GetRandomWord", mock.Anything, tt.userID, domain.DefaultLanguagePair).Return(tt.mockReturn, tt.mockError)
 
-			service := NewWordService(mockRepo)
+			service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
 
-			word, err := service.GetRandomPair(tt.userID)
+			word, err := service.GetRandomPair(context.Background(), tt.userID, domain.DefaultLanguagePair)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -141,78 +144,78 @@ func TestWordService_GetRandomPair(t *testing.T) {
 
 func TestWordService_GetDaysList(t *testing.T) {
 	tests := []struct {
-		name              string
-		userID            int64
-		page              int
-		mockDays          []domain.Day
-		mockTotalDays     int
-		mockError         error
+		name               string
+		userID             int64
+		page               int
+		mockDays           []domain.Day
+		mockTotalDays      int
+		mockError          error
 		mockTotalDaysError error
-		expectedPages     int
-		expectedDaysCount int
-		expectedError     bool
+		expectedPages      int
+		expectedDaysCount  int
+		expectedError      bool
 	}{
 		{
-			name:              "first page with 7 days",
-			userID:            123,
-			page:              1,
-			mockDays:          []domain.Day{testutil.NewTestDay(time.Now(), 5), testutil.NewTestDay(time.Now().AddDate(0, 0, -1), 3)},
-			mockTotalDays:     14,
-			mockError:         nil,
+			name:               "first page with 7 days",
+			userID:             123,
+			page:               1,
+			mockDays:           []domain.Day{testutil.NewTestDay(time.Now(), 5), testutil.NewTestDay(time.Now().AddDate(0, 0, -1), 3)},
+			mockTotalDays:      14,
+			mockError:          nil,
 			mockTotalDaysError: nil,
-			expectedPages:     2,
-			expectedDaysCount: 2,
-			expectedError:     false,
+			expectedPages:      2,
+			expectedDaysCount:  2,
+			expectedError:      false,
 		},
 		{
-			name:              "invalid page number (negative)",
-			userID:            123,
-			page:              -1,
-			mockDays:          []domain.Day{},
-			mockTotalDays:     7,
-			mockError:         nil,
+			name:               "invalid page number (negative)",
+			userID:             123,
+			page:               -1,
+			mockDays:           []domain.Day{},
+			mockTotalDays:      7,
+			mockError:          nil,
 			mockTotalDaysError: nil,
-			expectedPages:     1,
-			expectedDaysCount: 0,
-			expectedError:     false,
+			expectedPages:      1,
+			expectedDaysCount:  0,
+			expectedError:      false,
 		},
 		{
-			name:              "page zero defaults to 1",
-			userID:            123,
-			page:              0,
-			mockDays:          []domain.Day{testutil.NewTestDay(time.Now(), 5)},
-			mockTotalDays:     1,
-			mockError:         nil,
+			name:               "page zero defaults to 1",
+			userID:             123,
+			page:               0,
+			mockDays:           []domain.Day{testutil.NewTestDay(time.Now(), 5)},
+			mockTotalDays:      1,
+			mockError:          nil,
 			mockTotalDaysError: nil,
-			expectedPages:     1,
-			expectedDaysCount: 1,
-			expectedError:     false,
+			expectedPages:      1,
+			expectedDaysCount:  1,
+			expectedError:      false,
 		},
 		{
-			name:              "database error on days",
-			userID:            123,
-			page:              1,
-			mockError:         fmt.Errorf("db error"),
+			name:               "database error on days",
+			userID:             123,
+			page:               1,
+			mockError:          fmt.Errorf("db error"),
 			mockTotalDaysError: nil,
-			expectedError:     true,
+			expectedError:      true,
 		},
 		{
-			name:              "zero total days sets totalPages to 1",
-			userID:            123,
-			page:              1,
-			mockDays:          []domain.Day{},
-			mockTotalDays:     0,
-			mockError:         nil,
+			name:               "zero total days sets totalPages to 1",
+			userID:             123,
+			page:               1,
+			mockDays:           []domain.Day{},
+			mockTotalDays:      0,
+			mockError:          nil,
 			mockTotalDaysError: nil,
-			expectedPages:     1,
-			expectedDaysCount: 0,
-			expectedError:     false,
+			expectedPages:      1,
+			expectedDaysCount:  0,
+			expectedError:      false,
 		},
 		{
-			name:              "database error on total count",
-			userID:            123,
-			page:              1,
-			mockDays:          []domain.Day{testutil.NewTestDay(time.Now(), 5)},
+			name:               "database error on total count",
+			userID:             123,
+			page:               1,
+			mockDays:           []domain.Day{testutil.NewTestDay(time.Now(), 5)},
 			mockError:          nil,
 			mockTotalDaysError: fmt.Errorf("db error"),
 			expectedError:      true,
@@ -229,19 +232,19 @@ func TestWordService_GetDaysList(t *testing.T) {
 			}
 			offset := (page - 1) * 7
 
-			mockRepo.On("GetDaysWithWords", tt.userID, 7, offset).Return(tt.mockDays, tt.mockError)
+			mockRepo.On("GetDaysWithWords", mock.Anything, tt.userID, domain.DefaultLanguagePair, 60, 7, offset, time.UTC).Return(tt.mockDays, tt.mockError)
 
 			if tt.mockError == nil {
 				if tt.mockTotalDaysError != nil {
-					mockRepo.On("GetTotalDaysCount", tt.userID).Return(0, tt.mockTotalDaysError)
+					mockRepo.On("GetTotalDaysCount", mock.Anything, tt.userID, domain.DefaultLanguagePair, 60, time.UTC).Return(0, tt.mockTotalDaysError)
 				} else {
-					mockRepo.On("GetTotalDaysCount", tt.userID).Return(tt.mockTotalDays, nil)
+					mockRepo.On("GetTotalDaysCount", mock.Anything, tt.userID, domain.DefaultLanguagePair, 60, time.UTC).Return(tt.mockTotalDays, nil)
 				}
 			}
 
-			service := NewWordService(mockRepo)
+			service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
 
-			days, totalPages, err := service.GetDaysList(tt.userID, tt.page)
+			days, totalPages, err := service.GetDaysList(context.Background(), tt.userID, tt.page, testSettingsDefaults())
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -256,6 +259,25 @@ func TestWordService_GetDaysList(t *testing.T) {
 	}
 }
 
+func TestWordService_GetDaysList_CustomSettings(t *testing.T) {
+	pair := domain.LanguagePair{Source: "de", Target: "ru"}
+	mockRepo := new(testutil.MockWordRepository)
+	mockRepo.On("GetDaysWithWords", mock.Anything, int64(123), pair, 30, 3, 3, time.UTC).Return([]domain.Day{}, nil)
+	mockRepo.On("GetTotalDaysCount", mock.Anything, int64(123), pair, 30, time.UTC).Return(10, nil)
+
+	settings := testSettingsDefaults()
+	settings.PageSize = 3
+	settings.HistoryDays = 30
+	settings.Pair = pair
+
+	service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
+	_, totalPages, err := service.GetDaysList(context.Background(), 123, 2, settings)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, totalPages)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestWordService_GetWordsByDate(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -293,14 +315,14 @@ func TestWordService_GetWordsByDate(t *testing.T) {
 
 			if !tt.expectedError {
 				date, _ := time.Parse("20060102", tt.dateStr)
-				mockRepo.On("GetWordsByDate", int64(123), mock.MatchedBy(func(d time.Time) bool {
+				mockRepo.On("GetWordsByDate", mock.Anything, int64(123), domain.DefaultLanguagePair, mock.MatchedBy(func(d time.Time) bool {
 					return d.Year() == date.Year() && d.Month() == date.Month() && d.Day() == date.Day()
-				})).Return(tt.mockWords, tt.mockError)
+				}), time.UTC).Return(tt.mockWords, tt.mockError)
 			}
 
-			service := NewWordService(mockRepo)
+			service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
 
-			words, err := service.GetWordsByDate(123, tt.dateStr)
+			words, err := service.GetWordsByDate(context.Background(), 123, domain.DefaultLanguagePair, tt.dateStr, time.UTC)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -316,7 +338,7 @@ func TestWordService_GetWordsByDate(t *testing.T) {
 	}
 }
 
-func TestWordService_HideWordFor7Days(t *testing.T) {
+func TestWordService_SnoozeWord(t *testing.T) {
 	tests := []struct {
 		name          string
 		wordID        int
@@ -324,7 +346,7 @@ func TestWordService_HideWordFor7Days(t *testing.T) {
 		expectedError bool
 	}{
 		{
-			name:          "successful hide",
+			name:          "successful snooze",
 			wordID:        1,
 			mockError:     nil,
 			expectedError: false,
@@ -340,11 +362,11 @@ func TestWordService_HideWordFor7Days(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(testutil.MockWordRepository)
-			mockRepo.On("HideWordFor7Days", tt.wordID).Return(tt.mockError)
+			mockRepo.On("SnoozeWord", mock.Anything, int64(123), tt.wordID, 72*time.Hour).Return(tt.mockError)
 
-			service := NewWordService(mockRepo)
+			service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
 
-			err := service.HideWordFor7Days(tt.wordID)
+			err := service.SnoozeWord(context.Background(), 123, tt.wordID, 72*time.Hour)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -381,11 +403,11 @@ func TestWordService_HideWordForever(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(testutil.MockWordRepository)
-			mockRepo.On("HideWordForever", tt.wordID).Return(tt.mockError)
+			mockRepo.On("HideWordForever", mock.Anything, int64(123), tt.wordID).Return(tt.mockError)
 
-			service := NewWordService(mockRepo)
+			service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
 
-			err := service.HideWordForever(tt.wordID)
+			err := service.HideWordForever(context.Background(), 123, tt.wordID)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -398,3 +420,150 @@ func TestWordService_HideWordForever(t *testing.T) {
 	}
 }
 
+func TestWordService_GradeReview(t *testing.T) {
+	mockRepo := new(testutil.MockWordRepository)
+	mockRepo.On("RecordReview", mock.Anything, int64(123), 5, true, domain.DirectionForward).Return(true, nil)
+	mockRepo.On("RecordReview", mock.Anything, int64(123), 6, false, domain.DirectionReverse).Return(false, fmt.Errorf("database error"))
+
+	service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
+
+	mastered, err := service.GradeReview(context.Background(), 123, 5, true, domain.DirectionForward)
+	assert.NoError(t, err)
+	assert.True(t, mastered)
+
+	_, err = service.GradeReview(context.Background(), 123, 6, false, domain.DirectionReverse)
+	assert.Error(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWordService_StartSession(t *testing.T) {
+	tests := []struct {
+		name        string
+		size        int
+		ids         []int
+		expectedNil bool
+		expectedErr bool
+	}{
+		{name: "batch of 10", size: 10, ids: []int{3, 1, 2}},
+		{name: "all due words", size: 0, ids: []int{1}},
+		{name: "nothing to review", size: 10, ids: nil, expectedNil: true},
+		{name: "negative size", size: -1, expectedNil: true, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(testutil.MockWordRepository)
+			if tt.size >= 0 {
+				mockRepo.On("GetRandomWordIDs", mock.Anything, int64(123), domain.DefaultLanguagePair, tt.size).Return(tt.ids, nil)
+			}
+
+			service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
+			session, err := service.StartSession(context.Background(), 123, domain.DefaultLanguagePair, tt.size)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectedNil {
+				assert.Nil(t, session)
+			} else {
+				assert.Equal(t, tt.ids, session.WordIDs)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWordService_GetWordsByIDs(t *testing.T) {
+	mockRepo := new(testutil.MockWordRepository)
+	mockRepo.On("GetWordsByIDs", mock.Anything, int64(123), []int{3, 1, 2}).Return([]domain.Word{
+		{ID: 1, Word: "one"},
+		{ID: 3, Word: "three"},
+	}, nil)
+
+	service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
+
+	words, err := service.GetWordsByIDs(context.Background(), 123, []int{3, 1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Word{{ID: 3, Word: "three"}, {ID: 1, Word: "one"}}, words)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWordService_SnoozeWord_InvalidDuration(t *testing.T) {
+	mockRepo := new(testutil.MockWordRepository)
+	service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
+
+	assert.Error(t, service.SnoozeWord(context.Background(), 123, 1, 0))
+	assert.Error(t, service.SnoozeWord(context.Background(), 123, 1, 400*24*time.Hour))
+	mockRepo.AssertNotCalled(t, "SnoozeWord", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWordService_SetExample(t *testing.T) {
+	tests := []struct {
+		name    string
+		example string
+		stored  string
+		wantErr error
+	}{
+		{name: "stored trimmed", example: "  I like apples ", stored: "I like apples"},
+		{name: "inflected", example: "She likes apples", stored: "She likes apples"},
+		{name: "no word", example: "I love apples", wantErr: ErrExampleMismatch},
+		{name: "too long", example: "like " + strings.Repeat("a", MaxExampleLength), wantErr: ErrExampleTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(testutil.MockWordRepository)
+			if tt.wantErr == nil {
+				mockRepo.On("SetExample", mock.Anything, int64(123), 1, tt.stored).Return(nil)
+			}
+
+			service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
+			err := service.SetExample(context.Background(), 123, 1, "like", tt.example)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWordService_SetExample_Empty(t *testing.T) {
+	mockRepo := new(testutil.MockWordRepository)
+	service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
+
+	assert.Error(t, service.SetExample(context.Background(), 123, 1, "like", "   "))
+	mockRepo.AssertNotCalled(t, "SetExample", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWordService_NextCloze(t *testing.T) {
+	mockRepo := new(testutil.MockWordRepository)
+	mockRepo.On("GetRandomExampleWords", mock.Anything, int64(123), domain.DefaultLanguagePair, 5).Return([]domain.Word{
+		// Swapped after the example was added: skipped
+		{ID: 1, Word: "нравиться", Translation: "like", Example: "I like apples"},
+		{ID: 2, Word: "run", Translation: "бегать", Example: "She is running late"},
+	}, nil)
+
+	service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
+	word, card, err := service.NextCloze(context.Background(), 123, domain.DefaultLanguagePair)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, word.ID)
+	assert.Equal(t, "She is ＿＿＿ late", card.Text)
+	assert.Equal(t, "running", card.Answer)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWordService_NextCloze_None(t *testing.T) {
+	mockRepo := new(testutil.MockWordRepository)
+	mockRepo.On("GetRandomExampleWords", mock.Anything, int64(123), domain.DefaultLanguagePair, 5).Return(nil, nil)
+
+	service := NewWordService(mockRepo, NewEventBus(nil, testutil.NewTestLogger()))
+	word, card, err := service.NextCloze(context.Background(), 123, domain.DefaultLanguagePair)
+
+	assert.NoError(t, err)
+	assert.Nil(t, word)
+	assert.Nil(t, card)
+}
@@ -0,0 +1,133 @@
+package shutdown
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Coordinator tracks in-flight handlers and background jobs
+// so that shutdown can wait for them instead of cutting them off.
+type Coordinator struct {
+	mu       sync.Mutex
+	nextID   uint64
+	handlers map[uint64]string
+	jobs     map[uint64]string
+	changed  chan struct{} // closed and replaced whenever something finishes
+}
+
+// Report describes the outcome of waiting for in-flight work
+type Report struct {
+	Elapsed           time.Duration
+	AbandonedHandlers []string
+	AbandonedJobs     []string
+}
+
+// Clean reports whether everything finished before the deadline
+func (r Report) Clean() bool {
+	return len(r.AbandonedHandlers) == 0 && len(r.AbandonedJobs) == 0
+}
+
+// NewCoordinator creates an empty coordinator
+func NewCoordinator() *Coordinator {
+	return &Coordinator{
+		handlers: make(map[uint64]string),
+		jobs:     make(map[uint64]string),
+		changed:  make(chan struct{}),
+	}
+}
+
+// Track marks the start of an in-flight handler.
+// The returned function must be called when the handler returns.
+func (c *Coordinator) Track(name string) (done func()) {
+	id := c.add(c.handlers, name)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { c.remove(c.handlers, id) })
+	}
+}
+
+// Go runs a background job in a goroutine and tracks it until it returns
+func (c *Coordinator) Go(name string, job func()) {
+	id := c.add(c.jobs, name)
+
+	go func() {
+		defer c.remove(c.jobs, id)
+		job()
+	}()
+}
+
+// InFlight returns the number of running handlers and jobs
+func (c *Coordinator) InFlight() (handlers, jobs int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.handlers), len(c.jobs)
+}
+
+// Wait blocks until all tracked work finishes or ctx is done.
+// Whatever is still running at that point is listed in the report.
+func (c *Coordinator) Wait(ctx context.Context) Report {
+	start := time.Now()
+
+	for {
+		c.mu.Lock()
+		pending := len(c.handlers) + len(c.jobs)
+		changed := c.changed
+		c.mu.Unlock()
+
+		if pending == 0 {
+			return Report{Elapsed: time.Since(start)}
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return Report{
+				Elapsed:           time.Since(start),
+				AbandonedHandlers: names(c.handlers),
+				AbandonedJobs:     names(c.jobs),
+			}
+		}
+	}
+}
+
+func (c *Coordinator) add(set map[uint64]string, name string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextID++
+	set[c.nextID] = name
+	return c.nextID
+}
+
+func (c *Coordinator) remove(set map[uint64]string, id uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(set, id)
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// names returns sorted names, counting duplicates like "view_days x2"
+func names(set map[uint64]string) []string {
+	counts := make(map[string]int)
+	for _, name := range set {
+		counts[name]++
+	}
+
+	result := make([]string, 0, len(counts))
+	for name, n := range counts {
+		if n > 1 {
+			name = fmt.Sprintf("%s x%d", name, n)
+		}
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
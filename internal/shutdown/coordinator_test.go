@@ -0,0 +1,78 @@
+package shutdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoordinator_WaitWithNothingInFlight(t *testing.T) {
+	c := NewCoordinator()
+
+	report := c.Wait(context.Background())
+
+	assert.True(t, report.Clean())
+}
+
+func TestCoordinator_WaitsForHandlersAndJobs(t *testing.T) {
+	c := NewCoordinator()
+
+	done := c.Track("save_word")
+	release := make(chan struct{})
+	c.Go("cleanup", func() { <-release })
+
+	handlers, jobs := c.InFlight()
+	assert.Equal(t, 1, handlers)
+	assert.Equal(t, 1, jobs)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		done()
+		close(release)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	report := c.Wait(ctx)
+
+	assert.True(t, report.Clean())
+	handlers, jobs = c.InFlight()
+	assert.Equal(t, 0, handlers)
+	assert.Equal(t, 0, jobs)
+}
+
+func TestCoordinator_ReportsAbandonedWork(t *testing.T) {
+	c := NewCoordinator()
+
+	c.Track("random_pair")
+	c.Track("random_pair")
+	c.Track("view_days")
+	finished := c.Track("cancel")
+	finished()
+	c.Go("cleanup", func() { select {} })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	report := c.Wait(ctx)
+
+	assert.False(t, report.Clean())
+	assert.Equal(t, []string{"random_pair x2", "view_days"}, report.AbandonedHandlers)
+	assert.Equal(t, []string{"cleanup"}, report.AbandonedJobs)
+	assert.GreaterOrEqual(t, report.Elapsed, 20*time.Millisecond)
+}
+
+func TestCoordinator_DoneIsIdempotent(t *testing.T) {
+	c := NewCoordinator()
+
+	done := c.Track("a")
+	c.Track("b")
+	done()
+	done()
+
+	handlers, _ := c.InFlight()
+	assert.Equal(t, 1, handlers)
+}
@@ -1,6 +1,7 @@
 package testutil
 
 import (
+	"context"
 	"languager/internal/domain"
 	"time"
 
@@ -12,18 +13,23 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) IsAuthorized(userID int64) (bool, error) {
-	args := m.Called(userID)
+func (m *MockUserRepository) IsAuthorized(ctx context.Context, userID int64) (bool, error) {
+	args := m.Called(ctx, userID)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockUserRepository) AuthorizeUser(userID int64) error {
-	args := m.Called(userID)
+func (m *MockUserRepository) AuthorizeUser(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) EnsureUserExists(userID int64) error {
-	args := m.Called(userID)
+func (m *MockUserRepository) RevokeUser(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) EnsureUserExists(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
 
@@ -32,52 +38,522 @@ type MockWordRepository struct {
 	mock.Mock
 }
 
-func (m *MockWordRepository) SaveWord(userID int64, word, translation string) error {
-	args := m.Called(userID, word, translation)
-	return args.Error(0)
+func (m *MockWordRepository) SaveWord(ctx context.Context, userID int64, pair domain.LanguagePair, word, translation string) (int, error) {
+	args := m.Called(ctx, userID, pair, word, translation)
+	return args.Int(0), args.Error(1)
 }
 
-func (m *MockWordRepository) GetRandomWord(userID int64) (*domain.Word, error) {
-	args := m.Called(userID)
+func (m *MockWordRepository) SwapWord(ctx context.Context, userID int64, wordID int) (*domain.Word, error) {
+	args := m.Called(ctx, userID, wordID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Word), args.Error(1)
 }
 
-func (m *MockWordRepository) GetDaysWithWords(userID int64, limit, offset int) ([]domain.Day, error) {
-	args := m.Called(userID, limit, offset)
+func (m *MockWordRepository) GetRandomWord(ctx context.Context, userID int64, pair domain.LanguagePair) (*domain.Word, error) {
+	args := m.Called(ctx, userID, pair)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) GetDaysWithWords(ctx context.Context, userID int64, pair domain.LanguagePair, historyDays, limit, offset int, loc *time.Location) ([]domain.Day, error) {
+	args := m.Called(ctx, userID, pair, historyDays, limit, offset, loc)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.Day), args.Error(1)
 }
 
-func (m *MockWordRepository) GetWordsByDate(userID int64, date time.Time) ([]domain.Word, error) {
-	args := m.Called(userID, date)
+func (m *MockWordRepository) GetWordsByDate(ctx context.Context, userID int64, pair domain.LanguagePair, date time.Time, loc *time.Location) ([]domain.Word, error) {
+	args := m.Called(ctx, userID, pair, date, loc)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.Word), args.Error(1)
 }
 
-func (m *MockWordRepository) CleanOldWords(days int) error {
-	args := m.Called(days)
+func (m *MockWordRepository) CleanOldWords(ctx context.Context, defaultDays int) (int64, error) {
+	args := m.Called(ctx, defaultDays)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWordRepository) GetTotalDaysCount(ctx context.Context, userID int64, pair domain.LanguagePair, historyDays int, loc *time.Location) (int, error) {
+	args := m.Called(ctx, userID, pair, historyDays, loc)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWordRepository) SnoozeWord(ctx context.Context, userID int64, wordID int, duration time.Duration) error {
+	args := m.Called(ctx, userID, wordID, duration)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) HideWordForever(ctx context.Context, userID int64, wordID int) error {
+	args := m.Called(ctx, userID, wordID)
 	return args.Error(0)
 }
 
-func (m *MockWordRepository) GetTotalDaysCount(userID int64) (int, error) {
-	args := m.Called(userID)
+func (m *MockWordRepository) CountDueWords(ctx context.Context, userID int64, pair domain.LanguagePair) (int, error) {
+	args := m.Called(ctx, userID, pair)
 	return args.Int(0), args.Error(1)
 }
 
-func (m *MockWordRepository) HideWordFor7Days(wordID int) error {
-	args := m.Called(wordID)
+func (m *MockWordRepository) RecordReview(ctx context.Context, userID int64, wordID int, correct bool, direction domain.ReviewDirection) (bool, error) {
+	args := m.Called(ctx, userID, wordID, correct, direction)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWordRepository) GetRandomProblemWord(ctx context.Context, userID int64, pair domain.LanguagePair) (*domain.Word, error) {
+	args := m.Called(ctx, userID, pair)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) GetRandomWordIDs(ctx context.Context, userID int64, pair domain.LanguagePair, limit int) ([]int, error) {
+	args := m.Called(ctx, userID, pair, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockWordRepository) GetLanguagePairs(ctx context.Context, userID int64) ([]domain.LanguagePair, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.LanguagePair), args.Error(1)
+}
+
+func (m *MockWordRepository) SetExample(ctx context.Context, userID int64, wordID int, example string) error {
+	args := m.Called(ctx, userID, wordID, example)
 	return args.Error(0)
 }
 
-func (m *MockWordRepository) HideWordForever(wordID int) error {
-	args := m.Called(wordID)
+func (m *MockWordRepository) GetRandomExampleWords(ctx context.Context, userID int64, pair domain.LanguagePair, limit int) ([]domain.Word, error) {
+	args := m.Called(ctx, userID, pair, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) SearchWords(ctx context.Context, userID int64, query string, limit int) ([]domain.Word, error) {
+	args := m.Called(ctx, userID, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) GetWordsByIDs(ctx context.Context, userID int64, ids []int) ([]domain.Word, error) {
+	args := m.Called(ctx, userID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Word), args.Error(1)
+}
+
+// MockStateRepository is a mock for StateRepository
+type MockStateRepository struct {
+	mock.Mock
+}
+
+func (m *MockStateRepository) LoadStates(ctx context.Context) (map[int64]*domain.StateData, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]*domain.StateData), args.Error(1)
+}
+
+func (m *MockStateRepository) SaveStates(ctx context.Context, states map[int64]*domain.StateData) error {
+	args := m.Called(ctx, states)
+	return args.Error(0)
+}
+
+// MockReminderRepository is a mock for ReminderRepository
+type MockReminderRepository struct {
+	mock.Mock
+}
+
+func (m *MockReminderRepository) GetReminderSettings(ctx context.Context, userID int64) (*domain.ReminderSettings, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReminderSettings), args.Error(1)
+}
+
+func (m *MockReminderRepository) SaveReminderSettings(ctx context.Context, settings *domain.ReminderSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) ListActiveReminders(ctx context.Context) ([]domain.ReminderSettings, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReminderSettings), args.Error(1)
+}
+
+func (m *MockReminderRepository) ClaimDelivery(ctx context.Context, userID int64, scheduledFor time.Time) (bool, error) {
+	args := m.Called(ctx, userID, scheduledFor)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReminderRepository) ReleaseDelivery(ctx context.Context, userID int64, scheduledFor time.Time) error {
+	args := m.Called(ctx, userID, scheduledFor)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) CleanOldDeliveries(ctx context.Context, before time.Time) error {
+	args := m.Called(ctx, before)
+	return args.Error(0)
+}
+
+// MockStreakRepository is a mock for StreakRepository
+type MockStreakRepository struct {
+	mock.Mock
+}
+
+func (m *MockStreakRepository) AddActivity(ctx context.Context, userID int64, day time.Time, delta domain.Activity) (domain.Activity, error) {
+	args := m.Called(ctx, userID, day, delta)
+	return args.Get(0).(domain.Activity), args.Error(1)
+}
+
+func (m *MockStreakRepository) GetActivity(ctx context.Context, userID int64, day time.Time) (domain.Activity, error) {
+	args := m.Called(ctx, userID, day)
+	return args.Get(0).(domain.Activity), args.Error(1)
+}
+
+func (m *MockStreakRepository) GetStreak(ctx context.Context, userID int64) (*domain.Streak, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Streak), args.Error(1)
+}
+
+func (m *MockStreakRepository) SaveStreak(ctx context.Context, streak *domain.Streak) error {
+	args := m.Called(ctx, streak)
+	return args.Error(0)
+}
+
+// MockStatsRepository is a mock for StatsRepository
+type MockStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatsRepository) GetWordTotals(ctx context.Context, userID int64, pair domain.LanguagePair) (domain.WordTotals, error) {
+	args := m.Called(ctx, userID, pair)
+	return args.Get(0).(domain.WordTotals), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetReviewTotals(ctx context.Context, userID int64, pair domain.LanguagePair) (int, int, error) {
+	args := m.Called(ctx, userID, pair)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockStatsRepository) GetAddedPerDay(ctx context.Context, userID int64, pair domain.LanguagePair, from time.Time, loc *time.Location) ([]domain.DailyCount, error) {
+	args := m.Called(ctx, userID, pair, from, loc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DailyCount), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetReviewsPerDay(ctx context.Context, userID int64, pair domain.LanguagePair, from time.Time, loc *time.Location) ([]domain.DailyReviews, error) {
+	args := m.Called(ctx, userID, pair, from, loc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DailyReviews), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetActivityPerDay(ctx context.Context, userID int64, from time.Time) ([]domain.DailyCount, error) {
+	args := m.Called(ctx, userID, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DailyCount), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetReviewsByDirection(ctx context.Context, userID int64, pair domain.LanguagePair) ([]domain.DirectionReviews, error) {
+	args := m.Called(ctx, userID, pair)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DirectionReviews), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetHardestWords(ctx context.Context, userID int64, pair domain.LanguagePair, limit int) ([]domain.HardWord, error) {
+	args := m.Called(ctx, userID, pair, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.HardWord), args.Error(1)
+}
+
+// MockSettingsRepository is a mock for SettingsRepository
+type MockSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *MockSettingsRepository) GetSettings(ctx context.Context, userID int64) (map[string]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockSettingsRepository) SetSetting(ctx context.Context, userID int64, key, value string) error {
+	args := m.Called(ctx, userID, key, value)
 	return args.Error(0)
 }
 
+// MockReportRepository is a mock for ReportRepository
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) IsSubscribed(ctx context.Context, userID int64) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReportRepository) SetSubscribed(ctx context.Context, userID int64, subscribed bool) error {
+	args := m.Called(ctx, userID, subscribed)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) ListSubscribers(ctx context.Context) ([]int64, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockReportRepository) ClaimReport(ctx context.Context, userID int64, weekStart time.Time) (bool, error) {
+	args := m.Called(ctx, userID, weekStart)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReportRepository) ReleaseReport(ctx context.Context, userID int64, weekStart time.Time) error {
+	args := m.Called(ctx, userID, weekStart)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) CleanOldReports(ctx context.Context, before time.Time) error {
+	args := m.Called(ctx, before)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) GetPeriodActivity(ctx context.Context, userID int64, from, to time.Time) (int, int, int, error) {
+	args := m.Called(ctx, userID, from, to)
+	return args.Int(0), args.Int(1), args.Int(2), args.Error(3)
+}
+
+func (m *MockReportRepository) GetMasteredWords(ctx context.Context, userID int64, from, to time.Time) ([]domain.Word, error) {
+	args := m.Called(ctx, userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Word), args.Error(1)
+}
+
+func (m *MockReportRepository) GetFailingWords(ctx context.Context, userID int64, from, to time.Time, limit int) ([]domain.HardWord, error) {
+	args := m.Called(ctx, userID, from, to, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.HardWord), args.Error(1)
+}
+
+// MockDeckRepository is a mock for DeckRepository
+type MockDeckRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeckRepository) CreateDeck(ctx context.Context, deck *domain.Deck) error {
+	args := m.Called(ctx, deck)
+	return args.Error(0)
+}
+
+func (m *MockDeckRepository) GetDeckByCode(ctx context.Context, code string) (*domain.Deck, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Deck), args.Error(1)
+}
+
+func (m *MockDeckRepository) ListOwnDecks(ctx context.Context, ownerID int64) ([]domain.Deck, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Deck), args.Error(1)
+}
+
+func (m *MockDeckRepository) ListSubscribedDecks(ctx context.Context, userID int64) ([]domain.Deck, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Deck), args.Error(1)
+}
+
+func (m *MockDeckRepository) DeleteDeck(ctx context.Context, deckID int) error {
+	args := m.Called(ctx, deckID)
+	return args.Error(0)
+}
+
+func (m *MockDeckRepository) GetSubscription(ctx context.Context, deckID int, userID int64) (*domain.DeckSubscription, error) {
+	args := m.Called(ctx, deckID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DeckSubscription), args.Error(1)
+}
+
+func (m *MockDeckRepository) SaveSubscription(ctx context.Context, sub domain.DeckSubscription) error {
+	args := m.Called(ctx, sub)
+	return args.Error(0)
+}
+
+func (m *MockDeckRepository) DeleteSubscription(ctx context.Context, deckID int, userID int64) error {
+	args := m.Called(ctx, deckID, userID)
+	return args.Error(0)
+}
+
+func (m *MockDeckRepository) CopyDeckWords(ctx context.Context, deckID int, userID int64) (int, error) {
+	args := m.Called(ctx, deckID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockDeckRepository) AddWordToDecks(ctx context.Context, ownerID int64, pair domain.LanguagePair, wordID int) ([]int, error) {
+	args := m.Called(ctx, ownerID, pair, wordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockDeckRepository) CopyWordToFollowers(ctx context.Context, deckID, wordID int) (int, error) {
+	args := m.Called(ctx, deckID, wordID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockGroupRepository is a mock for GroupRepository
+type MockGroupRepository struct {
+	mock.Mock
+}
+
+func (m *MockGroupRepository) SaveGroup(ctx context.Context, chatID int64, title string, enabledBy int64) error {
+	args := m.Called(ctx, chatID, title, enabledBy)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) GroupExists(ctx context.Context, chatID int64) (bool, error) {
+	args := m.Called(ctx, chatID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGroupRepository) AddWord(ctx context.Context, word *domain.GroupWord) (bool, error) {
+	args := m.Called(ctx, word)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGroupRepository) GetRandomWords(ctx context.Context, chatID int64, limit int) ([]domain.GroupWord, error) {
+	args := m.Called(ctx, chatID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.GroupWord), args.Error(1)
+}
+
+func (m *MockGroupRepository) CreateQuiz(ctx context.Context, chatID int64, wordID int) (int, error) {
+	args := m.Called(ctx, chatID, wordID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockGroupRepository) GetQuiz(ctx context.Context, chatID int64, quizID int) (*domain.GroupQuiz, error) {
+	args := m.Called(ctx, chatID, quizID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GroupQuiz), args.Error(1)
+}
+
+func (m *MockGroupRepository) RecordAttempt(ctx context.Context, quizID int, userID int64) (bool, error) {
+	args := m.Called(ctx, quizID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGroupRepository) ClaimWin(ctx context.Context, chatID int64, quizID int, userID int64, name string) (bool, error) {
+	args := m.Called(ctx, chatID, quizID, userID, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGroupRepository) GetTopScores(ctx context.Context, chatID int64, limit int) ([]domain.GroupScore, error) {
+	args := m.Called(ctx, chatID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.GroupScore), args.Error(1)
+}
+
+// MockAchievementRepository is a mock for AchievementRepository
+type MockAchievementRepository struct {
+	mock.Mock
+}
+
+func (m *MockAchievementRepository) GetProgress(ctx context.Context, userID int64) (domain.AchievementProgress, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.AchievementProgress), args.Error(1)
+}
+
+func (m *MockAchievementRepository) ListEarned(ctx context.Context, userID int64) (map[string]time.Time, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]time.Time), args.Error(1)
+}
+
+func (m *MockAchievementRepository) SaveEarned(ctx context.Context, userID int64, ids []string) ([]string, error) {
+	args := m.Called(ctx, userID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockAuditRepository is a mock for AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Save(ctx context.Context, entry *domain.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
@@ -0,0 +1,2 @@
+-- Remove persisted user states
+DROP TABLE IF EXISTS user_states;
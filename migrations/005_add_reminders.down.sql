@@ -0,0 +1,3 @@
+-- Remove review reminders
+DROP TABLE IF EXISTS reminder_deliveries;
+DROP TABLE IF EXISTS reminder_settings;
@@ -0,0 +1,21 @@
+-- Review reminders
+
+CREATE TABLE IF NOT EXISTS reminder_settings (
+    user_id BIGINT PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    times INTEGER[] NOT NULL DEFAULT '{}',
+    days SMALLINT NOT NULL DEFAULT 127,
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per sent reminder; the primary key prevents double delivery
+CREATE TABLE IF NOT EXISTS reminder_deliveries (
+    user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, scheduled_for)
+);
+
+COMMENT ON COLUMN reminder_settings.times IS 'Reminder times in minutes after local midnight';
+COMMENT ON COLUMN reminder_settings.days IS 'Weekday bit mask, bit 0 = Sunday';
+COMMENT ON TABLE reminder_deliveries IS 'Claimed reminder slots, used to make the scheduler restart-safe';
@@ -0,0 +1,3 @@
+-- Remove study streaks
+DROP TABLE IF EXISTS user_streaks;
+DROP TABLE IF EXISTS user_activity;
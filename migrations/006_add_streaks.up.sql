@@ -0,0 +1,23 @@
+-- Study streaks and daily goals
+
+-- Words added and reviewed per user per day (day in the bot's time zone)
+CREATE TABLE IF NOT EXISTS user_activity (
+    user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    words_added INTEGER NOT NULL DEFAULT 0,
+    reviews INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day)
+);
+
+CREATE TABLE IF NOT EXISTS user_streaks (
+    user_id BIGINT PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    current_streak INTEGER NOT NULL DEFAULT 0,
+    best_streak INTEGER NOT NULL DEFAULT 0,
+    last_goal_day DATE,
+    daily_goal INTEGER NOT NULL DEFAULT 5,
+    freeze_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    freeze_used_on DATE
+);
+
+COMMENT ON COLUMN user_streaks.last_goal_day IS 'Last day the daily goal was reached';
+COMMENT ON COLUMN user_streaks.freeze_used_on IS 'Missed day covered by the last freeze, one per week';
@@ -0,0 +1,4 @@
+-- Remove graded reviews
+ALTER TABLE words DROP COLUMN IF EXISTS mastered_at;
+ALTER TABLE words DROP COLUMN IF EXISTS correct_streak;
+DROP TABLE IF EXISTS reviews;
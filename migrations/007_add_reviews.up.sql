@@ -0,0 +1,19 @@
+-- Graded reviews and word mastery
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    word_id INTEGER NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    correct BOOLEAN NOT NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_user_date ON reviews(user_id, reviewed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_reviews_word ON reviews(word_id);
+
+-- Consecutive correct answers; a word is mastered after 3 in a row
+ALTER TABLE words ADD COLUMN IF NOT EXISTS correct_streak INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE words ADD COLUMN IF NOT EXISTS mastered_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN words.correct_streak IS 'Consecutive correct reviews, reset on a failure';
+COMMENT ON COLUMN words.mastered_at IS 'When the word was first answered correctly 3 times in a row';
@@ -0,0 +1,4 @@
+-- Remove weekly learning reports
+DROP INDEX IF EXISTS idx_words_user_mastered;
+DROP TABLE IF EXISTS weekly_report_deliveries;
+DROP TABLE IF EXISTS weekly_report_subscriptions;
@@ -0,0 +1,19 @@
+-- Weekly learning reports
+
+-- A row means the user opted in to weekly reports
+CREATE TABLE IF NOT EXISTS weekly_report_subscriptions (
+    user_id BIGINT PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    subscribed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per sent report; the primary key prevents double delivery
+CREATE TABLE IF NOT EXISTS weekly_report_deliveries (
+    user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    week_start DATE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, week_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_words_user_mastered ON words(user_id, mastered_at) WHERE mastered_at IS NOT NULL;
+
+COMMENT ON COLUMN weekly_report_deliveries.week_start IS 'Monday of the reported week';
@@ -0,0 +1,3 @@
+-- Remove per-user preferences and review direction
+ALTER TABLE reviews DROP COLUMN IF EXISTS direction;
+DROP TABLE IF EXISTS user_settings;
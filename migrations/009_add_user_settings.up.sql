@@ -0,0 +1,15 @@
+-- Per-user preferences and review direction
+
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, key)
+);
+
+-- NULL for reviews graded before directions were tracked
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS direction TEXT;
+
+COMMENT ON TABLE user_settings IS 'User preferences; missing keys fall back to defaults';
+COMMENT ON COLUMN reviews.direction IS 'forward: word shown, translation recalled; reverse: the other way round';
@@ -0,0 +1,4 @@
+-- Remove language pairs
+DROP INDEX IF EXISTS idx_words_user_pair;
+ALTER TABLE words DROP COLUMN IF EXISTS target_lang;
+ALTER TABLE words DROP COLUMN IF EXISTS source_lang;
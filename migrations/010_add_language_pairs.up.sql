@@ -0,0 +1,10 @@
+-- Language pairs: every word belongs to a source and a target language
+
+-- Existing words are assigned to the default pair English → Russian
+ALTER TABLE words ADD COLUMN IF NOT EXISTS source_lang TEXT NOT NULL DEFAULT 'en';
+ALTER TABLE words ADD COLUMN IF NOT EXISTS target_lang TEXT NOT NULL DEFAULT 'ru';
+
+CREATE INDEX IF NOT EXISTS idx_words_user_pair ON words(user_id, source_lang, target_lang, created_at DESC);
+
+COMMENT ON COLUMN words.source_lang IS 'ISO 639-1 code of the word language';
+COMMENT ON COLUMN words.target_lang IS 'ISO 639-1 code of the translation language';
@@ -0,0 +1,4 @@
+-- Remove example sentences
+ALTER TABLE words DROP COLUMN IF EXISTS example;
+
+COMMENT ON COLUMN reviews.direction IS 'forward: word shown, translation recalled; reverse: the other way round';
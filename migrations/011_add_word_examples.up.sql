@@ -0,0 +1,6 @@
+-- Example sentences: cloze cards blank the word out of its example
+
+ALTER TABLE words ADD COLUMN IF NOT EXISTS example TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN words.example IS 'Example sentence using the word; empty if none';
+COMMENT ON COLUMN reviews.direction IS 'forward: word shown, translation recalled; reverse: the other way round; cloze: word typed into its example';
@@ -0,0 +1,3 @@
+-- Remove inline search indexes; the extension is kept, other objects may use it
+DROP INDEX IF EXISTS idx_words_translation_trgm;
+DROP INDEX IF EXISTS idx_words_word_trgm;
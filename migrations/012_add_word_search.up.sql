@@ -0,0 +1,12 @@
+-- Inline search: trigram indexes make substring search (LIKE '%app%') fast.
+--
+-- PREREQUISITE: creating pg_trgm needs CREATE on the database (PostgreSQL 13+,
+-- pg_trgm is a trusted extension) or a superuser. On managed or restricted
+-- PostgreSQL, have an administrator run this once before the first start:
+--   CREATE EXTENSION IF NOT EXISTS pg_trgm;
+-- Otherwise this migration fails and no later migration is applied.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_words_word_trgm ON words USING GIN (LOWER(word) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_words_translation_trgm ON words USING GIN (LOWER(translation) gin_trgm_ops);
@@ -0,0 +1,6 @@
+-- Remove shared decks
+DROP INDEX IF EXISTS idx_words_user_copied_from;
+ALTER TABLE words DROP COLUMN IF EXISTS copied_from;
+DROP TABLE IF EXISTS deck_subscriptions;
+DROP TABLE IF EXISTS deck_words;
+DROP TABLE IF EXISTS decks;
@@ -0,0 +1,38 @@
+-- Shared decks: a user publishes the words of a language pair under a share code,
+-- others copy them or subscribe
+
+CREATE TABLE IF NOT EXISTS decks (
+    id SERIAL PRIMARY KEY,
+    owner_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    code TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    source_lang TEXT NOT NULL,
+    target_lang TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (owner_id, source_lang, target_lang)
+);
+
+CREATE TABLE IF NOT EXISTS deck_words (
+    deck_id INTEGER NOT NULL REFERENCES decks(id) ON DELETE CASCADE,
+    word_id INTEGER NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    PRIMARY KEY (deck_id, word_id)
+);
+
+CREATE TABLE IF NOT EXISTS deck_subscriptions (
+    deck_id INTEGER NOT NULL REFERENCES decks(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    follow BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (deck_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_deck_subscriptions_user ON deck_subscriptions(user_id);
+
+-- Copies of deck words remember their original, so that a word is copied once
+ALTER TABLE words ADD COLUMN IF NOT EXISTS copied_from INTEGER REFERENCES words(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_words_user_copied_from ON words(user_id, copied_from) WHERE copied_from IS NOT NULL;
+
+COMMENT ON TABLE decks IS 'Published language pairs of a user, one deck per pair';
+COMMENT ON COLUMN deck_subscriptions.follow IS 'Copy words the owner adds later';
+COMMENT ON COLUMN words.copied_from IS 'Deck word this word was copied from';
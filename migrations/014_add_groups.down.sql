@@ -0,0 +1,6 @@
+-- Remove group chats
+DROP TABLE IF EXISTS group_scores;
+DROP TABLE IF EXISTS group_quiz_attempts;
+DROP TABLE IF EXISTS group_quizzes;
+DROP TABLE IF EXISTS group_words;
+DROP TABLE IF EXISTS group_chats;
@@ -0,0 +1,50 @@
+-- Group chats: a shared vocabulary of the group, quizzes and a leaderboard.
+-- Members of a group aren't necessarily users of the bot, so user IDs here
+-- are Telegram IDs without a reference to users.
+
+CREATE TABLE IF NOT EXISTS group_chats (
+    chat_id BIGINT PRIMARY KEY,
+    title TEXT NOT NULL DEFAULT '',
+    enabled_by BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS group_words (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES group_chats(chat_id) ON DELETE CASCADE,
+    added_by BIGINT NOT NULL,
+    word TEXT NOT NULL,
+    translation TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_group_words_unique ON group_words(chat_id, LOWER(word), LOWER(translation));
+
+CREATE TABLE IF NOT EXISTS group_quizzes (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES group_chats(chat_id) ON DELETE CASCADE,
+    word_id INTEGER NOT NULL REFERENCES group_words(id) ON DELETE CASCADE,
+    winner_id BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    solved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS group_quiz_attempts (
+    quiz_id INTEGER NOT NULL REFERENCES group_quizzes(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    PRIMARY KEY (quiz_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS group_scores (
+    chat_id BIGINT NOT NULL REFERENCES group_chats(chat_id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    points INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (chat_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_scores_points ON group_scores(chat_id, points DESC);
+
+COMMENT ON TABLE group_chats IS 'Group chats the bot was enabled in by an authorized user';
+COMMENT ON COLUMN group_quizzes.winner_id IS 'First member to answer correctly, NULL while unsolved';
+COMMENT ON TABLE group_quiz_attempts IS 'One answer per member and quiz';
@@ -0,0 +1,2 @@
+-- Remove achievements
+DROP TABLE IF EXISTS user_achievements;
@@ -0,0 +1,10 @@
+-- Achievements earned by users; definitions live in the code (domain.Achievements)
+
+CREATE TABLE IF NOT EXISTS user_achievements (
+    user_id BIGINT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    achievement TEXT NOT NULL,
+    earned_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, achievement)
+);
+
+COMMENT ON COLUMN user_achievements.achievement IS 'ID of the achievement definition';
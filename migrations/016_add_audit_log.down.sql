@@ -0,0 +1,2 @@
+-- Remove audit log
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,17 @@
+-- Audit log of security-relevant and data-changing actions
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT NOT NULL,
+    action TEXT NOT NULL,
+    target TEXT NOT NULL DEFAULT '',
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_target ON audit_log(target, created_at DESC);
+
+COMMENT ON COLUMN audit_log.actor_id IS 'Telegram user ID, 0 for background jobs; not a reference, so entries outlive users';
+COMMENT ON COLUMN audit_log.target IS 'What the action was done to, e.g. user:42, word:7, deck:abcd2345';
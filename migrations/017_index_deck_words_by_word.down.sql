@@ -0,0 +1,2 @@
+-- Remove deck words index by word
+DROP INDEX IF EXISTS idx_deck_words_word;
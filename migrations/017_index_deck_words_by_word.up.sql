@@ -0,0 +1,3 @@
+-- Cleanup keeps words of published decks and looks them up by word
+
+CREATE INDEX IF NOT EXISTS idx_deck_words_word ON deck_words(word_id);
@@ -0,0 +1,2 @@
+-- Remove words index by original
+DROP INDEX IF EXISTS idx_words_copied_from;
@@ -0,0 +1,3 @@
+-- Cleanup keeps words of decks someone copied from and looks copies up by original
+
+CREATE INDEX IF NOT EXISTS idx_words_copied_from ON words(copied_from) WHERE copied_from IS NOT NULL;
@@ -55,6 +55,15 @@ else
     exit 1
 fi
 
+# Check readiness endpoint (database and Telegram poller)
+if docker exec $SERVICE_NAME wget -qO- http://localhost:8080/readyz > /dev/null 2>&1; then
+    echo "✅ Readiness check passed"
+else
+    echo "❌ Readiness check failed:"
+    docker exec $SERVICE_NAME wget -qO- http://localhost:8080/readyz 2>&1 | tail -2
+    exit 1
+fi
+
 # Check container restart count
 RESTART_COUNT=$(docker inspect --format='{{.RestartCount}}' $SERVICE_NAME)
 if [ "$RESTART_COUNT" -gt 3 ]; then